@@ -8,8 +8,10 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/database/rpcdb"
+	"github.com/MetalBlockchain/metalgo/database/usage"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 )
@@ -31,6 +33,13 @@ type Client interface {
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	// DBIterate returns up to [limit] key-value pairs starting at [start]
+	// with [prefix], along with the start of the next page. A nil [start]
+	// starts from the first key, or the last key if [reverse] is set, while
+	// an empty [start] is the empty key. The returned start is nil once the
+	// iteration is complete.
+	DBIterate(ctx context.Context, start, prefix []byte, limit uint32, reverse bool, options ...rpc.Option) ([]KeyValue, []byte, error)
+	DBUsage(ctx context.Context, walk bool, options ...rpc.Option) (map[string]usage.Stats, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -163,3 +172,88 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+// KeyValue is a key-value pair returned by DBIterate.
+type KeyValue struct {
+	Key   []byte
+	Value []byte
+}
+
+func (c *client) DBIterate(
+	ctx context.Context,
+	start []byte,
+	prefix []byte,
+	limit uint32,
+	reverse bool,
+	options ...rpc.Option,
+) ([]KeyValue, []byte, error) {
+	var startStr *string
+	if start != nil {
+		str, err := formatting.Encode(formatting.HexNC, start)
+		if err != nil {
+			return nil, nil, err
+		}
+		startStr = &str
+	}
+	prefixStr, err := formatting.Encode(formatting.HexNC, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &DBIterateReply{}
+	err = c.requester.SendRequest(ctx, "admin.dbIterate", &DBIterateArgs{
+		Start:   startStr,
+		Prefix:  prefixStr,
+		Limit:   json.Uint32(limit),
+		Reverse: reverse,
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyValues := make([]KeyValue, len(res.KeyValues))
+	for i, kv := range res.KeyValues {
+		keyValues[i].Key, err = formatting.Decode(formatting.HexNC, kv.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		keyValues[i].Value, err = formatting.Decode(formatting.HexNC, kv.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if res.NextStart == nil {
+		return keyValues, nil, nil
+	}
+
+	nextStart, err := formatting.Decode(formatting.HexNC, *res.NextStart)
+	if err != nil {
+		return nil, nil, err
+	}
+	if nextStart == nil {
+		// The next page may start at the empty key.
+		nextStart = []byte{}
+	}
+	return keyValues, nextStart, nil
+}
+
+func (c *client) DBUsage(ctx context.Context, walk bool, options ...rpc.Option) (map[string]usage.Stats, error) {
+	res := &DBUsageReply{}
+	err := c.requester.SendRequest(ctx, "admin.dbUsage", &DBUsageArgs{
+		Walk: walk,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]usage.Stats, len(res.Namespaces))
+	for name, namespace := range res.Namespaces {
+		stats[name] = usage.Stats{
+			Keys:               uint64(namespace.Keys),
+			KeyBytes:           uint64(namespace.KeyBytes),
+			ValueBytes:         uint64(namespace.ValueBytes),
+			EstimatedDiskBytes: uint64(namespace.EstimatedDiskBytes),
+		}
+	}
+	return stats, nil
+}
@@ -9,7 +9,12 @@ import (
 	"github.com/MetalBlockchain/metalgo/database"
 )
 
-var _ database.KeyValueReader = (*KeyValueReader)(nil)
+var (
+	_ database.KeyValueReader  = (*KeyValueReader)(nil)
+	_ database.Iteratee        = (*KeyValueReader)(nil)
+	_ database.ReverseIteratee = (*KeyValueReader)(nil)
+	_ database.Iterator        = (*iterator)(nil)
+)
 
 type KeyValueReader struct {
 	client Client
@@ -32,3 +37,108 @@ func (r *KeyValueReader) Has(key []byte) (bool, error) {
 func (r *KeyValueReader) Get(key []byte) ([]byte, error) {
 	return r.client.DBGet(context.Background(), key)
 }
+
+func (r *KeyValueReader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *KeyValueReader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *KeyValueReader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns an iterator that fetches pages of
+// key-value pairs from the node as they are needed.
+func (r *KeyValueReader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		client: r.client,
+		start:  start,
+		prefix: prefix,
+	}
+}
+
+func (r *KeyValueReader) NewReverseIterator() database.Iterator {
+	return r.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *KeyValueReader) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return r.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *KeyValueReader) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (r *KeyValueReader) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	// Like other databases, an empty start iterates from the last key. The
+	// node would otherwise interpret it as the empty key.
+	if len(start) == 0 {
+		start = nil
+	}
+	return &iterator{
+		client:  r.client,
+		start:   start,
+		prefix:  prefix,
+		reverse: true,
+	}
+}
+
+type iterator struct {
+	client  Client
+	prefix  []byte
+	reverse bool
+
+	// start is the start of the next page to fetch.
+	start []byte
+	// done is true once the last page has been fetched.
+	done bool
+
+	page       []KeyValue
+	key, value []byte
+	err        error
+}
+
+func (it *iterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			it.key = nil
+			it.value = nil
+			return false
+		}
+
+		// A limit of 0 requests the maximum page size.
+		it.page, it.start, it.err = it.client.DBIterate(
+			context.Background(),
+			it.start,
+			it.prefix,
+			0,
+			it.reverse,
+		)
+		it.done = it.start == nil
+	}
+
+	it.key = it.page[0].Key
+	it.value = it.page[0].Value
+	it.page = it.page[1:]
+	return true
+}
+
+func (it *iterator) Error() error {
+	return it.err
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.page = nil
+	it.done = true
+}
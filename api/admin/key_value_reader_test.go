@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/rpc"
+)
+
+// serviceRequester sends requests directly to an admin service.
+type serviceRequester struct {
+	admin *Admin
+}
+
+func (r *serviceRequester) SendRequest(_ context.Context, method string, params interface{}, reply interface{}, _ ...rpc.Option) error {
+	switch method {
+	case "admin.dbGet":
+		return r.admin.DbGet(&http.Request{}, params.(*DBGetArgs), reply.(*DBGetReply))
+	case "admin.dbIterate":
+		return r.admin.DbIterate(&http.Request{}, params.(*DBIterateArgs), reply.(*DBIterateReply))
+	default:
+		panic("illegal method")
+	}
+}
+
+func TestKeyValueReaderIterator(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  db,
+	}}
+	reader := NewKeyValueReader(&client{
+		requester: &serviceRequester{admin: a},
+	})
+
+	// Write enough keys to require multiple pages. When iterating in reverse,
+	// the last page starts at the empty key.
+	for i := 0; i < 2*maxDBIteratePageSize; i++ {
+		require.NoError(db.Put([]byte{byte(i >> 8), byte(i)}, []byte{byte(i)}))
+	}
+	require.NoError(db.Put([]byte{}, []byte("empty")))
+
+	tests := []struct {
+		name       string
+		newIter    func(database.Iteratee) database.Iterator
+		newRevIter func(database.ReverseIteratee) database.Iterator
+	}{
+		{
+			name: "all",
+			newIter: func(db database.Iteratee) database.Iterator {
+				return db.NewIterator()
+			},
+			newRevIter: func(db database.ReverseIteratee) database.Iterator {
+				return db.NewReverseIterator()
+			},
+		},
+		{
+			name: "start",
+			newIter: func(db database.Iteratee) database.Iterator {
+				return db.NewIteratorWithStart([]byte{0x01, 0x80})
+			},
+			newRevIter: func(db database.ReverseIteratee) database.Iterator {
+				return db.NewReverseIteratorWithStart([]byte{0x01, 0x80})
+			},
+		},
+		{
+			name: "prefix",
+			newIter: func(db database.Iteratee) database.Iterator {
+				return db.NewIteratorWithPrefix([]byte{0x01})
+			},
+			newRevIter: func(db database.ReverseIteratee) database.Iterator {
+				return db.NewReverseIteratorWithPrefix([]byte{0x01})
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requireEqualIterators(t, test.newIter(db), test.newIter(reader))
+			requireEqualIterators(t, test.newRevIter(db), test.newRevIter(reader))
+		})
+	}
+
+	has, err := reader.Has([]byte{})
+	require.NoError(err)
+	require.True(has)
+}
+
+func requireEqualIterators(t *testing.T, expected, actual database.Iterator) {
+	require := require.New(t)
+
+	defer expected.Release()
+	defer actual.Release()
+
+	for expected.Next() {
+		require.True(actual.Next())
+		require.Equal(expected.Key(), actual.Key())
+		require.Equal(expected.Value(), actual.Value())
+	}
+	require.False(actual.Next())
+	require.NoError(expected.Error())
+	require.NoError(actual.Error())
+}
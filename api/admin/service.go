@@ -17,6 +17,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/rpcdb"
+	"github.com/MetalBlockchain/metalgo/database/usage"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
@@ -34,6 +35,9 @@ import (
 const (
 	maxAliasLength = 512
 
+	// Maximum number of key-value pairs returned by a single dbIterate call
+	maxDBIteratePageSize = 1024
+
 	// Name of file that stacktraces are written to
 	stacktraceFile = "stacktrace.txt"
 )
@@ -41,6 +45,8 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+
+	errDBUsageNotConfigured = errors.New("database usage tracking is not configured")
 )
 
 type Config struct {
@@ -49,6 +55,7 @@ type Config struct {
 	LogFactory   logging.Factory
 	NodeConfig   interface{}
 	DB           database.Database
+	DBUsage      *usage.Tracker
 	ChainManager chains.Manager
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
@@ -413,3 +420,179 @@ func (a *Admin) DbGet(_ *http.Request, args *DBGetArgs, reply *DBGetReply) error
 	reply.Value, err = formatting.Encode(formatting.HexNC, value)
 	return err
 }
+
+type DBIterateArgs struct {
+	// Start is the hex encoded key to start iterating from. It is inclusive.
+	// If omitted, iteration starts from the first key, or from the last key
+	// when iterating in reverse. An empty key, "0x", is a valid start.
+	Start *string `json:"start,omitempty"`
+	// Prefix is the hex encoded prefix that all returned keys must have.
+	Prefix string `json:"prefix"`
+	// Limit is the maximum number of key-value pairs to return. If 0 or
+	// greater than the maximum page size, the maximum page size is used.
+	Limit json.Uint32 `json:"limit"`
+	// Reverse iterates over the keys in descending order.
+	Reverse bool `json:"reverse"`
+}
+
+type DBKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type DBIterateReply struct {
+	KeyValues []DBKeyValue `json:"keyValues"`
+	// NextStart is the hex encoded start of the next page. It is omitted
+	// once the iteration is complete.
+	NextStart *string `json:"nextStart,omitempty"`
+}
+
+//nolint:stylecheck // renaming this method to DBIterate would change the API method from "dbIterate" to "dBIterate"
+func (a *Admin) DbIterate(_ *http.Request, args *DBIterateArgs, reply *DBIterateReply) error {
+	var startStr string
+	if args.Start != nil {
+		startStr = *args.Start
+	}
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "dbIterate"),
+		logging.UserString("start", startStr),
+		logging.UserString("prefix", args.Prefix),
+		zap.Uint32("limit", uint32(args.Limit)),
+		zap.Bool("reverse", args.Reverse),
+	)
+
+	start, err := formatting.Decode(formatting.HexNC, startStr)
+	if err != nil {
+		return err
+	}
+	prefix, err := formatting.Decode(formatting.HexNC, args.Prefix)
+	if err != nil {
+		return err
+	}
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxDBIteratePageSize {
+		limit = maxDBIteratePageSize
+	}
+
+	var it database.Iterator
+	switch {
+	case !args.Reverse:
+		it = a.DB.NewIteratorWithStartAndPrefix(start, prefix)
+	case args.Start != nil && len(start) == 0:
+		// Iterators treat an empty start as no start, but the empty key is
+		// the only key at or before the empty key.
+		return a.dbGetEmptyKey(prefix, reply)
+	default:
+		it = database.NewReverseIteratorWithStartAndPrefix(a.DB, start, prefix)
+	}
+	defer it.Release()
+
+	reply.KeyValues = make([]DBKeyValue, 0, limit)
+	for it.Next() {
+		key, err := formatting.Encode(formatting.HexNC, it.Key())
+		if err != nil {
+			return err
+		}
+		if len(reply.KeyValues) == limit {
+			// The next page starts from the first key that wasn't returned.
+			reply.NextStart = &key
+			break
+		}
+
+		value, err := formatting.Encode(formatting.HexNC, it.Value())
+		if err != nil {
+			return err
+		}
+		reply.KeyValues = append(reply.KeyValues, DBKeyValue{
+			Key:   key,
+			Value: value,
+		})
+	}
+	return it.Error()
+}
+
+// dbGetEmptyKey populates [reply] with the empty key, if it exists and has
+// [prefix].
+func (a *Admin) dbGetEmptyKey(prefix []byte, reply *DBIterateReply) error {
+	reply.KeyValues = []DBKeyValue{}
+	if len(prefix) != 0 {
+		return nil
+	}
+
+	value, err := a.DB.Get([]byte{})
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	key, err := formatting.Encode(formatting.HexNC, []byte{})
+	if err != nil {
+		return err
+	}
+	valueStr, err := formatting.Encode(formatting.HexNC, value)
+	if err != nil {
+		return err
+	}
+	reply.KeyValues = append(reply.KeyValues, DBKeyValue{
+		Key:   key,
+		Value: valueStr,
+	})
+	return nil
+}
+
+type DBUsageArgs struct {
+	// Walk iterates over the entire database to count the keys and bytes of
+	// each namespace. If false, only the estimated disk usage is reported.
+	Walk bool `json:"walk"`
+}
+
+type NamespaceUsage struct {
+	Keys               json.Uint64 `json:"keys"`
+	KeyBytes           json.Uint64 `json:"keyBytes"`
+	ValueBytes         json.Uint64 `json:"valueBytes"`
+	EstimatedDiskBytes json.Uint64 `json:"estimatedDiskBytes"`
+}
+
+type DBUsageReply struct {
+	Namespaces map[string]NamespaceUsage `json:"namespaces"`
+}
+
+//nolint:stylecheck // renaming this method to DBUsage would change the API method from "dbUsage" to "dBUsage"
+func (a *Admin) DbUsage(r *http.Request, args *DBUsageArgs, reply *DBUsageReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "dbUsage"),
+		zap.Bool("walk", args.Walk),
+	)
+
+	if a.DBUsage == nil {
+		return errDBUsageNotConfigured
+	}
+
+	var (
+		stats map[string]*usage.Stats
+		err   error
+	)
+	if args.Walk {
+		stats, err = a.DBUsage.Walk(r.Context())
+	} else {
+		stats, err = a.DBUsage.Estimate()
+	}
+	if err != nil {
+		return err
+	}
+
+	reply.Namespaces = make(map[string]NamespaceUsage, len(stats))
+	for name, s := range stats {
+		reply.Namespaces[name] = NamespaceUsage{
+			Keys:               json.Uint64(s.Keys),
+			KeyBytes:           json.Uint64(s.KeyBytes),
+			ValueBytes:         json.Uint64(s.ValueBytes),
+			EstimatedDiskBytes: json.Uint64(s.EstimatedDiskBytes),
+		}
+	}
+	return nil
+}
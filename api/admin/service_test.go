@@ -7,10 +7,13 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/database/usage"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -167,3 +170,204 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceDBUsage(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	tracker, err := usage.NewTracker(db, "", prometheus.NewRegistry())
+	require.NoError(err)
+	tracker.Register("chain", []byte("chain"))
+
+	a := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		DB:      db,
+		DBUsage: tracker,
+	}}
+
+	require.NoError(db.Put([]byte("chain1"), []byte("value")))
+	require.NoError(db.Put([]byte("other"), []byte("value")))
+
+	reply := &DBUsageReply{}
+	require.NoError(a.DbUsage(
+		&http.Request{},
+		&DBUsageArgs{
+			Walk: true,
+		},
+		reply,
+	))
+	require.Equal(
+		map[string]NamespaceUsage{
+			"chain": {
+				Keys:       1,
+				KeyBytes:   6,
+				ValueBytes: 5,
+			},
+			usage.UnknownNamespace: {
+				Keys:       1,
+				KeyBytes:   5,
+				ValueBytes: 5,
+			},
+		},
+		reply.Namespaces,
+	)
+
+	// memdb doesn't support estimating disk usage.
+	err = a.DbUsage(&http.Request{}, &DBUsageArgs{}, &DBUsageReply{})
+	require.ErrorIs(err, database.ErrDiskUsageEstimationNotSupported)
+}
+
+func TestServiceDBUsageNotConfigured(t *testing.T) {
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  memdb.New(),
+	}}
+
+	err := a.DbUsage(&http.Request{}, &DBUsageArgs{}, &DBUsageReply{})
+	require.ErrorIs(t, err, errDBUsageNotConfigured)
+}
+
+func TestServiceDBIterate(t *testing.T) {
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  memdb.New(),
+	}}
+
+	for _, key := range []string{"0x", "0x00", "0x01", "0x0100", "0x0101", "0x02"} {
+		keyBytes, err := formatting.Decode(formatting.HexNC, key)
+		require.NoError(t, err)
+		require.NoError(t, a.DB.Put(keyBytes, keyBytes))
+	}
+
+	var (
+		startEmpty       = "0x"
+		startMiddle      = "0x0100"
+		nextStartLimit   = "0x01"
+		nextStartReverse = "0x00"
+		nextStartLast    = "0x0101"
+	)
+	tests := []struct {
+		name              string
+		args              DBIterateArgs
+		expectedKeys      []string
+		expectedNextStart *string
+	}{
+		{
+			name:         "all",
+			args:         DBIterateArgs{},
+			expectedKeys: []string{"0x", "0x00", "0x01", "0x0100", "0x0101", "0x02"},
+		},
+		{
+			name: "limit",
+			args: DBIterateArgs{
+				Limit: 2,
+			},
+			expectedKeys:      []string{"0x", "0x00"},
+			expectedNextStart: &nextStartLimit,
+		},
+		{
+			name: "start and prefix",
+			args: DBIterateArgs{
+				Start:  &startMiddle,
+				Prefix: "0x01",
+			},
+			expectedKeys: []string{"0x0100", "0x0101"},
+		},
+		{
+			name: "reverse",
+			args: DBIterateArgs{
+				Start:   &startMiddle,
+				Limit:   2,
+				Reverse: true,
+			},
+			expectedKeys:      []string{"0x0100", "0x01"},
+			expectedNextStart: &nextStartReverse,
+		},
+		{
+			name: "reverse from the last key",
+			args: DBIterateArgs{
+				Limit:   1,
+				Reverse: true,
+			},
+			expectedKeys:      []string{"0x02"},
+			expectedNextStart: &nextStartLast,
+		},
+		{
+			name: "reverse from the empty key",
+			args: DBIterateArgs{
+				Start:   &startEmpty,
+				Reverse: true,
+			},
+			expectedKeys: []string{"0x"},
+		},
+		{
+			name: "reverse from the empty key with prefix",
+			args: DBIterateArgs{
+				Start:   &startEmpty,
+				Prefix:  "0x01",
+				Reverse: true,
+			},
+			expectedKeys: []string{},
+		},
+		{
+			name: "limit equal to remaining keys",
+			args: DBIterateArgs{
+				Prefix: "0x01",
+				Limit:  3,
+			},
+			expectedKeys: []string{"0x01", "0x0100", "0x0101"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			reply := &DBIterateReply{}
+			require.NoError(a.DbIterate(nil, &test.args, reply))
+
+			keys := make([]string, len(reply.KeyValues))
+			for i, kv := range reply.KeyValues {
+				keys[i] = kv.Key
+				require.Equal(kv.Key, kv.Value)
+			}
+			require.Equal(test.expectedKeys, keys)
+			require.Equal(test.expectedNextStart, reply.NextStart)
+		})
+	}
+}
+
+func TestServiceDBIterateReversePagination(t *testing.T) {
+	require := require.New(t)
+
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  memdb.New(),
+	}}
+	for _, key := range []string{"0x", "0x00", "0x01", "0x02"} {
+		keyBytes, err := formatting.Decode(formatting.HexNC, key)
+		require.NoError(err)
+		require.NoError(a.DB.Put(keyBytes, keyBytes))
+	}
+
+	// The last page starts at the empty key, which must not restart the
+	// iteration from the last key.
+	var (
+		args = DBIterateArgs{
+			Limit:   1,
+			Reverse: true,
+		}
+		keys []string
+	)
+	for i := 0; i < 5; i++ {
+		reply := &DBIterateReply{}
+		require.NoError(a.DbIterate(nil, &args, reply))
+		for _, kv := range reply.KeyValues {
+			keys = append(keys, kv.Key)
+		}
+		if reply.NextStart == nil {
+			break
+		}
+		args.Start = reply.NextStart
+	}
+	require.Equal([]string{"0x02", "0x01", "0x00", "0x"}, keys)
+}
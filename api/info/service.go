@@ -16,6 +16,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
@@ -42,6 +43,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	reputation   reputation.Scorer
 }
 
 type Parameters struct {
@@ -70,6 +72,7 @@ func NewService(
 	myIP ips.DynamicIPPort,
 	network network.Network,
 	benchlist benchlist.Manager,
+	reputation reputation.Scorer,
 ) (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := json.NewCodec()
@@ -85,6 +88,7 @@ func NewService(
 			myIP:         myIP,
 			networking:   network,
 			benchlist:    benchlist,
+			reputation:   reputation,
 		},
 		"info",
 	)
@@ -216,6 +220,9 @@ type Peer struct {
 	peer.Info
 
 	Benched []string `json:"benched"`
+	// Reputation is the score of the peer in (0, 1], where 1 is a peer that
+	// hasn't misbehaved.
+	Reputation json.Float64 `json:"reputation"`
 }
 
 // PeersReply are the results from calling Peers
@@ -246,8 +253,9 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 			benchedAliases[idx] = alias
 		}
 		peerInfo[index] = Peer{
-			Info:    peer,
-			Benched: benchedAliases,
+			Info:       peer,
+			Benched:    benchedAliases,
+			Reputation: json.Float64(i.reputation.Score(peer.ID)),
 		}
 	}
 
@@ -0,0 +1,205 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math/bits"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+const (
+	// The number of counters each key is counted in.
+	sketchDepth = 4
+	// Counters are 4 bits, so they saturate at this value.
+	maxSketchCount = 15
+	// The number of counters in each row per key that the sketch can count.
+	// More counters per key make it less likely that keys share counters.
+	sketchCountersPerKey = 16
+	minSketchKeys        = 64
+	// Counters are halved after this many increments per key that the sketch
+	// can count.
+	sketchSampleFactor = 10
+)
+
+// Hasher writes [key] to [h] so that it can be hashed. Keys that are equal must
+// be written identically.
+type Hasher[K any] func(h *maphash.Hash, key K)
+
+// HashString is a Hasher for string keys.
+func HashString(h *maphash.Hash, key string) {
+	_, _ = h.WriteString(key)
+}
+
+// HashID is a Hasher for ID keys.
+func HashID(h *maphash.Hash, key ids.ID) {
+	_, _ = h.Write(key[:])
+}
+
+// HashInt is a Hasher for int keys.
+func HashInt(h *maphash.Hash, key int) {
+	HashUint64(h, uint64(key))
+}
+
+// HashUint64 is a Hasher for uint64 keys.
+func HashUint64(h *maphash.Hash, key uint64) {
+	var bytes [8]byte
+	binary.BigEndian.PutUint64(bytes[:], key)
+	_, _ = h.Write(bytes[:])
+}
+
+// HashBytes is a Hasher for keys that are equal if their bytes are equal.
+func HashBytes[K interface{ Bytes() []byte }](h *maphash.Hash, key K) {
+	_, _ = h.Write(key.Bytes())
+}
+
+// DefaultHasher returns the Hasher for keys of type K. Returns false if K
+// isn't a string, ID, int, uint64 or a type with a Bytes method, in which case
+// a Hasher must be provided explicitly.
+func DefaultHasher[K comparable]() (Hasher[K], bool) {
+	var hasher any
+	switch any(utils.Zero[K]()).(type) {
+	case string:
+		hasher = Hasher[string](HashString)
+	case ids.ID:
+		hasher = Hasher[ids.ID](HashID)
+	case int:
+		hasher = Hasher[int](HashInt)
+	case uint64:
+		hasher = Hasher[uint64](HashUint64)
+	case interface{ Bytes() []byte }:
+		hasher = Hasher[K](func(h *maphash.Hash, key K) {
+			HashBytes(h, any(key).(interface{ Bytes() []byte }))
+		})
+	}
+	hash, ok := hasher.(Hasher[K])
+	return hash, ok
+}
+
+// frequencySketch estimates how many times each key was recently used, with a
+// count-min sketch. The estimate for a key is never less than the number of
+// times it was used, but may be more if its counters are shared with other
+// keys.
+//
+// Every [sketchSampleFactor] * numKeys increments, every counter is halved, so
+// that keys that were used frequently in the past, but aren't anymore, age
+// out.
+//
+// frequencySketch isn't safe for concurrent use.
+type frequencySketch[K comparable] struct {
+	seed maphash.Seed
+	hash Hasher[K]
+	// The number of keys that the sketch can count.
+	numKeys int
+	// Each byte holds 2 counters.
+	counters   [sketchDepth][]byte
+	mask       uint64
+	increments int
+	sampleSize int
+}
+
+func newFrequencySketch[K comparable](hash Hasher[K]) *frequencySketch[K] {
+	s := &frequencySketch[K]{
+		seed: maphash.MakeSeed(),
+		hash: hash,
+	}
+	s.init(minSketchKeys)
+	return s
+}
+
+// resize grows the sketch so that it can accurately count [numKeys] keys.
+// Growing the sketch forgets all previous counts.
+func (s *frequencySketch[_]) resize(numKeys int) {
+	if numKeys <= s.numKeys {
+		return
+	}
+	s.init(1 << bits.Len(uint(numKeys-1)))
+}
+
+// increment records a use of [key].
+func (s *frequencySketch[K]) increment(key K) {
+	var (
+		indices = s.indices(key)
+		count   = s.count(indices)
+	)
+	if count < maxSketchCount {
+		// Only increment the smallest counters, which reduces the
+		// overestimation caused by sharing counters with other keys.
+		for i, index := range indices {
+			if s.counter(i, index) == count {
+				s.counters[i][index/2] += 1 << (4 * (index % 2))
+			}
+		}
+	}
+
+	s.increments++
+	if s.increments >= s.sampleSize {
+		s.age()
+	}
+}
+
+// frequency returns the estimated number of times [key] was recently used.
+func (s *frequencySketch[K]) frequency(key K) uint8 {
+	return s.count(s.indices(key))
+}
+
+// init resets the sketch to count [numKeys] keys, which must be a power of 2.
+func (s *frequencySketch[_]) init(numKeys int) {
+	width := sketchCountersPerKey * numKeys
+	for i := range s.counters {
+		s.counters[i] = make([]byte, width/2)
+	}
+	s.numKeys = numKeys
+	s.mask = uint64(width - 1)
+	s.increments = 0
+	s.sampleSize = sketchSampleFactor * numKeys
+}
+
+// age halves every counter.
+func (s *frequencySketch[_]) age() {
+	for _, row := range s.counters {
+		for i := range row {
+			// Halve both counters in the byte.
+			row[i] = (row[i] >> 1) & 0x77
+		}
+	}
+	s.increments /= 2
+}
+
+func (s *frequencySketch[_]) count(indices [sketchDepth]uint64) uint8 {
+	count := uint8(maxSketchCount)
+	for i, index := range indices {
+		count = min(count, s.counter(i, index))
+	}
+	return count
+}
+
+func (s *frequencySketch[_]) counter(row int, index uint64) uint8 {
+	return (s.counters[row][index/2] >> (4 * (index % 2))) & 0xf
+}
+
+// indices returns the index of the counter for [key] in each row, derived from
+// a single hash with double hashing.
+func (s *frequencySketch[K]) indices(key K) [sketchDepth]uint64 {
+	var (
+		hash    = s.sum(key)
+		h1      = hash & 0xffffffff
+		h2      = hash>>32 | 1
+		indices [sketchDepth]uint64
+	)
+	for i := range indices {
+		indices[i] = (h1 + uint64(i)*h2) & s.mask
+	}
+	return indices
+}
+
+func (s *frequencySketch[K]) sum(key K) uint64 {
+	var h maphash.Hash
+	h.SetSeed(s.seed)
+	s.hash(&h, key)
+	return h.Sum64()
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestFrequencySketch(t *testing.T) {
+	require := require.New(t)
+
+	s := newFrequencySketch(HashID)
+
+	id := ids.GenerateTestID()
+	require.Zero(s.frequency(id))
+
+	for i := uint8(1); i <= maxSketchCount; i++ {
+		s.increment(id)
+		require.GreaterOrEqual(s.frequency(id), i)
+	}
+
+	// Counters saturate.
+	s.increment(id)
+	require.Equal(uint8(maxSketchCount), s.frequency(id))
+
+	// Counters are halved once enough increments have happened.
+	for i := s.increments; i < s.sampleSize; i++ {
+		s.increment(ids.GenerateTestID())
+	}
+	require.Equal(s.sampleSize/2, s.increments)
+	require.LessOrEqual(s.frequency(id), uint8(maxSketchCount/2+1))
+
+	// Growing the sketch forgets previous counts.
+	s.resize(minSketchKeys + 1)
+	require.Zero(s.frequency(id))
+	require.Equal(2*minSketchKeys, s.numKeys)
+	require.Len(s.counters[0], minSketchKeys*sketchCountersPerKey)
+
+	// Shrinking is a noop.
+	s.resize(1)
+	require.Equal(2*minSketchKeys, s.numKeys)
+}
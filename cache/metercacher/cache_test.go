@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/cache"
@@ -43,3 +44,31 @@ func TestInterface(t *testing.T) {
 		}
 	}
 }
+
+func TestPolicies(t *testing.T) {
+	for _, policy := range []cache.Policy{cache.LRUPolicy, cache.TwoQueuePolicy, cache.TinyLFUPolicy} {
+		t.Run(string(policy), func(t *testing.T) {
+			require := require.New(t)
+
+			baseCache, err := cache.NewSized[ids.ID, int64](policy, 10*cache.TestIntSize, cache.TestIntSizeFunc)
+			require.NoError(err)
+			c, err := New("", prometheus.NewRegistry(), baseCache)
+			require.NoError(err)
+			meterCache := c.(*Cache[ids.ID, int64])
+
+			id := ids.GenerateTestID()
+			_, found := c.Get(id)
+			require.False(found)
+
+			c.Put(id, 1)
+			value, found := c.Get(id)
+			require.True(found)
+			require.Equal(int64(1), value)
+
+			require.Equal(float64(1), testutil.ToFloat64(meterCache.hit))
+			require.Equal(float64(1), testutil.ToFloat64(meterCache.miss))
+			require.Equal(float64(1), testutil.ToFloat64(meterCache.len))
+			require.Equal(0.1, testutil.ToFloat64(meterCache.portionFilled))
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+const (
+	// LRUPolicy evicts the least recently used entry.
+	LRUPolicy Policy = "lru"
+	// TwoQueuePolicy only keeps entries that are used once in a small part of
+	// the cache. See NewSizedTwoQueue.
+	TwoQueuePolicy Policy = "2q"
+	// TinyLFUPolicy only admits entries into the cache if they're used more
+	// frequently than the entries they would evict. See NewSizedTinyLFU.
+	TinyLFUPolicy Policy = "tinylfu"
+)
+
+var (
+	ErrUnknownPolicy = errors.New("unknown cache policy")
+	ErrUnhashableKey = errors.New("key type can't be hashed")
+
+	policies = []Policy{
+		LRUPolicy,
+		TwoQueuePolicy,
+		TinyLFUPolicy,
+	}
+)
+
+// Policy is the eviction policy of a sized cache.
+// The empty policy is treated as LRUPolicy.
+type Policy string
+
+// Valid checks if Policy [p] is one of the predefined policies
+func (p Policy) Valid() error {
+	if p == "" {
+		return nil
+	}
+	for _, policy := range policies {
+		if policy == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrUnknownPolicy, p)
+}
+
+// NewSized returns a cache with the eviction [policy] that holds entries with
+// a total size of at most [maxSize].
+//
+// TinyLFUPolicy requires keys that have a DefaultHasher. Other key types can
+// use NewSizedTinyLFU directly.
+func NewSized[K comparable, V any](policy Policy, maxSize int, size func(K, V) int) (Cacher[K, V], error) {
+	switch policy {
+	case "", LRUPolicy:
+		return NewSizedLRU(maxSize, size), nil
+	case TwoQueuePolicy:
+		return NewSizedTwoQueue(maxSize, size), nil
+	case TinyLFUPolicy:
+		hash, ok := DefaultHasher[K]()
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUnhashableKey, utils.Zero[K]())
+		}
+		return NewSizedTinyLFU(maxSize, size, hash), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, policy)
+	}
+}
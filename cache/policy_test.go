@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyValid(t *testing.T) {
+	require := require.New(t)
+
+	for _, policy := range append(policies, "") {
+		require.NoError(policy.Valid())
+
+		c, err := NewSized[int, int](policy, 1, func(int, int) int { return 1 })
+		require.NoError(err)
+		require.NotNil(c)
+	}
+
+	policy := Policy("unknown")
+	require.ErrorIs(policy.Valid(), ErrUnknownPolicy)
+
+	_, err := NewSized[int, int](policy, 1, func(int, int) int { return 1 })
+	require.ErrorIs(err, ErrUnknownPolicy)
+}
+
+func TestPolicyUnhashableKey(t *testing.T) {
+	require := require.New(t)
+
+	type key struct{}
+	_, err := NewSized[key, int](TinyLFUPolicy, 1, func(key, int) int { return 1 })
+	require.ErrorIs(err, ErrUnhashableKey)
+
+	// Other policies don't hash keys.
+	_, err = NewSized[key, int](LRUPolicy, 1, func(key, int) int { return 1 })
+	require.NoError(err)
+}
+
+// TestPolicyScanResistance checks that scans of keys that are only used once
+// don't evict keys that are used repeatedly, unlike with an LRU cache.
+func TestPolicyScanResistance(t *testing.T) {
+	const (
+		cacheSize = 100
+		numHot    = 50
+		scanSize  = 200
+		numRounds = 20
+	)
+
+	hitRatio := func(policy Policy) float64 {
+		c, err := NewSized[int, int](policy, cacheSize, func(int, int) int { return 1 })
+		require.NoError(t, err)
+
+		var (
+			getOrPut = func(key int) bool {
+				if _, ok := c.Get(key); ok {
+					return true
+				}
+				c.Put(key, key)
+				return false
+			}
+			nextScanKey = numHot
+			hits        int
+		)
+		for round := 0; round < numRounds; round++ {
+			for i := 0; i < 2; i++ {
+				for key := 0; key < numHot; key++ {
+					if getOrPut(key) {
+						hits++
+					}
+				}
+			}
+			for i := 0; i < scanSize; i++ {
+				getOrPut(nextScanKey)
+				nextScanKey++
+			}
+		}
+		return float64(hits) / float64(2*numHot*numRounds)
+	}
+
+	lruHitRatio := hitRatio(LRUPolicy)
+	for _, policy := range []Policy{TwoQueuePolicy, TinyLFUPolicy} {
+		require.Greater(t, hitRatio(policy), lruHitRatio+0.25, policy)
+	}
+}
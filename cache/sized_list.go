@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import "github.com/MetalBlockchain/metalgo/utils/linkedhashmap"
+
+// sizedList is an ordered set of entries that tracks the total size of its
+// entries. Entries are ordered from least to most recently put.
+//
+// sizedList isn't safe for concurrent use.
+type sizedList[K comparable, V any] struct {
+	elements linkedhashmap.LinkedHashmap[K, V]
+	size     func(K, V) int
+	// The total size of the entries in the list.
+	currentSize int
+}
+
+func newSizedList[K comparable, V any](size func(K, V) int) *sizedList[K, V] {
+	return &sizedList[K, V]{
+		elements: linkedhashmap.New[K, V](),
+		size:     size,
+	}
+}
+
+// put inserts [key] as the most recent entry, replacing any previous value.
+func (l *sizedList[K, V]) put(key K, value V) {
+	l.delete(key)
+	l.elements.Put(key, value)
+	l.currentSize += l.size(key, value)
+}
+
+func (l *sizedList[K, V]) get(key K) (V, bool) {
+	return l.elements.Get(key)
+}
+
+func (l *sizedList[K, _]) has(key K) bool {
+	_, ok := l.elements.Get(key)
+	return ok
+}
+
+// delete removes [key], returning its value if it was in the list.
+func (l *sizedList[K, V]) delete(key K) (V, bool) {
+	value, ok := l.elements.Get(key)
+	if ok {
+		l.elements.Delete(key)
+		l.currentSize -= l.size(key, value)
+	}
+	return value, ok
+}
+
+// removeOldest removes and returns the least recently put entry.
+func (l *sizedList[K, V]) removeOldest() (K, V, bool) {
+	key, value, ok := l.elements.Oldest()
+	if ok {
+		l.elements.Delete(key)
+		l.currentSize -= l.size(key, value)
+	}
+	return key, value, ok
+}
+
+func (l *sizedList[K, V]) oldest() (K, V, bool) {
+	return l.elements.Oldest()
+}
+
+func (l *sizedList[_, _]) len() int {
+	return l.elements.Len()
+}
+
+func (l *sizedList[K, V]) flush() {
+	l.elements = linkedhashmap.New[K, V]()
+	l.currentSize = 0
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+const (
+	// The fraction of a W-TinyLFU cache's size that is used for the window of
+	// recently put entries.
+	tinyLFUWindowFraction = 0.01
+	// The fraction of a W-TinyLFU cache's main space that is used for entries
+	// that were accessed after being admitted.
+	tinyLFUProtectedFraction = 0.8
+)
+
+var _ Cacher[struct{}, any] = (*sizedTinyLFU[struct{}, any])(nil)
+
+// sizedTinyLFU is a key value store with bounded size that implements the
+// W-TinyLFU eviction policy.
+//
+// New entries are put into a small LRU window. Entries evicted from the window
+// are only admitted into the main space of the cache if they've been used more
+// frequently than the entry that they would evict. This prevents entries that
+// are only used once, such as those read during a scan, from evicting entries
+// that are used repeatedly.
+//
+// The main space is a segmented LRU. Admitted entries are put into the
+// probation segment, and are moved to the protected segment when they're
+// accessed again. Entries are evicted from the probation segment first.
+type sizedTinyLFU[K comparable, V any] struct {
+	lock      sync.Mutex
+	window    *sizedList[K, V]
+	probation *sizedList[K, V]
+	protected *sizedList[K, V]
+	sketch    *frequencySketch[K]
+
+	maxSize          int
+	maxWindowSize    int
+	maxMainSize      int
+	maxProtectedSize int
+	size             func(K, V) int
+}
+
+// NewSizedTinyLFU returns a W-TinyLFU cache that holds entries with a total
+// size of at most [maxSize]. [hash] is used to count how frequently each key is
+// used.
+func NewSizedTinyLFU[K comparable, V any](maxSize int, size func(K, V) int, hash Hasher[K]) Cacher[K, V] {
+	var (
+		maxWindowSize = int(float64(maxSize) * tinyLFUWindowFraction)
+		maxMainSize   = maxSize - maxWindowSize
+	)
+	return &sizedTinyLFU[K, V]{
+		window:           newSizedList(size),
+		probation:        newSizedList(size),
+		protected:        newSizedList(size),
+		sketch:           newFrequencySketch(hash),
+		maxSize:          maxSize,
+		maxWindowSize:    maxWindowSize,
+		maxMainSize:      maxMainSize,
+		maxProtectedSize: int(float64(maxMainSize) * tinyLFUProtectedFraction),
+		size:             size,
+	}
+}
+
+func (c *sizedTinyLFU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *sizedTinyLFU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *sizedTinyLFU[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *sizedTinyLFU[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *sizedTinyLFU[_, _]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.len()
+}
+
+func (c *sizedTinyLFU[_, _]) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.portionFilled()
+}
+
+func (c *sizedTinyLFU[K, V]) put(key K, value V) {
+	c.sketch.increment(key)
+	if c.size(key, value) > c.maxSize {
+		c.evict(key)
+		return
+	}
+
+	switch {
+	case c.window.has(key):
+		c.window.put(key, value)
+	case c.probation.has(key):
+		c.probation.delete(key)
+		c.protected.put(key, value)
+	case c.protected.has(key):
+		c.protected.put(key, value)
+	default:
+		c.window.put(key, value)
+		c.sketch.resize(c.len())
+	}
+	c.reclaim()
+}
+
+func (c *sizedTinyLFU[K, V]) get(key K) (V, bool) {
+	c.sketch.increment(key)
+
+	if value, ok := c.window.get(key); ok {
+		c.window.put(key, value) // Mark [key] as MRU.
+		return value, true
+	}
+	if value, ok := c.probation.delete(key); ok {
+		c.protected.put(key, value)
+		c.reclaim()
+		return value, true
+	}
+	if value, ok := c.protected.get(key); ok {
+		c.protected.put(key, value) // Mark [key] as MRU.
+		return value, true
+	}
+	return utils.Zero[V](), false
+}
+
+func (c *sizedTinyLFU[K, _]) evict(key K) {
+	c.window.delete(key)
+	c.probation.delete(key)
+	c.protected.delete(key)
+}
+
+func (c *sizedTinyLFU[_, _]) flush() {
+	c.window.flush()
+	c.probation.flush()
+	c.protected.flush()
+}
+
+func (c *sizedTinyLFU[_, _]) len() int {
+	return c.window.len() + c.probation.len() + c.protected.len()
+}
+
+func (c *sizedTinyLFU[_, _]) portionFilled() float64 {
+	return float64(c.window.currentSize+c.mainSize()) / float64(c.maxSize)
+}
+
+func (c *sizedTinyLFU[_, _]) mainSize() int {
+	return c.probation.currentSize + c.protected.currentSize
+}
+
+// reclaim moves and removes entries until every segment of the cache is
+// within its size limit.
+func (c *sizedTinyLFU[_, _]) reclaim() {
+	for c.protected.currentSize > c.maxProtectedSize {
+		key, value, _ := c.protected.removeOldest()
+		c.probation.put(key, value)
+	}
+	for c.window.currentSize > c.maxWindowSize {
+		key, value, _ := c.window.removeOldest()
+		c.admit(key, value)
+	}
+	for c.mainSize() > c.maxMainSize {
+		c.removeVictim()
+	}
+}
+
+// admit puts the entry evicted from the window into the main space if there
+// is room for it, or if it was used more frequently than the entry that
+// would be evicted for it. Otherwise, the entry is dropped.
+func (c *sizedTinyLFU[K, V]) admit(key K, value V) {
+	entrySize := c.size(key, value)
+	if entrySize > c.maxMainSize {
+		return
+	}
+
+	if c.mainSize()+entrySize > c.maxMainSize {
+		victimKey, _, _ := c.victim()
+		if c.sketch.frequency(key) <= c.sketch.frequency(victimKey) {
+			return
+		}
+		for c.mainSize()+entrySize > c.maxMainSize {
+			c.removeVictim()
+		}
+	}
+	c.probation.put(key, value)
+}
+
+// victim returns the entry that should be evicted next from the main space.
+func (c *sizedTinyLFU[K, V]) victim() (K, V, bool) {
+	if c.probation.len() > 0 {
+		return c.probation.oldest()
+	}
+	return c.protected.oldest()
+}
+
+func (c *sizedTinyLFU[_, _]) removeVictim() {
+	if c.probation.len() > 0 {
+		c.probation.removeOldest()
+	} else {
+		c.protected.removeOldest()
+	}
+}
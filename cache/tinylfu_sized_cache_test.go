@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizedTinyLFU(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTinyLFU[int, int](100, func(int, int) int { return 1 }, HashInt)
+
+	_, ok := cache.Get(0)
+	require.False(ok)
+
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+	}
+	require.Equal(100, cache.Len())
+	require.Equal(1.0, cache.PortionFilled())
+
+	for i := 0; i < 100; i++ {
+		v, ok := cache.Get(i)
+		require.True(ok)
+		require.Equal(i, v)
+	}
+
+	cache.Put(0, 1)
+	v, ok := cache.Get(0)
+	require.True(ok)
+	require.Equal(1, v)
+
+	cache.Evict(0)
+	_, ok = cache.Get(0)
+	require.False(ok)
+	require.Equal(99, cache.Len())
+
+	cache.Flush()
+	require.Zero(cache.Len())
+	require.Zero(cache.PortionFilled())
+}
+
+func TestSizedTinyLFUAdmission(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTinyLFU[int, int](100, func(int, int) int { return 1 }, HashInt)
+
+	// Fill the cache with entries that are used frequently.
+	for i := 0; i < 100; i++ {
+		cache.Put(i, i)
+	}
+	for j := 0; j < 10; j++ {
+		for i := 0; i < 100; i++ {
+			_, _ = cache.Get(i)
+		}
+	}
+
+	// Entries that are used less frequently aren't admitted. The frequency of
+	// an entry is estimated, so a few may be admitted.
+	for i := 100; i < 400; i++ {
+		cache.Put(i, i)
+	}
+	require.Equal(100, cache.Len())
+	var numAdmitted int
+	for i := 100; i < 400; i++ {
+		if _, ok := cache.Get(i); ok {
+			numAdmitted++
+		}
+	}
+	require.LessOrEqual(numAdmitted, 3)
+
+	// Entries that become used more frequently are admitted.
+	for j := 0; j < 10; j++ {
+		cache.Put(1000, 1000)
+		_, _ = cache.Get(1000)
+	}
+	for i := 1001; i < 1010; i++ {
+		cache.Put(i, i)
+	}
+	v, ok := cache.Get(1000)
+	require.True(ok)
+	require.Equal(1000, v)
+	require.Equal(100, cache.Len())
+}
+
+func TestSizedTinyLFULargeEntry(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTinyLFU[string, struct{}](
+		3,
+		func(key string, _ struct{}) int {
+			return len(key)
+		},
+		HashString,
+	)
+
+	cache.Put("a", struct{}{})
+	cache.Put("bbbb", struct{}{})
+
+	_, ok := cache.Get("a")
+	require.True(ok)
+	_, ok = cache.Get("bbbb")
+	require.False(ok)
+	require.Equal(1, cache.Len())
+}
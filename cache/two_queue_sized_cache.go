@@ -0,0 +1,170 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+const (
+	// The fraction of a 2Q cache's size that is used for entries that have
+	// only been put once.
+	twoQueueRecentFraction = 0.25
+	// The total size of the entries that a 2Q cache remembers evicting from its
+	// recent queue, as a fraction of the cache's size.
+	twoQueueGhostFraction = 0.5
+)
+
+var _ Cacher[struct{}, any] = (*sizedTwoQueue[struct{}, any])(nil)
+
+// sizedTwoQueue is a key value store with bounded size that implements the 2Q
+// eviction policy.
+//
+// New entries are put into a FIFO queue of recent entries. Entries evicted
+// from the recent queue are remembered, without their values, in a ghost
+// queue. If an entry in the recent queue is used again, or an entry in the
+// ghost queue is put again, it has been used more than once, so it's moved to
+// an LRU queue of frequent entries. Since the recent queue only uses a small
+// part of the cache, entries that are only used once, such as those read
+// during a scan, don't evict entries that are used repeatedly.
+type sizedTwoQueue[K comparable, V any] struct {
+	lock sync.Mutex
+	// FIFO queue of entries that have been used once.
+	recent *sizedList[K, V]
+	// LRU queue of entries that have been used more than once.
+	frequent *sizedList[K, V]
+	// FIFO queue of the keys that were evicted from [recent], mapped to the
+	// size of their evicted entry.
+	ghosts *sizedList[K, int]
+
+	maxSize       int
+	maxRecentSize int
+	maxGhostSize  int
+	size          func(K, V) int
+}
+
+func NewSizedTwoQueue[K comparable, V any](maxSize int, size func(K, V) int) Cacher[K, V] {
+	return &sizedTwoQueue[K, V]{
+		recent:   newSizedList(size),
+		frequent: newSizedList(size),
+		ghosts: newSizedList(func(_ K, size int) int {
+			return size
+		}),
+		maxSize:       maxSize,
+		maxRecentSize: int(float64(maxSize) * twoQueueRecentFraction),
+		maxGhostSize:  int(float64(maxSize) * twoQueueGhostFraction),
+		size:          size,
+	}
+}
+
+func (c *sizedTwoQueue[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *sizedTwoQueue[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *sizedTwoQueue[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *sizedTwoQueue[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *sizedTwoQueue[_, _]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.len()
+}
+
+func (c *sizedTwoQueue[_, _]) PortionFilled() float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.portionFilled()
+}
+
+func (c *sizedTwoQueue[K, V]) put(key K, value V) {
+	if c.size(key, value) > c.maxSize {
+		c.evict(key)
+		return
+	}
+
+	_, inRecent := c.recent.delete(key)
+	_, inGhosts := c.ghosts.delete(key)
+	if inRecent || inGhosts || c.frequent.has(key) {
+		c.frequent.put(key, value)
+	} else {
+		c.recent.put(key, value)
+	}
+	c.reclaim()
+}
+
+func (c *sizedTwoQueue[K, V]) get(key K) (V, bool) {
+	if value, ok := c.recent.delete(key); ok {
+		c.frequent.put(key, value)
+		return value, true
+	}
+	if value, ok := c.frequent.get(key); ok {
+		c.frequent.put(key, value) // Mark [key] as MRU.
+		return value, true
+	}
+	return utils.Zero[V](), false
+}
+
+func (c *sizedTwoQueue[K, _]) evict(key K) {
+	c.recent.delete(key)
+	c.frequent.delete(key)
+	c.ghosts.delete(key)
+}
+
+func (c *sizedTwoQueue[_, _]) flush() {
+	c.recent.flush()
+	c.frequent.flush()
+	c.ghosts.flush()
+}
+
+func (c *sizedTwoQueue[_, _]) len() int {
+	return c.recent.len() + c.frequent.len()
+}
+
+func (c *sizedTwoQueue[_, _]) portionFilled() float64 {
+	return float64(c.recent.currentSize+c.frequent.currentSize) / float64(c.maxSize)
+}
+
+// reclaim removes entries until the size of the entries in the cache is
+// <= [c.maxSize].
+// Entries are evicted from the recent queue if it's larger than its share of
+// the cache. Otherwise, they're evicted from the frequent queue.
+func (c *sizedTwoQueue[_, _]) reclaim() {
+	for c.recent.currentSize+c.frequent.currentSize > c.maxSize {
+		if c.recent.currentSize <= c.maxRecentSize && c.frequent.len() > 0 {
+			c.frequent.removeOldest()
+			continue
+		}
+
+		key, value, _ := c.recent.removeOldest()
+		c.ghosts.put(key, c.size(key, value))
+		for c.ghosts.currentSize > c.maxGhostSize {
+			c.ghosts.removeOldest()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizedTwoQueueEviction(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTwoQueue[int, int](4, func(int, int) int { return 1 })
+
+	// Entries that are only used once are evicted first in, first out.
+	for i := 0; i < 5; i++ {
+		cache.Put(i, i)
+	}
+	require.Equal(4, cache.Len())
+	_, ok := cache.Get(0)
+	require.False(ok)
+
+	// Using an entry again protects it from a scan.
+	v, ok := cache.Get(1)
+	require.True(ok)
+	require.Equal(1, v)
+	for i := 5; i < 10; i++ {
+		cache.Put(i, i)
+	}
+	require.Equal(4, cache.Len())
+	_, ok = cache.Get(1)
+	require.True(ok)
+	_, ok = cache.Get(5)
+	require.False(ok)
+
+	// Putting an entry that was recently evicted also protects it.
+	cache.Put(5, 5)
+	for i := 10; i < 15; i++ {
+		cache.Put(i, i)
+	}
+	_, ok = cache.Get(1)
+	require.True(ok)
+	_, ok = cache.Get(5)
+	require.True(ok)
+	require.Equal(4, cache.Len())
+	require.Equal(1.0, cache.PortionFilled())
+
+	cache.Evict(1)
+	_, ok = cache.Get(1)
+	require.False(ok)
+	require.Equal(3, cache.Len())
+
+	cache.Flush()
+	require.Zero(cache.Len())
+	require.Zero(cache.PortionFilled())
+}
+
+func TestSizedTwoQueueLargeEntry(t *testing.T) {
+	require := require.New(t)
+
+	cache := NewSizedTwoQueue[string, struct{}](
+		3,
+		func(key string, _ struct{}) int {
+			return len(key)
+		},
+	)
+
+	cache.Put("a", struct{}{})
+	cache.Put("bbbb", struct{}{})
+
+	_, ok := cache.Get("a")
+	require.True(ok)
+	_, ok = cache.Get("bbbb")
+	require.False(ok)
+
+	require.Equal(1, cache.Len())
+}
@@ -6,6 +6,10 @@ package atomic
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/faultdb"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/database/prefixdb"
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -29,3 +33,36 @@ func TestSharedMemory(t *testing.T) {
 		test(t, chainID0, chainID1, sm0, sm1, testDB)
 	}
 }
+
+func TestSharedMemoryApplyWriteFailure(t *testing.T) {
+	require := require.New(t)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+
+	db := faultdb.New(memdb.New(), 0, faultdb.Rule{
+		Ops:   []faultdb.Op{faultdb.OpBatchWrite},
+		Count: 1,
+		Fault: faultdb.FaultError,
+	})
+	m := NewMemory(prefixdb.New([]byte{0}, db))
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	requests := map[ids.ID]*Requests{chainID1: {PutRequests: []*Element{{
+		Key:   []byte{0},
+		Value: []byte{1},
+	}}}}
+	require.ErrorIs(sm0.Apply(requests), faultdb.ErrInjected)
+
+	// The failed write must not have modified shared memory.
+	_, err := sm1.Get(chainID0, [][]byte{{0}})
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(sm0.Apply(requests))
+
+	values, err := sm1.Get(chainID0, [][]byte{{0}})
+	require.NoError(err)
+	require.Equal([][]byte{{1}}, values)
+}
@@ -20,10 +20,12 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/keystore"
 	"github.com/MetalBlockchain/metalgo/api/metrics"
 	"github.com/MetalBlockchain/metalgo/api/server"
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/chains/atomic"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/meterdb"
 	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/database/usage"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network"
@@ -37,6 +39,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/syncer"
 	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/sender"
 	"github.com/MetalBlockchain/metalgo/snow/networking/timeout"
@@ -185,6 +188,7 @@ type ManagerConfig struct {
 	TxAcceptorGroup           snow.AcceptorGroup
 	VertexAcceptorGroup       snow.AcceptorGroup
 	DB                        database.Database
+	DBUsage                   *usage.Tracker             // Attributes the usage of [DB] to each chain. May be nil
 	MsgCreator                message.OutboundMsgBuilder // message creator, shared with network
 	Router                    router.Router              // Routes incoming messages to the appropriate chain
 	Net                       network.Network            // Sends consensus messages to other validators
@@ -208,6 +212,9 @@ type ManagerConfig struct {
 	MeterVMEnabled   bool // Should each VM be wrapped with a MeterVM
 	Metrics          metrics.MultiGatherer
 
+	// Eviction policy of the ProposerVM block caches
+	ProposerVMCachePolicy cache.Policy
+
 	FrontierPollFrequency   time.Duration
 	ConsensusAppConcurrency int
 
@@ -226,6 +233,9 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Tracks the reputation of each peer.
+	Reputation reputation.Tracker
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -458,6 +468,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 			Metrics:      vmMetrics,
 
 			WarpSigner: warp.NewSigner(m.StakingBLSKey, m.NetworkID, chainParams.ID),
+			Reputation: m.Reputation,
 
 			ValidatorState: m.validatorState,
 			ChainDataDir:   chainDataDir,
@@ -539,6 +550,34 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 	return chain, nil
 }
 
+// registerDBUsage attributes the usage of each database in [dbs] to
+// [chainAlias]/<name>. The database with an empty name is attributed to
+// [chainAlias].
+func (m *manager) registerDBUsage(chainAlias string, dbs map[string]*prefixdb.Database) {
+	if m.DBUsage == nil {
+		return
+	}
+	for name, db := range dbs {
+		namespace := chainAlias
+		if name != "" {
+			namespace += "/" + name
+		}
+		m.DBUsage.Register(namespace, db.Prefix())
+	}
+}
+
+// observeVMDB returns a function that attributes the usage of the databases
+// created by the VM of [chainAlias] to [chainAlias]/vm/<prefix>.
+func (m *manager) observeVMDB(chainAlias string) func(prefix, dbPrefix []byte) {
+	return func(prefix, dbPrefix []byte) {
+		if m.DBUsage == nil {
+			return
+		}
+		namespace := fmt.Sprintf("%s/%s/%s", chainAlias, VMDBPrefix, usage.FormatPrefix(prefix))
+		m.DBUsage.Register(namespace, dbPrefix)
+	}
+}
+
 func (m *manager) AddRegistrant(r Registrant) {
 	m.registrants = append(m.registrants, r)
 }
@@ -564,12 +603,21 @@ func (m *manager) createAvalancheChain(
 	if err != nil {
 		return nil, err
 	}
+	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 	prefixDB := prefixdb.New(ctx.ChainID[:], meterDB)
-	vmDB := prefixdb.New(VMDBPrefix, prefixDB)
+	vmDB := prefixdb.NewObserved(VMDBPrefix, prefixDB, m.observeVMDB(chainAlias))
 	vertexDB := prefixdb.New(VertexDBPrefix, prefixDB)
 	vertexBootstrappingDB := prefixdb.New(VertexBootstrappingDBPrefix, prefixDB)
 	txBootstrappingDB := prefixdb.New(TxBootstrappingDBPrefix, prefixDB)
 	blockBootstrappingDB := prefixdb.New(BlockBootstrappingDBPrefix, prefixDB)
+	m.registerDBUsage(chainAlias, map[string]*prefixdb.Database{
+		"":                                  prefixDB,
+		string(VMDBPrefix):                  vmDB,
+		string(VertexDBPrefix):              vertexDB,
+		string(VertexBootstrappingDBPrefix): vertexBootstrappingDB,
+		string(TxBootstrappingDBPrefix):     txBootstrappingDB,
+		string(BlockBootstrappingDBPrefix):  blockBootstrappingDB,
+	})
 
 	vtxBlocker, err := queue.NewWithMissing(vertexBootstrappingDB, "vtx", ctx.AvalancheRegisterer)
 	if err != nil {
@@ -700,8 +748,6 @@ func (m *manager) createAvalancheChain(
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
 	)
 
-	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
-
 	// Note: this does not use [dagVM] to ensure we use the [vm]'s height index.
 	untracedVMWrappedInsideProposerVM := NewLinearizeOnInitializeVM(vm)
 
@@ -722,6 +768,7 @@ func (m *manager) createAvalancheChain(
 			NumHistoricalBlocks: numHistoricalBlocks,
 			StakingLeafSigner:   m.StakingTLSSigner,
 			StakingCertLeaf:     m.StakingTLSCert,
+			CachePolicy:         m.ProposerVMCachePolicy,
 		},
 	)
 
@@ -948,9 +995,15 @@ func (m *manager) createSnowmanChain(
 	if err != nil {
 		return nil, err
 	}
+	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 	prefixDB := prefixdb.New(ctx.ChainID[:], meterDB)
-	vmDB := prefixdb.New(VMDBPrefix, prefixDB)
+	vmDB := prefixdb.NewObserved(VMDBPrefix, prefixDB, m.observeVMDB(chainAlias))
 	bootstrappingDB := prefixdb.New(ChainBootstrappingDBPrefix, prefixDB)
+	m.registerDBUsage(chainAlias, map[string]*prefixdb.Database{
+		"":                                 prefixDB,
+		string(VMDBPrefix):                 vmDB,
+		string(ChainBootstrappingDBPrefix): bootstrappingDB,
+	})
 
 	blocked, err := queue.NewWithMissing(bootstrappingDB, "block", ctx.Registerer)
 	if err != nil {
@@ -1044,7 +1097,6 @@ func (m *manager) createSnowmanChain(
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
 	)
 
-	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
 	if m.TracingEnabled {
 		vm = tracedvm.NewBlockVM(vm, chainAlias, m.Tracer)
 	}
@@ -1059,6 +1111,7 @@ func (m *manager) createSnowmanChain(
 			NumHistoricalBlocks: numHistoricalBlocks,
 			StakingLeafSigner:   m.StakingTLSSigner,
 			StakingCertLeaf:     m.StakingTLSCert,
+			CachePolicy:         m.ProposerVMCachePolicy,
 		},
 	)
 
@@ -0,0 +1,759 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package codecgen generates code that (un)marshals structs without
+// reflection. The generated code implements reflectcodec.Marshaler and
+// reflectcodec.Unmarshaler, and is registered with
+// reflectcodec.RegisterGenerated when the package is initialized. Its output
+// is identical to the output of reflectcodec with the same tag names, which is
+// used for the fields that the generated code can't handle itself, such as
+// interfaces and maps.
+//
+// The generator inspects types with reflection, so it's run by a small
+// program that imports the package of the types:
+//
+//	//go:generate go run ./gen
+//
+// where ./gen writes the output of Generate to a file in the package. If the
+// generated file doesn't compile, it must be removed before it can be
+// generated again.
+package codecgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	codecPath        = "github.com/MetalBlockchain/metalgo/codec"
+	reflectcodecPath = "github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	wrappersPath     = "github.com/MetalBlockchain/metalgo/utils/wrappers"
+
+	// The capacity of slices before their elements are unmarshaled, which
+	// matches reflectcodec.
+	initialSliceLen = 16
+)
+
+var (
+	errNoTypes        = errors.New("no types to generate code for")
+	errNotStruct      = errors.New("type isn't a struct")
+	errWrongPackage   = errors.New("types are defined in different packages")
+	errDuplicatedType = errors.New("duplicated type")
+
+	byteType = reflect.TypeOf(byte(0))
+)
+
+// Generate returns the source of a file that (un)marshals the struct types of
+// [values] with the struct fields that have any of the [tagNames]. The types
+// must be defined in the same package, which is the package of the file.
+func Generate(tagNames []string, values ...interface{}) ([]byte, error) {
+	if len(values) == 0 {
+		return nil, errNoTypes
+	}
+
+	g := &generator{
+		tagNames:  tagNames,
+		fielder:   reflectcodec.NewStructFielder(tagNames),
+		generated: set.NewSet[reflect.Type](len(values)),
+		imports: map[string]string{
+			codecPath:        "codec",
+			reflectcodecPath: "reflectcodec",
+			wrappersPath:     "wrappers",
+			"fmt":            "fmt",
+			"math":           "math",
+		},
+		used: set.Of(reflectcodecPath, wrappersPath),
+		names: set.Of(
+			"c", "p", "v", "size", "fieldSize", "err",
+			"codec", "reflectcodec", "wrappers", "fmt", "math",
+		),
+	}
+	types := make([]reflect.Type, len(values))
+	for i, value := range values {
+		t := reflect.TypeOf(value)
+		if t == nil || t.Kind() != reflect.Struct || t.Name() == "" {
+			return nil, fmt.Errorf("%w: %v", errNotStruct, t)
+		}
+		if i == 0 {
+			g.pkgPath = t.PkgPath()
+		}
+		if t.PkgPath() != g.pkgPath {
+			return nil, fmt.Errorf("%w: %s isn't in %s", errWrongPackage, t, g.pkgPath)
+		}
+		if g.generated.Contains(t) {
+			return nil, fmt.Errorf("%w: %s", errDuplicatedType, t)
+		}
+		g.generated.Add(t)
+		types[i] = t
+	}
+
+	var body bytes.Buffer
+	for _, t := range types {
+		if err := g.generateType(&body, t); err != nil {
+			return nil, err
+		}
+	}
+	return g.file(types, body.Bytes())
+}
+
+type generator struct {
+	pkgPath  string
+	tagNames []string
+	fielder  reflectcodec.StructFielder
+
+	// The struct types that code is being generated for.
+	generated set.Set[reflect.Type]
+	// The structs that are currently being inlined, which are (un)marshaled
+	// with reflection if they're nested in themselves.
+	inlining set.Set[reflect.Type]
+
+	// Import path -> package name
+	imports map[string]string
+	// The import paths of the packages that the generated code uses.
+	used set.Set[string]
+	// Identifiers that imported packages can't be named.
+	names set.Set[string]
+
+	// The code of the function that is being generated.
+	buf *bytes.Buffer
+	// The number of local variables declared in the function that is being
+	// generated, which is used to give them unique names.
+	numVars int
+	// Whether the function that is being generated uses fieldSize and err.
+	usesFieldSize bool
+	// The size of fixed size fields that hasn't been added to size yet.
+	pendingSize int
+}
+
+func (g *generator) generateType(w *bytes.Buffer, t reflect.Type) error {
+	name := t.Name()
+	fields, err := g.fielder.GetSerializedFields(t)
+	if err != nil {
+		return fmt.Errorf("couldn't generate code for %s: %w", name, err)
+	}
+
+	if err := g.function(w, func() error {
+		for _, i := range fields {
+			field := t.Field(i)
+			if err := g.marshal("v."+field.Name, field.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "func (v *%s) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {\n", name)
+	w.Write(g.buf.Bytes())
+	w.WriteString("return nil\n}\n\n")
+
+	if err := g.function(w, func() error {
+		for _, i := range fields {
+			field := t.Field(i)
+			if err := g.unmarshal("v."+field.Name, field.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "func (v *%s) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {\n", name)
+	w.Write(g.buf.Bytes())
+	w.WriteString("return nil\n}\n\n")
+
+	if err := g.function(w, func() error {
+		for _, i := range fields {
+			field := t.Field(i)
+			if err := g.size("v."+field.Name, field.Type); err != nil {
+				return err
+			}
+		}
+		g.flushSize()
+		return nil
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "func (v *%s) Size(c reflectcodec.FieldCodec) (int, error) {\n", name)
+	if g.usesFieldSize {
+		w.WriteString("var (\nsize int\nfieldSize int\nerr error\n)\n")
+	} else {
+		w.WriteString("size := 0\n")
+	}
+	w.Write(g.buf.Bytes())
+	w.WriteString("return size, nil\n}\n\n")
+	return nil
+}
+
+// function resets the state of the function that is being generated and
+// generates its code with [generate].
+func (g *generator) function(w *bytes.Buffer, generate func() error) error {
+	g.buf = &bytes.Buffer{}
+	g.numVars = 0
+	g.usesFieldSize = false
+	g.pendingSize = 0
+	return generate()
+}
+
+func (g *generator) file(types []reflect.Type, body []byte) ([]byte, error) {
+	var w bytes.Buffer
+	w.WriteString("// Code generated by codecgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&w, "package %s\n\n", packageName(types[0]))
+
+	// Standard library packages are imported before other packages.
+	paths := g.used.List()
+	slices.SortFunc(paths, func(a, b string) int {
+		if aStd, bStd := isStd(a), isStd(b); aStd != bStd {
+			if aStd {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	w.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) != isStd(path) {
+			w.WriteString("\n")
+		}
+		name := g.imports[path]
+		if name == path[strings.LastIndex(path, "/")+1:] {
+			fmt.Fprintf(&w, "%q\n", path)
+		} else {
+			fmt.Fprintf(&w, "%s %q\n", name, path)
+		}
+	}
+	w.WriteString(")\n\n")
+
+	w.WriteString("var (\n")
+	for _, t := range types {
+		fmt.Fprintf(&w, "_ reflectcodec.Marshaler = (*%s)(nil)\n", t.Name())
+		fmt.Fprintf(&w, "_ reflectcodec.Unmarshaler = (*%s)(nil)\n", t.Name())
+	}
+	w.WriteString("\n")
+	fmt.Fprintf(&w, "codecTags = %#v\n", g.tagNames)
+	w.WriteString(")\n\n")
+
+	w.WriteString("func init() {\nreflectcodec.RegisterGenerated(\ncodecTags,\n")
+	for _, t := range types {
+		fmt.Fprintf(&w, "(*%s)(nil),\n", t.Name())
+	}
+	w.WriteString(")\n}\n\n")
+
+	w.Write(body)
+	return format.Source(w.Bytes())
+}
+
+// marshal generates code that writes [x], which is an addressable expression
+// of type [t], into p.
+func (g *generator) marshal(x string, t reflect.Type) error {
+	if size, fixed := g.fixedSize(t); fixed && size == 0 {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Int8:
+		g.pack("p.PackByte(uint8(%s))", x)
+	case reflect.Uint16, reflect.Int16:
+		g.pack("p.PackShort(uint16(%s))", x)
+	case reflect.Uint32, reflect.Int32:
+		g.pack("p.PackInt(uint32(%s))", x)
+	case reflect.Uint64, reflect.Int64:
+		g.pack("p.PackLong(uint64(%s))", x)
+	case reflect.Bool:
+		g.pack("p.PackBool(bool(%s))", x)
+	case reflect.String:
+		g.pack("p.PackStr(string(%s))", x)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && t.Elem() != byteType {
+			g.marshalField(x)
+			return nil
+		}
+
+		g.printf("if len(%s) > math.MaxInt32 {\n", x)
+		g.printf("return fmt.Errorf(\"%%w; slice length, %%d, exceeds maximum length, %%d\", codec.ErrMaxSliceLenExceeded, len(%s), math.MaxInt32)\n}\n", x)
+		g.pack("p.PackInt(uint32(len(%s)))", x)
+		if t.Elem() == byteType {
+			g.pack("p.PackFixedBytes(%s)", x)
+			return nil
+		}
+
+		// Elements with a fixed, non-zero, size can't have zero length.
+		elemSize, fixed := g.fixedSize(t.Elem())
+		if fixed && elemSize == 0 {
+			g.printf("if len(%s) != 0 {\n", x)
+			g.printf("return fmt.Errorf(\"couldn't marshal slice of zero length values: %%w\", codec.ErrMarshalZeroLength)\n}\n")
+			return nil
+		}
+		checkLength := !fixed
+
+		i, start := g.newVar("i"), g.newVar("start")
+		g.printf("for %s := range %s {\n", i, x)
+		if checkLength {
+			g.printf("%s := p.Offset\n", start)
+		}
+		if err := g.marshal(fmt.Sprintf("%s[%s]", x, i), t.Elem()); err != nil {
+			return err
+		}
+		if checkLength {
+			g.printf("if p.Offset == %s {\n", start)
+			g.printf("return fmt.Errorf(\"couldn't marshal slice of zero length values: %%w\", codec.ErrMarshalZeroLength)\n}\n")
+		}
+		g.printf("}\n")
+	case reflect.Array:
+		if t.Elem() == byteType {
+			g.pack("p.PackFixedBytes(%s[:])", x)
+			return nil
+		}
+
+		i := g.newVar("i")
+		g.printf("for %s := range %s {\n", i, x)
+		if err := g.marshal(fmt.Sprintf("%s[%s]", x, i), t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Ptr:
+		g.printf("if %s == nil {\nreturn codec.ErrMarshalNil\n}\n", x)
+		return g.marshal(fmt.Sprintf("(*%s)", x), t.Elem())
+	case reflect.Struct:
+		if g.hasMethods(t) {
+			g.printf("if err := %s.MarshalInto(c, p); err != nil {\nreturn err\n}\n", x)
+			return nil
+		}
+		return g.inline(x, t, g.marshal, g.marshalField)
+	default:
+		g.marshalField(x)
+	}
+	return nil
+}
+
+func (g *generator) marshalField(x string) {
+	g.printf("if err := c.MarshalField(%s, p); err != nil {\nreturn err\n}\n", addr(x))
+}
+
+// pack generates a call to the packer, which returns if the packer errors.
+func (g *generator) pack(format string, args ...interface{}) {
+	g.printf(format+"\n", args...)
+	g.printf("if p.Err != nil {\nreturn p.Err\n}\n")
+}
+
+// unmarshal generates code that reads [x], which is an addressable expression
+// of type [t], from p.
+func (g *generator) unmarshal(x string, t reflect.Type) error {
+	if size, fixed := g.fixedSize(t); fixed && size == 0 {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Int8:
+		g.unpack(x, t, "p.UnpackByte()")
+	case reflect.Uint16, reflect.Int16:
+		g.unpack(x, t, "p.UnpackShort()")
+	case reflect.Uint32, reflect.Int32:
+		g.unpack(x, t, "p.UnpackInt()")
+	case reflect.Uint64, reflect.Int64:
+		g.unpack(x, t, "p.UnpackLong()")
+	case reflect.Bool:
+		g.unpack(x, t, "p.UnpackBool()")
+	case reflect.String:
+		g.unpack(x, t, "p.UnpackStr()")
+	case reflect.Slice:
+		typeName, ok := g.typeName(t)
+		elemName, elemOK := g.typeName(t.Elem())
+		if !ok || !elemOK || (t.Elem().Kind() == reflect.Uint8 && t.Elem() != byteType) {
+			g.unmarshalField(x)
+			return nil
+		}
+
+		numElts := g.newVar("numElts")
+		g.printf("%s := p.UnpackInt()\n", numElts)
+		g.printf("if p.Err != nil {\nreturn fmt.Errorf(\"couldn't unmarshal slice: %%w\", p.Err)\n}\n")
+		g.printf("if %s > math.MaxInt32 {\n", numElts)
+		g.printf("return fmt.Errorf(\"%%w; array length, %%d, exceeds maximum length, %%d\", codec.ErrMaxSliceLenExceeded, %s, math.MaxInt32)\n}\n", numElts)
+		if t.Elem() == byteType {
+			g.printf("%s = p.UnpackFixedBytes(int(%s))\n", x, numElts)
+			g.printf("if p.Err != nil {\nreturn p.Err\n}\n")
+			return nil
+		}
+
+		// Elements with a fixed, non-zero, size can't have zero length.
+		elemSize, fixed := g.fixedSize(t.Elem())
+		checkLength := !fixed || elemSize == 0
+
+		g.useType(t)
+		i, elem, start := g.newVar("i"), g.newVar("elem"), g.newVar("start")
+		g.printf("%s = make(%s, 0, min(int(%s), %d))\n", x, typeName, numElts, initialSliceLen)
+		g.printf("for %s := 0; %s < int(%s); %s++ {\n", i, i, numElts, i)
+		g.printf("var %s %s\n", elem, elemName)
+		if checkLength {
+			g.printf("%s := p.Offset\n", start)
+		}
+		if err := g.unmarshal(elem, t.Elem()); err != nil {
+			return err
+		}
+		if checkLength {
+			g.printf("if %s == p.Offset {\n", start)
+			g.printf("return fmt.Errorf(\"couldn't unmarshal slice of zero length values: %%w\", codec.ErrUnmarshalZeroLength)\n}\n")
+		}
+		g.printf("%s = append(%s, %s)\n}\n", x, x, elem)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if t.Elem() != byteType {
+				g.unmarshalField(x)
+				return nil
+			}
+			g.printf("copy(%s[:], p.UnpackFixedBytes(%d))\n", x, t.Len())
+			g.printf("if p.Err != nil {\nreturn p.Err\n}\n")
+			return nil
+		}
+
+		i := g.newVar("i")
+		g.printf("for %s := range %s {\n", i, x)
+		if err := g.unmarshal(fmt.Sprintf("%s[%s]", x, i), t.Elem()); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case reflect.Ptr:
+		elemName, ok := g.typeName(t.Elem())
+		if !ok {
+			g.unmarshalField(x)
+			return nil
+		}
+
+		g.useType(t)
+		ptr := g.newVar("ptr")
+		g.printf("%s := new(%s)\n", ptr, elemName)
+		if err := g.unmarshal(fmt.Sprintf("(*%s)", ptr), t.Elem()); err != nil {
+			return err
+		}
+		g.printf("%s = %s\n", x, ptr)
+	case reflect.Struct:
+		if g.hasMethods(t) {
+			g.printf("if err := %s.Unmarshal(c, p); err != nil {\nreturn err\n}\n", x)
+			return nil
+		}
+		return g.inline(x, t, g.unmarshal, g.unmarshalField)
+	default:
+		g.unmarshalField(x)
+	}
+	return nil
+}
+
+// unpack generates code that assigns [unpack] to [x], which has the basic type
+// [t].
+func (g *generator) unpack(x string, t reflect.Type, unpack string) {
+	typeName, ok := g.typeName(t)
+	if !ok {
+		g.unmarshalField(x)
+		return
+	}
+	g.useType(t)
+	g.printf("%s = %s(%s)\n", x, typeName, unpack)
+	g.printf("if p.Err != nil {\nreturn fmt.Errorf(\"couldn't unmarshal %s: %%w\", p.Err)\n}\n", t.Kind())
+}
+
+func (g *generator) unmarshalField(x string) {
+	g.printf("if err := c.UnmarshalField(p, %s); err != nil {\nreturn err\n}\n", addr(x))
+}
+
+// size generates code that adds the size of [x], which is an addressable
+// expression of type [t], to size.
+func (g *generator) size(x string, t reflect.Type) error {
+	if fixedSize, ok := g.fixedSize(t); ok {
+		g.pendingSize += fixedSize
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		g.addSize("wrappers.StringLen(string(%s))", x)
+	case reflect.Slice:
+		g.pendingSize += wrappers.IntLen
+		if t.Elem() == byteType {
+			g.addSize("len(%s)", x)
+			return nil
+		}
+		if elemSize, ok := g.fixedSize(t.Elem()); ok {
+			if elemSize == 0 {
+				g.printf("if len(%s) != 0 {\n", x)
+				g.printf("return 0, fmt.Errorf(\"can't marshal slice of zero length values: %%w\", codec.ErrMarshalZeroLength)\n}\n")
+				return nil
+			}
+			g.addSize("%d * len(%s)", elemSize, x)
+			return nil
+		}
+
+		g.flushSize()
+		i, start := g.newVar("i"), g.newVar("start")
+		g.printf("%s := size\n", start)
+		g.printf("for %s := range %s {\n", i, x)
+		if err := g.size(fmt.Sprintf("%s[%s]", x, i), t.Elem()); err != nil {
+			return err
+		}
+		g.flushSize()
+		g.printf("if %s == 0 && size == %s {\n", i, start)
+		g.printf("return 0, fmt.Errorf(\"can't marshal slice of zero length values: %%w\", codec.ErrMarshalZeroLength)\n}\n}\n")
+	case reflect.Array:
+		g.flushSize()
+		i := g.newVar("i")
+		g.printf("for %s := range %s {\n", i, x)
+		if err := g.size(fmt.Sprintf("%s[%s]", x, i), t.Elem()); err != nil {
+			return err
+		}
+		g.flushSize()
+		g.printf("}\n")
+	case reflect.Ptr:
+		g.printf("if %s == nil {\nreturn 0, codec.ErrMarshalNil\n}\n", x)
+		return g.size(fmt.Sprintf("(*%s)", x), t.Elem())
+	case reflect.Struct:
+		if g.hasMethods(t) {
+			g.flushSize()
+			g.usesFieldSize = true
+			g.printf("fieldSize, err = %s.Size(c)\n", x)
+			g.printf("if err != nil {\nreturn 0, err\n}\n")
+			g.printf("size += fieldSize\n")
+			return nil
+		}
+		return g.inline(x, t, g.size, g.sizeField)
+	default:
+		g.sizeField(x)
+	}
+	return nil
+}
+
+func (g *generator) sizeField(x string) {
+	g.flushSize()
+	g.usesFieldSize = true
+	g.printf("fieldSize, err = c.FieldSize(%s)\n", addr(x))
+	g.printf("if err != nil {\nreturn 0, err\n}\n")
+	g.printf("size += fieldSize\n")
+}
+
+func (g *generator) addSize(format string, args ...interface{}) {
+	g.flushSize()
+	g.printf("size += "+format+"\n", args...)
+}
+
+// flushSize adds the size of the fixed size fields that haven't been added
+// yet to size.
+func (g *generator) flushSize() {
+	if g.pendingSize != 0 {
+		g.printf("size += %d\n", g.pendingSize)
+		g.pendingSize = 0
+	}
+}
+
+// fixedSize returns the size of [t] if every value of [t] has the same size.
+func (g *generator) fixedSize(t reflect.Type) (int, bool) {
+	switch t.Kind() {
+	case reflect.Uint8, reflect.Int8:
+		return wrappers.ByteLen, true
+	case reflect.Uint16, reflect.Int16:
+		return wrappers.ShortLen, true
+	case reflect.Uint32, reflect.Int32:
+		return wrappers.IntLen, true
+	case reflect.Uint64, reflect.Int64:
+		return wrappers.LongLen, true
+	case reflect.Bool:
+		return wrappers.BoolLen, true
+	case reflect.Array:
+		if t.Len() == 0 {
+			return 0, true
+		}
+		elemSize, ok := g.fixedSize(t.Elem())
+		return t.Len() * elemSize, ok
+	case reflect.Struct:
+		if g.inlining.Contains(t) {
+			return 0, false
+		}
+		fields, err := g.fielder.GetSerializedFields(t)
+		if err != nil {
+			return 0, false
+		}
+
+		g.inlining.Add(t)
+		defer g.inlining.Remove(t)
+
+		size := 0
+		for _, i := range fields {
+			fieldSize, ok := g.fixedSize(t.Field(i).Type)
+			if !ok {
+				return 0, false
+			}
+			size += fieldSize
+		}
+		return size, true
+	default:
+		return 0, false
+	}
+}
+
+// inline generates code for each serialized field of the struct [x], which
+// has the type [t], with [generate]. If [t] is nested in itself, [fallback] is
+// used instead.
+func (g *generator) inline(
+	x string,
+	t reflect.Type,
+	generate func(string, reflect.Type) error,
+	fallback func(string),
+) error {
+	if g.inlining.Contains(t) {
+		fallback(x)
+		return nil
+	}
+	fields, err := g.fielder.GetSerializedFields(t)
+	if err != nil {
+		return fmt.Errorf("couldn't generate code for %s: %w", t, err)
+	}
+
+	g.inlining.Add(t)
+	defer g.inlining.Remove(t)
+
+	for _, i := range fields {
+		field := t.Field(i)
+		if err := generate(x+"."+field.Name, field.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasMethods returns true if the struct [t] has generated code for the tag
+// names that code is being generated for, either by this generator or by the
+// generated code of an imported package.
+func (g *generator) hasMethods(t reflect.Type) bool {
+	return g.generated.Contains(t) || reflectcodec.HasGenerated(t, g.tagNames)
+}
+
+// typeName returns the name of [t] in the generated code, if [t] can be named.
+func (g *generator) typeName(t reflect.Type) (string, bool) {
+	if name := t.Name(); name != "" {
+		switch pkgPath := t.PkgPath(); {
+		case pkgPath == "":
+			return name, true
+		case strings.Contains(name, "["):
+			// The type arguments of generic types aren't supported.
+			return "", false
+		case pkgPath == g.pkgPath:
+			return name, true
+		case !token.IsExported(name):
+			return "", false
+		default:
+			return g.importName(pkgPath) + "." + name, true
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		elemName, ok := g.typeName(t.Elem())
+		return "[]" + elemName, ok
+	case reflect.Array:
+		elemName, ok := g.typeName(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elemName), ok
+	case reflect.Ptr:
+		elemName, ok := g.typeName(t.Elem())
+		return "*" + elemName, ok
+	case reflect.Map:
+		keyName, keyOK := g.typeName(t.Key())
+		elemName, elemOK := g.typeName(t.Elem())
+		return fmt.Sprintf("map[%s]%s", keyName, elemName), keyOK && elemOK
+	case reflect.Interface:
+		return "interface{}", t.NumMethod() == 0
+	default:
+		return "", false
+	}
+}
+
+// importName returns the name of the package with [path] in the generated
+// code. The package is only imported if it's used. See useType.
+func (g *generator) importName(path string) string {
+	if name, ok := g.imports[path]; ok {
+		return name
+	}
+	base := path[strings.LastIndex(path, "/")+1:]
+	name := base
+	for i := 2; g.names.Contains(name); i++ {
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+	g.names.Add(name)
+	g.imports[path] = name
+	return name
+}
+
+// useType imports the packages that are used to name [t].
+func (g *generator) useType(t reflect.Type) {
+	if t.Name() != "" {
+		if pkgPath := t.PkgPath(); pkgPath != "" && pkgPath != g.pkgPath {
+			g.used.Add(pkgPath)
+		}
+		return
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Ptr:
+		g.useType(t.Elem())
+	case reflect.Map:
+		g.useType(t.Key())
+		g.useType(t.Elem())
+	}
+}
+
+func (g *generator) newVar(prefix string) string {
+	g.numVars++
+	return fmt.Sprintf("%s%d", prefix, g.numVars)
+}
+
+// printf writes code to the function that is being generated, and imports the
+// packages that are used in [format].
+func (g *generator) printf(format string, args ...interface{}) {
+	for path, selector := range map[string]string{
+		codecPath: "codec.",
+		"fmt":     "fmt.",
+		"math":    "math.",
+	} {
+		if strings.Contains(format, selector) {
+			g.used.Add(path)
+		}
+	}
+	fmt.Fprintf(g.buf, format, args...)
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// addr returns the address of the addressable expression [x].
+func addr(x string) string {
+	if !strings.HasPrefix(x, "(*") {
+		return "&" + x
+	}
+	// If [x] is a dereferenced pointer, return the pointer.
+	depth := 0
+	for i, r := range x {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 && i != len(x)-1 {
+				return "&" + x
+			}
+		}
+	}
+	return x[2 : len(x)-1]
+}
+
+// packageName returns the name of the package that defines [t].
+func packageName(t reflect.Type) string {
+	name := t.String()
+	return name[:strings.LastIndex(name, ".")]
+}
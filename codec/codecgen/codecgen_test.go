@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/codecgen/codecgentest"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+)
+
+type unexportedField struct {
+	value uint64 `serialize:"true"`
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []interface{}
+		expectedErr error
+	}{
+		{
+			name:        "no types",
+			expectedErr: errNoTypes,
+		},
+		{
+			name:        "not a struct",
+			values:      []interface{}{uint64(0)},
+			expectedErr: errNotStruct,
+		},
+		{
+			name:        "pointer",
+			values:      []interface{}{&codecgentest.Block{}},
+			expectedErr: errNotStruct,
+		},
+		{
+			name:        "different packages",
+			values:      []interface{}{codecgentest.Block{}, unexportedField{}},
+			expectedErr: errWrongPackage,
+		},
+		{
+			name:        "duplicated type",
+			values:      []interface{}{codecgentest.Block{}, codecgentest.Block{}},
+			expectedErr: errDuplicatedType,
+		},
+		{
+			name:        "unexported field",
+			values:      []interface{}{unexportedField{}},
+			expectedErr: codec.ErrUnexportedField,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Generate([]string{reflectcodec.DefaultTagName}, test.values...)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAddr(t *testing.T) {
+	tests := []struct {
+		x        string
+		expected string
+	}{
+		{
+			x:        "v.Field",
+			expected: "&v.Field",
+		},
+		{
+			x:        "(*v.Field)",
+			expected: "v.Field",
+		},
+		{
+			x:        "(*(*v.Field).Next)",
+			expected: "(*v.Field).Next",
+		},
+		{
+			x:        "(*v.Field).Next",
+			expected: "&(*v.Field).Next",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.x, func(t *testing.T) {
+			require.Equal(t, test.expected, addr(test.x))
+		})
+	}
+}
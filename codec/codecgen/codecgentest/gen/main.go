@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MetalBlockchain/metalgo/codec/codecgen"
+	"github.com/MetalBlockchain/metalgo/codec/codecgen/codecgentest"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+)
+
+const fileName = "types_codec.go"
+
+func main() {
+	src, err := codecgen.Generate(
+		[]string{reflectcodec.DefaultTagName},
+		codecgentest.GeneratedTypes...,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't generate code: %s\n", err)
+		os.Exit(1)
+	}
+	if err := perms.WriteFile(fileName, src, perms.ReadWrite); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't write %s: %s\n", fileName, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package codecgentest has types with generated marshaling code, which are
+// used to test that the generated code is identical to reflection.
+package codecgentest
+
+import "github.com/MetalBlockchain/metalgo/ids"
+
+//go:generate go run ./gen
+
+var (
+	// GeneratedTypes are the types that types_codec.go is generated for.
+	GeneratedTypes = []interface{}{
+		Block{},
+		Header{},
+		Output{},
+		Square{},
+		Circle{},
+		Tree{},
+	}
+
+	// RegisteredTypes are the implementations of Shape.
+	RegisteredTypes = []interface{}{
+		Square{},
+		&Circle{},
+	}
+
+	_ Shape = Square{}
+	_ Shape = (*Circle)(nil)
+)
+
+type Status uint32
+
+type Bytes []byte
+
+type Shape interface {
+	Area() uint64
+}
+
+type Square struct {
+	Side uint32 `serialize:"true"`
+}
+
+func (s Square) Area() uint64 {
+	return uint64(s.Side) * uint64(s.Side)
+}
+
+type Circle struct {
+	Radius uint16 `serialize:"true"`
+	Label  string `serialize:"true"`
+}
+
+func (c *Circle) Area() uint64 {
+	return 3 * uint64(c.Radius) * uint64(c.Radius)
+}
+
+type Header struct {
+	ParentID  ids.ID     `serialize:"true"`
+	Height    uint64     `serialize:"true"`
+	Timestamp int64      `serialize:"true"`
+	Proposer  ids.NodeID `serialize:"true"`
+	Status    Status     `serialize:"true"`
+
+	// Not serialized
+	id ids.ID
+}
+
+func (h *Header) ID() ids.ID {
+	return h.id
+}
+
+// SignedHeader isn't generated, but it embeds a generated struct, so it has
+// the methods of the generated code, which must not be used to (un)marshal it.
+type SignedHeader struct {
+	Header `serialize:"true"`
+
+	Signature []byte `serialize:"true"`
+}
+
+// Input isn't generated, so it's inlined into the types that contain it.
+type Input struct {
+	TxID   ids.ID   `serialize:"true"`
+	Index  uint32   `serialize:"true"`
+	Sigs   []uint32 `serialize:"true"`
+	Shifts [2]int16 `serialize:"true"`
+}
+
+type Output struct {
+	Amount  uint64          `serialize:"true"`
+	Locked  bool            `serialize:"true"`
+	Owners  []ids.ShortID   `serialize:"true"`
+	Memo    Bytes           `serialize:"true"`
+	Aliases map[string]int8 `serialize:"true"`
+}
+
+// Node isn't generated, and is nested in itself, so the nested nodes are
+// (un)marshaled with reflection.
+type Node struct {
+	Value    uint8  `serialize:"true"`
+	Children []Node `serialize:"true"`
+}
+
+type Tree struct {
+	Value    string  `serialize:"true"`
+	Children []*Tree `serialize:"true"`
+}
+
+type Block struct {
+	Header `serialize:"true"`
+
+	Version  uint16      `serialize:"true"`
+	Flags    int8        `serialize:"true"`
+	Epoch    int32       `serialize:"true"`
+	Payload  []byte      `serialize:"true"`
+	Checksum [4]byte     `serialize:"true"`
+	Parents  []ids.ID    `serialize:"true"`
+	Inputs   []Input     `serialize:"true"`
+	Outputs  []*Output   `serialize:"true"`
+	Change   *Output     `serialize:"true"`
+	Shape    Shape       `serialize:"true"`
+	Shapes   []Shape     `serialize:"true"`
+	Matrix   [2][]uint16 `serialize:"true"`
+	Statuses []Status    `serialize:"true"`
+	Root     *Node       `serialize:"true"`
+	Tree     Tree        `serialize:"true"`
+	Memos    []string    `serialize:"true"`
+	Empty    []struct{}  `serialize:"true"`
+	Extra    *struct {
+		A bool `serialize:"true"`
+	} `serialize:"true"`
+
+	// Not serialized
+	bytes []byte
+}
+
+func (b *Block) Bytes() []byte {
+	return b.bytes
+}
@@ -0,0 +1,884 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package codecgentest
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+var (
+	_ reflectcodec.Marshaler   = (*Block)(nil)
+	_ reflectcodec.Unmarshaler = (*Block)(nil)
+	_ reflectcodec.Marshaler   = (*Header)(nil)
+	_ reflectcodec.Unmarshaler = (*Header)(nil)
+	_ reflectcodec.Marshaler   = (*Output)(nil)
+	_ reflectcodec.Unmarshaler = (*Output)(nil)
+	_ reflectcodec.Marshaler   = (*Square)(nil)
+	_ reflectcodec.Unmarshaler = (*Square)(nil)
+	_ reflectcodec.Marshaler   = (*Circle)(nil)
+	_ reflectcodec.Unmarshaler = (*Circle)(nil)
+	_ reflectcodec.Marshaler   = (*Tree)(nil)
+	_ reflectcodec.Unmarshaler = (*Tree)(nil)
+
+	codecTags = []string{"serialize"}
+)
+
+func init() {
+	reflectcodec.RegisterGenerated(
+		codecTags,
+		(*Block)(nil),
+		(*Header)(nil),
+		(*Output)(nil),
+		(*Square)(nil),
+		(*Circle)(nil),
+		(*Tree)(nil),
+	)
+}
+
+func (v *Block) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.Header.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackShort(uint16(v.Version))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackByte(uint8(v.Flags))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.Epoch))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Payload) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Payload), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Payload)))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.Payload)
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.Checksum[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Parents) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Parents), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Parents)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Parents {
+		p.PackFixedBytes(v.Parents[i1][:])
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	if len(v.Inputs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Inputs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Inputs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i3 := range v.Inputs {
+		start4 := p.Offset
+		p.PackFixedBytes(v.Inputs[i3].TxID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		p.PackInt(uint32(v.Inputs[i3].Index))
+		if p.Err != nil {
+			return p.Err
+		}
+		if len(v.Inputs[i3].Sigs) > math.MaxInt32 {
+			return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Inputs[i3].Sigs), math.MaxInt32)
+		}
+		p.PackInt(uint32(len(v.Inputs[i3].Sigs)))
+		if p.Err != nil {
+			return p.Err
+		}
+		for i5 := range v.Inputs[i3].Sigs {
+			p.PackInt(uint32(v.Inputs[i3].Sigs[i5]))
+			if p.Err != nil {
+				return p.Err
+			}
+		}
+		for i7 := range v.Inputs[i3].Shifts {
+			p.PackShort(uint16(v.Inputs[i3].Shifts[i7]))
+			if p.Err != nil {
+				return p.Err
+			}
+		}
+		if p.Offset == start4 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if len(v.Outputs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Outputs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Outputs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i8 := range v.Outputs {
+		start9 := p.Offset
+		if v.Outputs[i8] == nil {
+			return codec.ErrMarshalNil
+		}
+		if err := (*v.Outputs[i8]).MarshalInto(c, p); err != nil {
+			return err
+		}
+		if p.Offset == start9 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if v.Change == nil {
+		return codec.ErrMarshalNil
+	}
+	if err := (*v.Change).MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := c.MarshalField(&v.Shape, p); err != nil {
+		return err
+	}
+	if len(v.Shapes) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Shapes), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Shapes)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i10 := range v.Shapes {
+		start11 := p.Offset
+		if err := c.MarshalField(&v.Shapes[i10], p); err != nil {
+			return err
+		}
+		if p.Offset == start11 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	for i12 := range v.Matrix {
+		if len(v.Matrix[i12]) > math.MaxInt32 {
+			return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Matrix[i12]), math.MaxInt32)
+		}
+		p.PackInt(uint32(len(v.Matrix[i12])))
+		if p.Err != nil {
+			return p.Err
+		}
+		for i13 := range v.Matrix[i12] {
+			p.PackShort(uint16(v.Matrix[i12][i13]))
+			if p.Err != nil {
+				return p.Err
+			}
+		}
+	}
+	if len(v.Statuses) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Statuses), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Statuses)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i15 := range v.Statuses {
+		p.PackInt(uint32(v.Statuses[i15]))
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	if v.Root == nil {
+		return codec.ErrMarshalNil
+	}
+	p.PackByte(uint8((*v.Root).Value))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len((*v.Root).Children) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len((*v.Root).Children), math.MaxInt32)
+	}
+	p.PackInt(uint32(len((*v.Root).Children)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i17 := range (*v.Root).Children {
+		start18 := p.Offset
+		if err := c.MarshalField(&(*v.Root).Children[i17], p); err != nil {
+			return err
+		}
+		if p.Offset == start18 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if err := v.Tree.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if len(v.Memos) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Memos), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Memos)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i19 := range v.Memos {
+		start20 := p.Offset
+		p.PackStr(string(v.Memos[i19]))
+		if p.Err != nil {
+			return p.Err
+		}
+		if p.Offset == start20 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if len(v.Empty) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Empty), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Empty)))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Empty) != 0 {
+		return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+	}
+	if v.Extra == nil {
+		return codec.ErrMarshalNil
+	}
+	p.PackBool(bool((*v.Extra).A))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *Block) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.Header.Unmarshal(c, p); err != nil {
+		return err
+	}
+	v.Version = uint16(p.UnpackShort())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint16: %w", p.Err)
+	}
+	v.Flags = int8(p.UnpackByte())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal int8: %w", p.Err)
+	}
+	v.Epoch = int32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal int32: %w", p.Err)
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Payload = p.UnpackFixedBytes(int(numElts1))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.Checksum[:], p.UnpackFixedBytes(4))
+	if p.Err != nil {
+		return p.Err
+	}
+	numElts2 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts2 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts2, math.MaxInt32)
+	}
+	v.Parents = make([]ids.ID, 0, min(int(numElts2), 16))
+	for i3 := 0; i3 < int(numElts2); i3++ {
+		var elem4 ids.ID
+		copy(elem4[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Parents = append(v.Parents, elem4)
+	}
+	numElts6 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts6 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts6, math.MaxInt32)
+	}
+	v.Inputs = make([]Input, 0, min(int(numElts6), 16))
+	for i7 := 0; i7 < int(numElts6); i7++ {
+		var elem8 Input
+		start9 := p.Offset
+		copy(elem8.TxID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		elem8.Index = uint32(p.UnpackInt())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+		}
+		numElts10 := p.UnpackInt()
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+		}
+		if numElts10 > math.MaxInt32 {
+			return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts10, math.MaxInt32)
+		}
+		elem8.Sigs = make([]uint32, 0, min(int(numElts10), 16))
+		for i11 := 0; i11 < int(numElts10); i11++ {
+			var elem12 uint32
+			elem12 = uint32(p.UnpackInt())
+			if p.Err != nil {
+				return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+			}
+			elem8.Sigs = append(elem8.Sigs, elem12)
+		}
+		for i14 := range elem8.Shifts {
+			elem8.Shifts[i14] = int16(p.UnpackShort())
+			if p.Err != nil {
+				return fmt.Errorf("couldn't unmarshal int16: %w", p.Err)
+			}
+		}
+		if start9 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Inputs = append(v.Inputs, elem8)
+	}
+	numElts15 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts15 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts15, math.MaxInt32)
+	}
+	v.Outputs = make([]*Output, 0, min(int(numElts15), 16))
+	for i16 := 0; i16 < int(numElts15); i16++ {
+		var elem17 *Output
+		start18 := p.Offset
+		ptr19 := new(Output)
+		if err := (*ptr19).Unmarshal(c, p); err != nil {
+			return err
+		}
+		elem17 = ptr19
+		if start18 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Outputs = append(v.Outputs, elem17)
+	}
+	ptr20 := new(Output)
+	if err := (*ptr20).Unmarshal(c, p); err != nil {
+		return err
+	}
+	v.Change = ptr20
+	if err := c.UnmarshalField(p, &v.Shape); err != nil {
+		return err
+	}
+	numElts21 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts21 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts21, math.MaxInt32)
+	}
+	v.Shapes = make([]Shape, 0, min(int(numElts21), 16))
+	for i22 := 0; i22 < int(numElts21); i22++ {
+		var elem23 Shape
+		start24 := p.Offset
+		if err := c.UnmarshalField(p, &elem23); err != nil {
+			return err
+		}
+		if start24 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Shapes = append(v.Shapes, elem23)
+	}
+	for i25 := range v.Matrix {
+		numElts26 := p.UnpackInt()
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+		}
+		if numElts26 > math.MaxInt32 {
+			return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts26, math.MaxInt32)
+		}
+		v.Matrix[i25] = make([]uint16, 0, min(int(numElts26), 16))
+		for i27 := 0; i27 < int(numElts26); i27++ {
+			var elem28 uint16
+			elem28 = uint16(p.UnpackShort())
+			if p.Err != nil {
+				return fmt.Errorf("couldn't unmarshal uint16: %w", p.Err)
+			}
+			v.Matrix[i25] = append(v.Matrix[i25], elem28)
+		}
+	}
+	numElts30 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts30 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts30, math.MaxInt32)
+	}
+	v.Statuses = make([]Status, 0, min(int(numElts30), 16))
+	for i31 := 0; i31 < int(numElts30); i31++ {
+		var elem32 Status
+		elem32 = Status(p.UnpackInt())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+		}
+		v.Statuses = append(v.Statuses, elem32)
+	}
+	ptr34 := new(Node)
+	(*ptr34).Value = uint8(p.UnpackByte())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint8: %w", p.Err)
+	}
+	numElts35 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts35 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts35, math.MaxInt32)
+	}
+	(*ptr34).Children = make([]Node, 0, min(int(numElts35), 16))
+	for i36 := 0; i36 < int(numElts35); i36++ {
+		var elem37 Node
+		start38 := p.Offset
+		if err := c.UnmarshalField(p, &elem37); err != nil {
+			return err
+		}
+		if start38 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		(*ptr34).Children = append((*ptr34).Children, elem37)
+	}
+	v.Root = ptr34
+	if err := v.Tree.Unmarshal(c, p); err != nil {
+		return err
+	}
+	numElts39 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts39 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts39, math.MaxInt32)
+	}
+	v.Memos = make([]string, 0, min(int(numElts39), 16))
+	for i40 := 0; i40 < int(numElts39); i40++ {
+		var elem41 string
+		start42 := p.Offset
+		elem41 = string(p.UnpackStr())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
+		}
+		if start42 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Memos = append(v.Memos, elem41)
+	}
+	if err := c.UnmarshalField(p, &v.Empty); err != nil {
+		return err
+	}
+	if err := c.UnmarshalField(p, &v.Extra); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *Block) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 83
+	size += len(v.Payload)
+	size += 8
+	size += 32 * len(v.Parents)
+	size += 4
+	start2 := size
+	for i1 := range v.Inputs {
+		size += 40
+		size += 4 * len(v.Inputs[i1].Sigs)
+		size += 4
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	size += 4
+	start4 := size
+	for i3 := range v.Outputs {
+		if v.Outputs[i3] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		fieldSize, err = (*v.Outputs[i3]).Size(c)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i3 == 0 && size == start4 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if v.Change == nil {
+		return 0, codec.ErrMarshalNil
+	}
+	fieldSize, err = (*v.Change).Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.FieldSize(&v.Shape)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 4
+	start6 := size
+	for i5 := range v.Shapes {
+		fieldSize, err = c.FieldSize(&v.Shapes[i5])
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i5 == 0 && size == start6 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	for i7 := range v.Matrix {
+		size += 4
+		size += 2 * len(v.Matrix[i7])
+	}
+	size += 4
+	size += 4 * len(v.Statuses)
+	if v.Root == nil {
+		return 0, codec.ErrMarshalNil
+	}
+	size += 5
+	start9 := size
+	for i8 := range (*v.Root).Children {
+		fieldSize, err = c.FieldSize(&(*v.Root).Children[i8])
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i8 == 0 && size == start9 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	fieldSize, err = v.Tree.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 4
+	start11 := size
+	for i10 := range v.Memos {
+		size += wrappers.StringLen(string(v.Memos[i10]))
+		if i10 == 0 && size == start11 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if len(v.Empty) != 0 {
+		return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+	}
+	if v.Extra == nil {
+		return 0, codec.ErrMarshalNil
+	}
+	size += 5
+	return size, nil
+}
+
+func (v *Header) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.ParentID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.Height))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.Timestamp))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.Proposer[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.Status))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *Header) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	copy(v.ParentID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Height = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.Timestamp = int64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal int64: %w", p.Err)
+	}
+	copy(v.Proposer[:], p.UnpackFixedBytes(20))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Status = Status(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *Header) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 72
+	return size, nil
+}
+
+func (v *Output) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Amount))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackBool(bool(v.Locked))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Owners) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Owners), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Owners)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Owners {
+		p.PackFixedBytes(v.Owners[i1][:])
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	if len(v.Memo) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Memo), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Memo)))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.Memo)
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.MarshalField(&v.Aliases, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *Output) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Amount = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.Locked = bool(p.UnpackBool())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal bool: %w", p.Err)
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Owners = make([]ids.ShortID, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 ids.ShortID
+		copy(elem3[:], p.UnpackFixedBytes(20))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Owners = append(v.Owners, elem3)
+	}
+	numElts5 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts5 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts5, math.MaxInt32)
+	}
+	v.Memo = p.UnpackFixedBytes(int(numElts5))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalField(p, &v.Aliases); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *Output) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 13
+	size += 20 * len(v.Owners)
+	size += 4
+	size += len(v.Memo)
+	fieldSize, err = c.FieldSize(&v.Aliases)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *Square) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackInt(uint32(v.Side))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *Square) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Side = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *Square) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 4
+	return size, nil
+}
+
+func (v *Circle) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackShort(uint16(v.Radius))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackStr(string(v.Label))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *Circle) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Radius = uint16(p.UnpackShort())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint16: %w", p.Err)
+	}
+	v.Label = string(p.UnpackStr())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *Circle) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 2
+	size += wrappers.StringLen(string(v.Label))
+	return size, nil
+}
+
+func (v *Tree) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackStr(string(v.Value))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Children) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Children), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Children)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Children {
+		start2 := p.Offset
+		if v.Children[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		if err := (*v.Children[i1]).MarshalInto(c, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return nil
+}
+
+func (v *Tree) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Value = string(p.UnpackStr())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Children = make([]*Tree, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *Tree
+		start4 := p.Offset
+		ptr5 := new(Tree)
+		if err := (*ptr5).Unmarshal(c, p); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Children = append(v.Children, elem3)
+	}
+	return nil
+}
+
+func (v *Tree) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += wrappers.StringLen(string(v.Value))
+	size += 4
+	start2 := size
+	for i1 := range v.Children {
+		if v.Children[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		fieldSize, err = (*v.Children[i1]).Size(c)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return size, nil
+}
@@ -0,0 +1,336 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecgentest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/codecgen"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+const codecVersion = 0
+
+func newManager(t testing.TB, c linearcodec.Codec) codec.Manager {
+	require := require.New(t)
+
+	for _, value := range RegisteredTypes {
+		require.NoError(c.RegisterType(value))
+	}
+	m := codec.NewDefaultManager()
+	require.NoError(m.RegisterCodec(codecVersion, c))
+	return m
+}
+
+func newBlock() *Block {
+	return &Block{
+		Header: Header{
+			ParentID:  ids.GenerateTestID(),
+			Height:    10,
+			Timestamp: -5,
+			Proposer:  ids.GenerateTestNodeID(),
+			Status:    3,
+		},
+		Version:  1,
+		Flags:    -2,
+		Epoch:    7,
+		Payload:  []byte{1, 2, 3},
+		Checksum: [4]byte{4, 5, 6, 7},
+		Parents:  []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()},
+		Inputs: []Input{
+			{
+				TxID:   ids.GenerateTestID(),
+				Index:  1,
+				Sigs:   []uint32{0, 1},
+				Shifts: [2]int16{-1, 1},
+			},
+		},
+		Outputs: []*Output{
+			{
+				Amount: 100,
+				Locked: true,
+				Owners: []ids.ShortID{ids.GenerateTestShortID()},
+				Memo:   Bytes("memo"),
+				Aliases: map[string]int8{
+					"a": 1,
+					"b": -1,
+				},
+			},
+		},
+		Change: &Output{},
+		Shape:  &Circle{Radius: 2, Label: "circle"},
+		Shapes: []Shape{Square{Side: 3}, &Circle{}},
+		Matrix: [2][]uint16{{1, 2}, {}},
+		Statuses: []Status{
+			1,
+			2,
+		},
+		Root: &Node{
+			Value: 1,
+			Children: []Node{
+				{Value: 2},
+				{Value: 3, Children: []Node{{Value: 4}}},
+			},
+		},
+		Tree: Tree{
+			Value: "root",
+			Children: []*Tree{
+				{Value: "child"},
+			},
+		},
+		Memos: []string{"", "memo"},
+		Extra: &struct {
+			A bool `serialize:"true"`
+		}{A: true},
+	}
+}
+
+// Test that the generated code is the same as the code that is generated by
+// the current generator.
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	require := require.New(t)
+
+	expected, err := codecgen.Generate(
+		[]string{reflectcodec.DefaultTagName},
+		GeneratedTypes...,
+	)
+	require.NoError(err)
+
+	generated, err := os.ReadFile("types_codec.go")
+	require.NoError(err)
+	require.Equal(string(expected), string(generated), "run go generate")
+}
+
+func TestGeneratedCodec(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		generated  = newManager(t, linearcodec.NewDefault())
+		reflective = newManager(t, linearcodec.NewReflective([]string{reflectcodec.DefaultTagName}))
+		block      = newBlock()
+	)
+
+	expectedBytes, err := reflective.Marshal(codecVersion, block)
+	require.NoError(err)
+	generatedBytes, err := generated.Marshal(codecVersion, block)
+	require.NoError(err)
+	require.Equal(expectedBytes, generatedBytes)
+
+	size, err := generated.Size(codecVersion, block)
+	require.NoError(err)
+	require.Len(expectedBytes, size)
+
+	var expectedBlock Block
+	_, err = reflective.Unmarshal(expectedBytes, &expectedBlock)
+	require.NoError(err)
+	var parsedBlock Block
+	_, err = generated.Unmarshal(generatedBytes, &parsedBlock)
+	require.NoError(err)
+	require.Equal(expectedBlock, parsedBlock)
+	require.Equal(block.Header, parsedBlock.Header)
+	require.Equal(block.Outputs, parsedBlock.Outputs)
+}
+
+func TestEmbeddedGeneratedStruct(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		generated  = newManager(t, linearcodec.NewDefault())
+		reflective = newManager(t, linearcodec.NewReflective([]string{reflectcodec.DefaultTagName}))
+		header     = &SignedHeader{
+			Header:    newBlock().Header,
+			Signature: []byte{1, 2, 3},
+		}
+	)
+
+	expectedBytes, err := reflective.Marshal(codecVersion, header)
+	require.NoError(err)
+	generatedBytes, err := generated.Marshal(codecVersion, header)
+	require.NoError(err)
+	require.Equal(expectedBytes, generatedBytes)
+
+	size, err := generated.Size(codecVersion, header)
+	require.NoError(err)
+	require.Len(expectedBytes, size)
+
+	var parsedHeader SignedHeader
+	_, err = generated.Unmarshal(generatedBytes, &parsedHeader)
+	require.NoError(err)
+	require.Equal(header, &parsedHeader)
+}
+
+func TestGeneratedCodecErrors(t *testing.T) {
+	var (
+		generated  = newManager(t, linearcodec.NewDefault())
+		reflective = newManager(t, linearcodec.NewReflective([]string{reflectcodec.DefaultTagName}))
+	)
+
+	tests := []struct {
+		name        string
+		modify      func(*Block)
+		expectedErr error
+	}{
+		{
+			name: "nil pointer",
+			modify: func(b *Block) {
+				b.Change = nil
+			},
+			expectedErr: codec.ErrMarshalNil,
+		},
+		{
+			name: "nil pointer in slice",
+			modify: func(b *Block) {
+				b.Outputs = append(b.Outputs, nil)
+			},
+			expectedErr: codec.ErrMarshalNil,
+		},
+		{
+			name: "nil interface",
+			modify: func(b *Block) {
+				b.Shape = nil
+			},
+			expectedErr: codec.ErrMarshalNil,
+		},
+		{
+			name: "zero length values",
+			modify: func(b *Block) {
+				b.Empty = make([]struct{}, 1)
+			},
+			expectedErr: codec.ErrMarshalZeroLength,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			block := newBlock()
+			test.modify(block)
+
+			// The generated code must return the same errors as reflection.
+			_, expectedErr := reflective.Marshal(codecVersion, block)
+			require.ErrorIs(expectedErr, test.expectedErr)
+			_, err := generated.Marshal(codecVersion, block)
+			require.EqualError(err, expectedErr.Error())
+
+			_, expectedErr = reflective.Size(codecVersion, block)
+			require.ErrorIs(expectedErr, test.expectedErr)
+			_, err = generated.Size(codecVersion, block)
+			require.EqualError(err, expectedErr.Error())
+		})
+	}
+}
+
+// Test that the generated code and reflection unmarshal the same values from
+// the same bytes, and marshal them back into the same bytes.
+func FuzzGeneratedCodec(f *testing.F) {
+	var (
+		generated  = newManager(f, linearcodec.NewDefault())
+		reflective = newManager(f, linearcodec.NewReflective([]string{reflectcodec.DefaultTagName}))
+	)
+
+	blockBytes, err := reflective.Marshal(codecVersion, newBlock())
+	require.NoError(f, err)
+	f.Add(blockBytes)
+	f.Add(blockBytes[:len(blockBytes)/2])
+	f.Add([]byte{})
+
+	f.Fuzz(func(t *testing.T, bytes []byte) {
+		require := require.New(t)
+
+		var (
+			expectedBlock Block
+			block         Block
+		)
+		_, expectedErr := reflective.Unmarshal(bytes, &expectedBlock)
+		_, err := generated.Unmarshal(bytes, &block)
+		if expectedErr != nil {
+			require.EqualError(err, expectedErr.Error())
+			return
+		}
+		require.NoError(err)
+		require.Equal(expectedBlock, block)
+
+		expectedBytes, expectedErr := reflective.Marshal(codecVersion, &expectedBlock)
+		blockBytes, err := generated.Marshal(codecVersion, &block)
+		if expectedErr != nil {
+			require.EqualError(err, expectedErr.Error())
+			return
+		}
+		require.NoError(err)
+		require.Equal(expectedBytes, blockBytes)
+
+		size, err := generated.Size(codecVersion, &block)
+		require.NoError(err)
+		require.Len(blockBytes, size)
+	})
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		newCodec func() linearcodec.Codec
+	}{
+		{
+			name: "reflective",
+			newCodec: func() linearcodec.Codec {
+				return linearcodec.NewReflective([]string{reflectcodec.DefaultTagName})
+			},
+		},
+		{
+			name:     "generated",
+			newCodec: linearcodec.NewDefault,
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			var (
+				m     = newManager(b, benchmark.newCodec())
+				block = newBlock()
+			)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, err := m.Marshal(codecVersion, block)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		newCodec func() linearcodec.Codec
+	}{
+		{
+			name: "reflective",
+			newCodec: func() linearcodec.Codec {
+				return linearcodec.NewReflective([]string{reflectcodec.DefaultTagName})
+			},
+		},
+		{
+			name:     "generated",
+			newCodec: linearcodec.NewDefault,
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			m := newManager(b, benchmark.newCodec())
+			blockBytes, err := m.Marshal(codecVersion, newBlock())
+			require.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var block Block
+				_, err := m.Unmarshal(blockBytes, &block)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
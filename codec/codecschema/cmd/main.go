@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+//go:generate go run . --output-dir ../schemas
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/codecschema"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/vms/avm/fxs"
+	"github.com/MetalBlockchain/metalgo/vms/nftfx"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
+	"github.com/MetalBlockchain/metalgo/vms/propertyfx"
+	"github.com/MetalBlockchain/metalgo/vms/proposervm/block"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+
+	xtxs "github.com/MetalBlockchain/metalgo/vms/avm/txs"
+	ptxs "github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+)
+
+const (
+	outputDirKey = "output-dir"
+	seedKey      = "seed"
+
+	schemaFileName  = "schema.json"
+	vectorsFileName = "vectors.json"
+
+	defaultSeed = 1
+)
+
+func main() {
+	var (
+		outputDir string
+		seed      int64
+	)
+	rootCmd := &cobra.Command{
+		Use:   "codecschema",
+		Short: "Exports the schemas and test vectors of the codecs",
+		Long: `Writes the schema of the registered types of each codec, and a test vector
+of each registered type, to <output-dir>/<codec>/schema.json and
+<output-dir>/<codec>/vectors.json.
+
+The test vectors are generated deterministically from --seed, so that
+implementations in other languages can be checked against the committed
+files.`,
+		RunE: func(*cobra.Command, []string) error {
+			return write(outputDir, seed)
+		},
+	}
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&outputDir, outputDirKey, "schemas", "Directory to write the schemas and vectors to")
+	flags.Int64Var(&seed, seedKey, defaultSeed, "Seed of the generated test vectors")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "codecschema failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// managers returns the codecs to export, by name.
+func managers() (map[string]codec.Manager, error) {
+	avmParser, err := xtxs.NewParser([]fxs.Fx{
+		&secp256k1fx.Fx{},
+		&nftfx.Fx{},
+		&propertyfx.Fx{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]codec.Manager{
+		"avm":        avmParser.Codec(),
+		"platformvm": ptxs.Codec,
+		"proposervm": block.Codec,
+		"warp":       warp.Codec,
+	}, nil
+}
+
+// files returns the contents of the schema and vectors files by path,
+// relative to the output directory.
+func files(seed int64) (map[string][]byte, error) {
+	managers, err := managers()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, 2*len(managers))
+	for name, m := range managers {
+		schema, err := codecschema.Export(m)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't export %s schema: %w", name, err)
+		}
+		vectors, err := codecschema.Vectors(m, seed)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't generate %s vectors: %w", name, err)
+		}
+
+		for fileName, v := range map[string]interface{}{
+			schemaFileName:  schema,
+			vectorsFileName: vectors,
+		} {
+			b, err := json.MarshalIndent(v, "", "\t")
+			if err != nil {
+				return nil, err
+			}
+			files[filepath.Join(name, fileName)] = append(b, '\n')
+		}
+	}
+	return files, nil
+}
+
+func write(outputDir string, seed int64) error {
+	files, err := files(seed)
+	if err != nil {
+		return err
+	}
+	for path, b := range files {
+		path = filepath.Join(outputDir, path)
+		if err := os.MkdirAll(filepath.Dir(path), perms.ReadWriteExecute); err != nil {
+			return err
+		}
+		if err := perms.WriteFile(path, b, perms.ReadWrite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
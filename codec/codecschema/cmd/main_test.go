@@ -0,0 +1,27 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchemasUpToDate ensures that the committed schemas and vectors match
+// the registered types. Run "go generate" in this directory after changing a
+// codec.
+func TestSchemasUpToDate(t *testing.T) {
+	require := require.New(t)
+
+	files, err := files(defaultSeed)
+	require.NoError(err)
+	for path, expected := range files {
+		actual, err := os.ReadFile(filepath.Join("..", "schemas", path))
+		require.NoError(err)
+		require.Equal(string(expected), string(actual), path)
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package codecschema describes the binary formats of the types registered
+// with linearcodec codecs, so that they can be implemented in other
+// languages.
+//
+// A Schema describes every registered type, and every struct that they
+// contain, with its serialized fields in order. Vectors are values of each
+// registered type with their expected encoding.
+//
+// The schemas and vectors of the avm, platformvm, proposervm and warp codecs
+// are committed in the schemas directory. After changing a registered type,
+// regenerate them by running "go generate" in the cmd directory.
+package codecschema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+)
+
+const (
+	BoolKind      Kind = "bool"
+	Uint8Kind     Kind = "uint8"
+	Int8Kind      Kind = "int8"
+	Uint16Kind    Kind = "uint16"
+	Int16Kind     Kind = "int16"
+	Uint32Kind    Kind = "uint32"
+	Int32Kind     Kind = "int32"
+	Uint64Kind    Kind = "uint64"
+	Int64Kind     Kind = "int64"
+	StringKind    Kind = "string"
+	ArrayKind     Kind = "array"
+	SliceKind     Kind = "slice"
+	MapKind       Kind = "map"
+	StructKind    Kind = "struct"
+	InterfaceKind Kind = "interface"
+)
+
+var (
+	errUnsupportedCodec = errors.New("unsupported codec")
+	errUnsupportedType  = errors.New("unsupported type")
+	errDuplicatedName   = errors.New("duplicated type name")
+
+	kinds = map[reflect.Kind]Kind{
+		reflect.Bool:      BoolKind,
+		reflect.Uint8:     Uint8Kind,
+		reflect.Int8:      Int8Kind,
+		reflect.Uint16:    Uint16Kind,
+		reflect.Int16:     Int16Kind,
+		reflect.Uint32:    Uint32Kind,
+		reflect.Int32:     Int32Kind,
+		reflect.Uint64:    Uint64Kind,
+		reflect.Int64:     Int64Kind,
+		reflect.String:    StringKind,
+		reflect.Array:     ArrayKind,
+		reflect.Slice:     SliceKind,
+		reflect.Map:       MapKind,
+		reflect.Struct:    StructKind,
+		reflect.Interface: InterfaceKind,
+	}
+)
+
+// Kind is how a value is encoded:
+//
+//   - Integers are encoded in big-endian with their size.
+//   - Bools are encoded as a byte, which is 1 if the bool is true.
+//   - Strings are encoded as their length, as a uint16, followed by their
+//     bytes.
+//   - Arrays are encoded as their elements.
+//   - Slices are encoded as their length, as a uint32, followed by their
+//     elements.
+//   - Maps are encoded as their length, as a uint32, followed by their keys
+//     and values. The entries are sorted by the encoding of their keys.
+//   - Structs are encoded as their fields.
+//   - Interfaces are encoded as the type ID of their value, as a uint32,
+//     followed by their value.
+//
+// Pointers are encoded as the value that they point to, so they aren't
+// described.
+type Kind string
+
+// Schema describes the registered types of each version of a codec.Manager.
+type Schema struct {
+	Versions []*Version `json:"versions"`
+}
+
+// Version describes the registered types of the codec of a version.
+type Version struct {
+	// Version is the uint16 that prefixes values encoded with this version.
+	Version uint16 `json:"version"`
+	// TypeIDs are the registered types, sorted by type ID.
+	TypeIDs []*TypeID `json:"typeIDs"`
+	// Structs are the structs that are used by the registered types, by
+	// name.
+	Structs map[string]*Struct `json:"structs"`
+}
+
+// TypeID is a registered type.
+type TypeID struct {
+	ID   uint32 `json:"id"`
+	Type *Type  `json:"type"`
+}
+
+// Type describes how a value is encoded.
+type Type struct {
+	Kind Kind `json:"kind"`
+	// Name is the name of the Go type, if it is a defined type. Structs are
+	// described in Version.Structs with this name.
+	Name string `json:"name,omitempty"`
+	// Length is the number of elements of arrays.
+	Length int `json:"length,omitempty"`
+	// Key is the type of the keys of maps.
+	Key *Type `json:"key,omitempty"`
+	// Elem is the type of the elements of arrays, slices and maps.
+	Elem *Type `json:"elem,omitempty"`
+	// Implementations are the type IDs of the registered types that can be
+	// the value of interfaces.
+	Implementations []uint32 `json:"implementations,omitempty"`
+}
+
+// Struct describes the serialized fields of a struct, in the order that they
+// are encoded.
+type Struct struct {
+	Fields []*Field `json:"fields"`
+}
+
+type Field struct {
+	Name string `json:"name"`
+	Type *Type  `json:"type"`
+}
+
+// Export describes the registered types of every codec of [m], which must be
+// linearcodec codecs.
+func Export(m codec.Manager) (*Schema, error) {
+	codecs := m.Codecs()
+	versions := maps.Keys(codecs)
+	slices.Sort(versions)
+
+	schema := &Schema{
+		Versions: make([]*Version, len(versions)),
+	}
+	for i, version := range versions {
+		c, ok := codecs[version].(linearcodec.Codec)
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d is %T", errUnsupportedCodec, version, codecs[version])
+		}
+
+		v, err := newExporter(c).export(version)
+		if err != nil {
+			return nil, err
+		}
+		schema.Versions[i] = v
+	}
+	return schema, nil
+}
+
+type exporter struct {
+	fielder reflectcodec.StructFielder
+	// Type ID -> registered type
+	types map[uint32]reflect.Type
+	// The registered type IDs, sorted.
+	typeIDs []uint32
+
+	// Struct name -> struct type
+	names   map[string]reflect.Type
+	structs map[string]*Struct
+}
+
+func newExporter(c linearcodec.Codec) *exporter {
+	types := c.RegisteredTypes()
+	typeIDs := maps.Keys(types)
+	slices.Sort(typeIDs)
+	return &exporter{
+		fielder: reflectcodec.NewStructFielder(c.TagNames()),
+		types:   types,
+		typeIDs: typeIDs,
+		names:   make(map[string]reflect.Type),
+		structs: make(map[string]*Struct),
+	}
+}
+
+func (e *exporter) export(version uint16) (*Version, error) {
+	v := &Version{
+		Version: version,
+		TypeIDs: make([]*TypeID, len(e.typeIDs)),
+		Structs: e.structs,
+	}
+	for i, typeID := range e.typeIDs {
+		t, err := e.describe(e.types[typeID])
+		if err != nil {
+			return nil, err
+		}
+		v.TypeIDs[i] = &TypeID{
+			ID:   typeID,
+			Type: t,
+		}
+	}
+	return v, nil
+}
+
+// describe returns how values of type [t] are encoded. The structs that are
+// used by [t] are added to e.structs.
+func (e *exporter) describe(t reflect.Type) (*Type, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	kind, ok := kinds[t.Kind()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedType, t)
+	}
+	desc := &Type{
+		Kind: kind,
+	}
+	if t.PkgPath() != "" {
+		desc.Name = t.String()
+	}
+
+	var err error
+	switch kind {
+	case ArrayKind:
+		desc.Length = t.Len()
+		desc.Elem, err = e.describe(t.Elem())
+	case SliceKind:
+		desc.Elem, err = e.describe(t.Elem())
+	case MapKind:
+		desc.Key, err = e.describe(t.Key())
+		if err == nil {
+			desc.Elem, err = e.describe(t.Elem())
+		}
+	case StructKind:
+		if desc.Name == "" {
+			return nil, fmt.Errorf("%w: anonymous struct %s", errUnsupportedType, t)
+		}
+		err = e.describeStruct(desc.Name, t)
+	case InterfaceKind:
+		desc.Implementations = e.implementations(t)
+	}
+	return desc, err
+}
+
+func (e *exporter) describeStruct(name string, t reflect.Type) error {
+	if other, ok := e.names[name]; ok {
+		if other != t {
+			return fmt.Errorf("%w: %s is %s and %s", errDuplicatedName, name, other.PkgPath(), t.PkgPath())
+		}
+		return nil
+	}
+
+	fields, err := e.fielder.GetSerializedFields(t)
+	if err != nil {
+		return err
+	}
+
+	// Register the struct before describing its fields, which may contain
+	// the struct.
+	s := &Struct{
+		Fields: make([]*Field, len(fields)),
+	}
+	e.names[name] = t
+	e.structs[name] = s
+	for i, fieldIndex := range fields {
+		field := t.Field(fieldIndex)
+		fieldType, err := e.describe(field.Type)
+		if err != nil {
+			return fmt.Errorf("couldn't describe %s.%s: %w", name, field.Name, err)
+		}
+		s.Fields[i] = &Field{
+			Name: field.Name,
+			Type: fieldType,
+		}
+	}
+	return nil
+}
+
+// implementations returns the type IDs of the registered types that implement
+// the interface [t].
+func (e *exporter) implementations(t reflect.Type) []uint32 {
+	var typeIDs []uint32
+	for _, typeID := range e.typeIDs {
+		if e.types[typeID].Implements(t) {
+			typeIDs = append(typeIDs, typeID)
+		}
+	}
+	return typeIDs
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecschema
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/hierarchycodec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+)
+
+const testVersion = 1
+
+var (
+	_ shape = (*square)(nil)
+	_ shape = (*group)(nil)
+)
+
+type shape interface {
+	isShape()
+}
+
+type square struct {
+	Side   uint32 `serialize:"true"`
+	Hidden bool
+}
+
+func (*square) isShape() {}
+
+type group struct {
+	Name   string            `serialize:"true"`
+	Shapes []shape           `serialize:"true"`
+	Owner  [2]byte           `serialize:"true"`
+	Tags   map[uint16]string `serialize:"true"`
+	Parent *square           `serialize:"true"`
+}
+
+func (*group) isShape() {}
+
+func newTestManager(t *testing.T) codec.Manager {
+	require := require.New(t)
+
+	c := linearcodec.NewDefault()
+	c.SkipRegistrations(2)
+	require.NoError(c.RegisterType(&square{}))
+	require.NoError(c.RegisterType(&group{}))
+
+	m := codec.NewManager(math.MaxInt)
+	require.NoError(m.RegisterCodec(testVersion, c))
+	return m
+}
+
+func TestExport(t *testing.T) {
+	require := require.New(t)
+
+	schema, err := Export(newTestManager(t))
+	require.NoError(err)
+
+	squareType := &Type{
+		Kind: StructKind,
+		Name: "codecschema.square",
+	}
+	require.Equal(&Schema{
+		Versions: []*Version{
+			{
+				Version: testVersion,
+				TypeIDs: []*TypeID{
+					{
+						ID:   2,
+						Type: squareType,
+					},
+					{
+						ID: 3,
+						Type: &Type{
+							Kind: StructKind,
+							Name: "codecschema.group",
+						},
+					},
+				},
+				Structs: map[string]*Struct{
+					"codecschema.square": {
+						Fields: []*Field{
+							{
+								Name: "Side",
+								Type: &Type{Kind: Uint32Kind},
+							},
+						},
+					},
+					"codecschema.group": {
+						Fields: []*Field{
+							{
+								Name: "Name",
+								Type: &Type{Kind: StringKind},
+							},
+							{
+								Name: "Shapes",
+								Type: &Type{
+									Kind: SliceKind,
+									Elem: &Type{
+										Kind:            InterfaceKind,
+										Name:            "codecschema.shape",
+										Implementations: []uint32{2, 3},
+									},
+								},
+							},
+							{
+								Name: "Owner",
+								Type: &Type{
+									Kind:   ArrayKind,
+									Length: 2,
+									Elem:   &Type{Kind: Uint8Kind},
+								},
+							},
+							{
+								Name: "Tags",
+								Type: &Type{
+									Kind: MapKind,
+									Key:  &Type{Kind: Uint16Kind},
+									Elem: &Type{Kind: StringKind},
+								},
+							},
+							{
+								Name: "Parent",
+								Type: squareType,
+							},
+						},
+					},
+				},
+			},
+		},
+	}, schema)
+}
+
+func TestExportErrors(t *testing.T) {
+	type anonymous struct {
+		Value struct {
+			Value uint32 `serialize:"true"`
+		} `serialize:"true"`
+	}
+	type unsupported struct {
+		Value float64 `serialize:"true"`
+	}
+
+	tests := []struct {
+		name        string
+		val         interface{}
+		expectedErr error
+	}{
+		{
+			name:        "anonymous struct",
+			val:         &anonymous{},
+			expectedErr: errUnsupportedType,
+		},
+		{
+			name:        "unsupported kind",
+			val:         &unsupported{},
+			expectedErr: errUnsupportedType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c := linearcodec.NewDefault()
+			require.NoError(c.RegisterType(test.val))
+
+			m := codec.NewManager(math.MaxInt)
+			require.NoError(m.RegisterCodec(testVersion, c))
+
+			_, err := Export(m)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestExportUnsupportedCodec(t *testing.T) {
+	require := require.New(t)
+
+	m := codec.NewManager(math.MaxInt)
+	require.NoError(m.RegisterCodec(testVersion, hierarchycodec.NewDefault()))
+
+	_, err := Export(m)
+	require.ErrorIs(err, errUnsupportedCodec)
+}
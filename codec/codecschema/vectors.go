@@ -0,0 +1,380 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	// The maximum number of elements of generated slices and maps.
+	maxGeneratedLen = 2
+	// The maximum length of generated strings and byte slices.
+	maxGeneratedBytes = 8
+	letters           = "abcdefghijklmnopqrstuvwxyz"
+)
+
+var (
+	errNoImplementation = errors.New("no registered implementation")
+	errMismatch         = errors.New("decoded value doesn't match encoded value")
+)
+
+// Vector is a value of a registered type and its encoding.
+type Vector struct {
+	Version uint16 `json:"version"`
+	TypeID  uint32 `json:"typeID"`
+	// Value is the value in JSON:
+	//
+	//   - 8, 16 and 32 bit integers are numbers.
+	//   - 64 bit integers are strings, because they can't be represented
+	//     exactly by all JSON parsers.
+	//   - Arrays and slices of bytes are hex strings with a 0x prefix.
+	//   - Other arrays and slices are arrays.
+	//   - Maps are arrays of [key, value] pairs, in the order they're encoded.
+	//   - Structs are objects of their serialized fields, in the order they're
+	//     encoded.
+	//   - Interfaces are objects with the typeID and value of their value.
+	Value json.RawMessage `json:"value"`
+	// Bytes is the encoding of the value as an interface, which is the codec
+	// version, followed by the type ID, followed by the value, as a hex
+	// string with a 0x prefix.
+	Bytes string `json:"bytes"`
+}
+
+// Vectors returns a vector with a generated value of every registered type of
+// every codec of [m], which must be linearcodec codecs. The values are
+// generated deterministically from [seed].
+func Vectors(m codec.Manager, seed int64) ([]*Vector, error) {
+	codecs := m.Codecs()
+	versions := maps.Keys(codecs)
+	slices.Sort(versions)
+
+	var vectors []*Vector
+	for _, version := range versions {
+		c, ok := codecs[version].(linearcodec.Codec)
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d is %T", errUnsupportedCodec, version, codecs[version])
+		}
+
+		g := newGenerator(c)
+		for _, typeID := range g.typeIDs {
+			vector, err := g.vector(m, version, typeID, seed)
+			if err != nil {
+				return nil, err
+			}
+			vectors = append(vectors, vector)
+		}
+	}
+	return vectors, nil
+}
+
+type generator struct {
+	*exporter
+
+	codec codec.Codec
+	// Registered type -> type ID
+	typeIDsByType map[reflect.Type]uint32
+
+	rng *rand.Rand
+	// The registered types that are being generated, which can't be
+	// generated again in their own values.
+	typeStack set.Set[reflect.Type]
+}
+
+func newGenerator(c linearcodec.Codec) *generator {
+	e := newExporter(c)
+	typeIDsByType := make(map[reflect.Type]uint32, len(e.types))
+	for typeID, t := range e.types {
+		typeIDsByType[t] = typeID
+	}
+	return &generator{
+		exporter:      e,
+		codec:         c,
+		typeIDsByType: typeIDsByType,
+	}
+}
+
+func (g *generator) vector(m codec.Manager, version uint16, typeID uint32, seed int64) (*Vector, error) {
+	t := g.types[typeID]
+	g.rng = rand.New(rand.NewSource(seed + int64(typeID))) //#nosec G404
+
+	g.typeStack = set.Of(t)
+	value := reflect.New(t).Elem()
+	if err := g.generate(value); err != nil {
+		return nil, fmt.Errorf("couldn't generate %s: %w", t, err)
+	}
+
+	// Values are encoded as interfaces, so that they're prefixed with their
+	// type ID.
+	encoded := value.Interface()
+	encodedBytes, err := m.Marshal(version, &encoded)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal %s: %w", t, err)
+	}
+
+	var decoded interface{}
+	if _, err := m.Unmarshal(encodedBytes, &decoded); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal %s: %w", t, err)
+	}
+	if !reflect.DeepEqual(encoded, decoded) {
+		return nil, fmt.Errorf("%w: %s", errMismatch, t)
+	}
+
+	var w bytes.Buffer
+	if err := g.writeValue(&w, value); err != nil {
+		return nil, fmt.Errorf("couldn't write %s: %w", t, err)
+	}
+	hex, err := formatting.Encode(formatting.HexNC, encodedBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector{
+		Version: version,
+		TypeID:  typeID,
+		Value:   w.Bytes(),
+		Bytes:   hex,
+	}, nil
+}
+
+// generate sets [value] to a random value that can be encoded.
+func (g *generator) generate(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Bool:
+		value.SetBool(g.rng.Intn(2) == 1)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(g.rng.Uint64())
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(int64(g.rng.Uint64()))
+	case reflect.String:
+		s := make([]byte, g.rng.Intn(maxGeneratedBytes+1))
+		for i := range s {
+			s[i] = letters[g.rng.Intn(len(letters))]
+		}
+		value.SetString(string(s))
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := g.generate(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		maxLen := maxGeneratedLen
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			maxLen = maxGeneratedBytes
+		}
+		numElts := g.rng.Intn(maxLen + 1)
+		value.Set(reflect.MakeSlice(value.Type(), numElts, numElts))
+		for i := 0; i < numElts; i++ {
+			if err := g.generate(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		value.Set(reflect.MakeMap(value.Type()))
+		numElts := g.rng.Intn(maxGeneratedLen + 1)
+		for i := 0; i < numElts; i++ {
+			k := reflect.New(value.Type().Key()).Elem()
+			if err := g.generate(k); err != nil {
+				return err
+			}
+			v := reflect.New(value.Type().Elem()).Elem()
+			if err := g.generate(v); err != nil {
+				return err
+			}
+			value.SetMapIndex(k, v)
+		}
+	case reflect.Ptr:
+		ptr := reflect.New(value.Type().Elem())
+		if err := g.generate(ptr.Elem()); err != nil {
+			return err
+		}
+		value.Set(ptr)
+	case reflect.Struct:
+		fields, err := g.fielder.GetSerializedFields(value.Type())
+		if err != nil {
+			return err
+		}
+		for _, i := range fields {
+			if err := g.generate(value.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Interface:
+		var candidates []uint32
+		for _, typeID := range g.implementations(value.Type()) {
+			if !g.typeStack.Contains(g.types[typeID]) {
+				candidates = append(candidates, typeID)
+			}
+		}
+		if len(candidates) == 0 {
+			return fmt.Errorf("%w of %s", errNoImplementation, value.Type())
+		}
+
+		t := g.types[candidates[g.rng.Intn(len(candidates))]]
+		g.typeStack.Add(t)
+		defer g.typeStack.Remove(t)
+
+		implementation := reflect.New(t).Elem()
+		if err := g.generate(implementation); err != nil {
+			return err
+		}
+		value.Set(implementation)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, value.Type())
+	}
+	return nil
+}
+
+// writeValue writes [value] to [w] in JSON. See Vector.Value.
+func (g *generator) writeValue(w *bytes.Buffer, value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Bool:
+		w.WriteString(strconv.FormatBool(value.Bool()))
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		w.WriteString(strconv.FormatUint(value.Uint(), 10))
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		w.WriteString(strconv.FormatInt(value.Int(), 10))
+	case reflect.Uint64:
+		w.WriteString(strconv.Quote(strconv.FormatUint(value.Uint(), 10)))
+	case reflect.Int64:
+		w.WriteString(strconv.Quote(strconv.FormatInt(value.Int(), 10)))
+	case reflect.String:
+		return writeJSON(w, value.String())
+	case reflect.Array, reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, value.Len())
+			for i := range b {
+				b[i] = uint8(value.Index(i).Uint())
+			}
+			hex, err := formatting.Encode(formatting.HexNC, b)
+			if err != nil {
+				return err
+			}
+			return writeJSON(w, hex)
+		}
+
+		w.WriteByte('[')
+		for i := 0; i < value.Len(); i++ {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := g.writeValue(w, value.Index(i)); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+	case reflect.Map:
+		return g.writeMap(w, value)
+	case reflect.Ptr:
+		return g.writeValue(w, value.Elem())
+	case reflect.Struct:
+		fields, err := g.fielder.GetSerializedFields(value.Type())
+		if err != nil {
+			return err
+		}
+
+		w.WriteByte('{')
+		for i, fieldIndex := range fields {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := writeJSON(w, value.Type().Field(fieldIndex).Name); err != nil {
+				return err
+			}
+			w.WriteByte(':')
+			if err := g.writeValue(w, value.Field(fieldIndex)); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+	case reflect.Interface:
+		elem := value.Elem()
+		fmt.Fprintf(w, `{"typeID":%d,"value":`, g.typeIDsByType[elem.Type()])
+		if err := g.writeValue(w, elem); err != nil {
+			return err
+		}
+		w.WriteByte('}')
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, value.Type())
+	}
+	return nil
+}
+
+// writeMap writes the entries of the map [value] to [w], sorted by the
+// encoding of their keys.
+func (g *generator) writeMap(w *bytes.Buffer, value reflect.Value) error {
+	type entry struct {
+		keyBytes []byte
+		value    reflect.Value
+		key      reflect.Value
+	}
+	entries := make([]entry, 0, value.Len())
+	iter := value.MapRange()
+	for iter.Next() {
+		key := reflect.New(iter.Key().Type())
+		key.Elem().Set(iter.Key())
+
+		size, err := g.codec.Size(key.Interface())
+		if err != nil {
+			return err
+		}
+		p := wrappers.Packer{
+			MaxSize: size,
+			Bytes:   make([]byte, 0, size),
+		}
+		if err := g.codec.MarshalInto(key.Interface(), &p); err != nil {
+			return err
+		}
+		entries = append(entries, entry{
+			keyBytes: p.Bytes,
+			value:    iter.Value(),
+			key:      iter.Key(),
+		})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return bytes.Compare(a.keyBytes, b.keyBytes)
+	})
+
+	w.WriteByte('[')
+	for i, entry := range entries {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteByte('[')
+		if err := g.writeValue(w, entry.key); err != nil {
+			return err
+		}
+		w.WriteByte(',')
+		if err := g.writeValue(w, entry.value); err != nil {
+			return err
+		}
+		w.WriteByte(']')
+	}
+	w.WriteByte(']')
+	return nil
+}
+
+func writeJSON(w *bytes.Buffer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Write(b)
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package codecschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/utils/formatting"
+)
+
+func TestVectors(t *testing.T) {
+	require := require.New(t)
+
+	m := newTestManager(t)
+	vectors, err := Vectors(m, 1)
+	require.NoError(err)
+	require.Len(vectors, 2)
+
+	for i, typeID := range []uint32{2, 3} {
+		vector := vectors[i]
+		require.Equal(uint16(testVersion), vector.Version)
+		require.Equal(typeID, vector.TypeID)
+		require.True(json.Valid(vector.Value))
+
+		b, err := formatting.Decode(formatting.HexNC, vector.Bytes)
+		require.NoError(err)
+
+		var decoded interface{}
+		version, err := m.Unmarshal(b, &decoded)
+		require.NoError(err)
+		require.Equal(uint16(testVersion), version)
+
+		reencoded, err := m.Marshal(testVersion, &decoded)
+		require.NoError(err)
+		require.Equal(b, reencoded)
+	}
+
+	// The vectors are deterministic.
+	expectedVectors, err := Vectors(newTestManager(t), 1)
+	require.NoError(err)
+	require.Equal(expectedVectors, vectors)
+}
+
+func TestVectorsValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected string
+	}{
+		{
+			name: "square",
+			value: &square{
+				Side:   5,
+				Hidden: true,
+			},
+			expected: `{"Side":5}`,
+		},
+		{
+			name: "group",
+			value: &group{
+				Name: "a",
+				Shapes: []shape{
+					&square{Side: 1},
+				},
+				Owner: [2]byte{0x01, 0x02},
+				Tags: map[uint16]string{
+					2: "b",
+					1: "a",
+				},
+				Parent: &square{Side: 2},
+			},
+			expected: `{"Name":"a","Shapes":[{"typeID":0,"value":{"Side":1}}],"Owner":"0x0102","Tags":[[1,"a"],[2,"b"]],"Parent":{"Side":2}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c := linearcodec.NewDefault()
+			require.NoError(c.RegisterType(&square{}))
+			require.NoError(c.RegisterType(&group{}))
+			g := newGenerator(c)
+
+			var w bytes.Buffer
+			require.NoError(g.writeValue(&w, reflect.ValueOf(test.value)))
+			require.JSONEq(test.expected, w.String())
+		})
+	}
+}
+
+func TestVectorsNoImplementation(t *testing.T) {
+	type noImplementation struct {
+		Shape shape `serialize:"true"`
+	}
+
+	require := require.New(t)
+
+	c := linearcodec.NewDefault()
+	require.NoError(c.RegisterType(&noImplementation{}))
+
+	m := codec.NewManager(math.MaxInt)
+	require.NoError(m.RegisterCodec(testVersion, c))
+
+	_, err := Vectors(m, 1)
+	require.ErrorIs(err, errNoImplementation)
+}
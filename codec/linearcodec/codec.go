@@ -6,6 +6,7 @@ package linearcodec
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/MetalBlockchain/metalgo/codec"
@@ -26,6 +27,12 @@ type Codec interface {
 	codec.Registry
 	codec.Codec
 	SkipRegistrations(int)
+
+	// RegisteredTypes returns the registered types by type ID.
+	RegisteredTypes() map[uint32]reflect.Type
+
+	// TagNames returns the tags that mark struct fields as serialized.
+	TagNames() []string
 }
 
 // Codec handles marshaling and unmarshaling of structs
@@ -33,6 +40,7 @@ type linearCodec struct {
 	codec.Codec
 
 	lock            sync.RWMutex
+	tagNames        []string
 	nextTypeID      uint32
 	registeredTypes *bimap.BiMap[uint32, reflect.Type]
 }
@@ -40,6 +48,7 @@ type linearCodec struct {
 // New returns a new, concurrency-safe codec; it allow to specify tagNames.
 func New(tagNames []string) Codec {
 	hCodec := &linearCodec{
+		tagNames:        tagNames,
 		nextTypeID:      0,
 		registeredTypes: bimap.New[uint32, reflect.Type](),
 	}
@@ -47,6 +56,19 @@ func New(tagNames []string) Codec {
 	return hCodec
 }
 
+// NewReflective returns a new, concurrency-safe codec that ignores generated
+// marshaling code; it allow to specify tagNames. See
+// reflectcodec.NewReflective.
+func NewReflective(tagNames []string) Codec {
+	hCodec := &linearCodec{
+		tagNames:        tagNames,
+		nextTypeID:      0,
+		registeredTypes: bimap.New[uint32, reflect.Type](),
+	}
+	hCodec.Codec = reflectcodec.NewReflective(hCodec, tagNames)
+	return hCodec
+}
+
 // NewDefault is a convenience constructor; it returns a new codec with default
 // tagNames.
 func NewDefault() Codec {
@@ -76,6 +98,21 @@ func (c *linearCodec) RegisterType(val interface{}) error {
 	return nil
 }
 
+func (c *linearCodec) RegisteredTypes() map[uint32]reflect.Type {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	registeredTypes := make(map[uint32]reflect.Type, c.registeredTypes.Len())
+	for _, typeID := range c.registeredTypes.Keys() {
+		registeredTypes[typeID], _ = c.registeredTypes.GetValue(typeID)
+	}
+	return registeredTypes
+}
+
+func (c *linearCodec) TagNames() []string {
+	return slices.Clone(c.tagNames)
+}
+
 func (*linearCodec) PrefixSize(reflect.Type) int {
 	// see PackPrefix implementation
 	return wrappers.IntLen
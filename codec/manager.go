@@ -6,6 +6,7 @@ package codec
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 
 	"github.com/MetalBlockchain/metalgo/utils/units"
@@ -35,10 +36,17 @@ var (
 var _ Manager = (*manager)(nil)
 
 // Manager describes the functionality for managing codec versions.
+//
+// Codecs that are built on reflectcodec, such as linearcodec, (un)marshal
+// structs with generated code instead of reflection when it's present. See
+// codec/codecgen.
 type Manager interface {
 	// Associate the given codec with the given version ID
 	RegisterCodec(version uint16, codec Codec) error
 
+	// Codecs returns the registered codecs by version.
+	Codecs() map[uint16]Codec
+
 	// Size returns the size, in bytes, of [value] when it's marshaled
 	// using the codec with the given version.
 	// RegisterCodec must have been called with that version.
@@ -87,6 +95,13 @@ func (m *manager) RegisterCodec(version uint16, codec Codec) error {
 	return nil
 }
 
+func (m *manager) Codecs() map[uint16]Codec {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return maps.Clone(m.codecs)
+}
+
 func (m *manager) Size(version uint16, value interface{}) (int, error) {
 	if value == nil {
 		return 0, ErrMarshalNil // can't marshal nil
@@ -38,6 +38,20 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// Codecs mocks base method.
+func (m *MockManager) Codecs() map[uint16]Codec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Codecs")
+	ret0, _ := ret[0].(map[uint16]Codec)
+	return ret0
+}
+
+// Codecs indicates an expected call of Codecs.
+func (mr *MockManagerMockRecorder) Codecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Codecs", reflect.TypeOf((*MockManager)(nil).Codecs))
+}
+
 // Marshal mocks base method.
 func (m *MockManager) Marshal(arg0 uint16, arg1 any) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reflectcodec
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+var (
+	_ FieldCodec = (*fieldCodec)(nil)
+
+	// generatedTags maps the struct types that have generated code to the tag
+	// names that the code was generated for. It's only written to during
+	// package initialization, so it isn't guarded by a lock.
+	generatedTags = make(map[reflect.Type][]string)
+)
+
+// Marshaler is implemented by pointers to structs that have generated
+// marshaling code. See codec/codecgen.
+//
+// The generated code is used instead of reflection by codecs whose tag names
+// are the same as the tag names that the code was registered for. See
+// RegisterGenerated. The output of the generated code must be identical to
+// the output of reflection.
+type Marshaler interface {
+	// MarshalInto writes the serialized fields of the struct into [p].
+	MarshalInto(c FieldCodec, p *wrappers.Packer) error
+
+	// Size returns the size, in bytes, of the serialized fields of the struct.
+	Size(c FieldCodec) (int, error)
+}
+
+// Unmarshaler is implemented by pointers to structs that have generated
+// unmarshaling code. See Marshaler.
+type Unmarshaler interface {
+	// Unmarshal reads the serialized fields of the struct from [p].
+	Unmarshal(c FieldCodec, p *wrappers.Packer) error
+}
+
+// FieldCodec (un)marshals the fields that generated code doesn't handle
+// itself, such as interfaces, with reflection.
+type FieldCodec interface {
+	// MarshalField writes the value pointed to by [field] into [p].
+	MarshalField(field interface{}, p *wrappers.Packer) error
+
+	// UnmarshalField reads the value pointed to by [field] from [p].
+	UnmarshalField(p *wrappers.Packer, field interface{}) error
+
+	// FieldSize returns the size, in bytes, of the value pointed to by
+	// [field].
+	FieldSize(field interface{}) (int, error)
+}
+
+// RegisterGenerated records that the structs pointed to by [ptrs] have
+// generated code for [tagNames], which must implement Unmarshaler too. It
+// must only be called during package initialization, by the generated code.
+//
+// Only the registered types are (un)marshaled with generated code. Otherwise,
+// a struct that embeds a struct with generated code, and so is given its
+// methods, would be (un)marshaled as the embedded struct.
+func RegisterGenerated(tagNames []string, ptrs ...Marshaler) {
+	for _, ptr := range ptrs {
+		generatedTags[reflect.TypeOf(ptr).Elem()] = tagNames
+	}
+}
+
+// HasGenerated returns true if the struct type [t] has registered generated
+// code for [tagNames].
+func HasGenerated(t reflect.Type, tagNames []string) bool {
+	tags, ok := generatedTags[t]
+	return ok && slices.Equal(tags, tagNames)
+}
+
+// fieldCodec calls back into the generic codec from generated code, keeping
+// track of the interface types that are being (un)marshaled.
+type fieldCodec struct {
+	c         *genericCodec
+	typeStack set.Set[reflect.Type]
+}
+
+func (f *fieldCodec) MarshalField(field interface{}, p *wrappers.Packer) error {
+	return f.c.marshal(reflect.ValueOf(field).Elem(), p, f.typeStack)
+}
+
+func (f *fieldCodec) UnmarshalField(p *wrappers.Packer, field interface{}) error {
+	return f.c.unmarshal(p, reflect.ValueOf(field).Elem(), f.typeStack)
+}
+
+func (f *fieldCodec) FieldSize(field interface{}) (int, error) {
+	size, _, err := f.c.size(reflect.ValueOf(field).Elem(), f.typeStack)
+	return size, err
+}
+
+// marshaler returns the generated marshaling code of [ptr], which is a pointer
+// to a struct, if the code was generated for the tag names of this codec.
+func (c *genericCodec) marshaler(ptr reflect.Value) (Marshaler, bool) {
+	if !c.useGenerated || !ptr.CanInterface() || !HasGenerated(ptr.Type().Elem(), c.tags) {
+		return nil, false
+	}
+	m, ok := ptr.Interface().(Marshaler)
+	return m, ok
+}
+
+// unmarshaler returns the generated unmarshaling code of [ptr], which is a
+// pointer to a struct, if the code was generated for the tag names of this
+// codec.
+func (c *genericCodec) unmarshaler(ptr reflect.Value) (Unmarshaler, bool) {
+	if !c.useGenerated || !ptr.CanInterface() || !HasGenerated(ptr.Type().Elem(), c.tags) {
+		return nil, false
+	}
+	u, ok := ptr.Interface().(Unmarshaler)
+	return u, ok
+}
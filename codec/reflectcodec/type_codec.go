@@ -25,7 +25,6 @@ const (
 var (
 	_ codec.Codec = (*genericCodec)(nil)
 
-	errMarshalNil              = errors.New("can't marshal nil pointer or interface")
 	errUnmarshalNil            = errors.New("can't unmarshal nil")
 	errNeedPointer             = errors.New("argument to unmarshal must be a pointer")
 	errRecursiveInterfaceTypes = errors.New("recursive interface types")
@@ -69,22 +68,39 @@ type TypeCodec interface {
 //     codec.RegisterType([instance of the type that fulfills the interface]).
 //  6. Serialized fields must be exported
 //  7. nil slices are marshaled as empty slices
+//  8. Structs with registered generated marshaling code for the same tag
+//     names are (un)marshaled with the generated code. See RegisterGenerated.
 type genericCodec struct {
-	typer   TypeCodec
-	fielder StructFielder
+	typer        TypeCodec
+	fielder      StructFielder
+	tags         []string
+	useGenerated bool
 }
 
 // New returns a new, concurrency-safe codec
 func New(typer TypeCodec, tagNames []string) codec.Codec {
+	return &genericCodec{
+		typer:        typer,
+		fielder:      NewStructFielder(tagNames),
+		tags:         tagNames,
+		useGenerated: true,
+	}
+}
+
+// NewReflective returns a new, concurrency-safe codec that ignores generated
+// marshaling code and always uses reflection. It's used to test that the
+// generated code is identical to reflection.
+func NewReflective(typer TypeCodec, tagNames []string) codec.Codec {
 	return &genericCodec{
 		typer:   typer,
 		fielder: NewStructFielder(tagNames),
+		tags:    tagNames,
 	}
 }
 
 func (c *genericCodec) Size(value interface{}) (int, error) {
 	if value == nil {
-		return 0, errMarshalNil // can't marshal nil
+		return 0, codec.ErrMarshalNil // can't marshal nil
 	}
 
 	size, _, err := c.size(reflect.ValueOf(value), nil /*=typeStack*/)
@@ -120,14 +136,14 @@ func (c *genericCodec) size(
 		return wrappers.StringLen(value.String()), false, nil
 	case reflect.Ptr:
 		if value.IsNil() {
-			return 0, false, errMarshalNil
+			return 0, false, codec.ErrMarshalNil
 		}
 
 		return c.size(value.Elem(), typeStack)
 
 	case reflect.Interface:
 		if value.IsNil() {
-			return 0, false, errMarshalNil
+			return 0, false, codec.ErrMarshalNil
 		}
 
 		underlyingValue := value.Interface()
@@ -200,6 +216,13 @@ func (c *genericCodec) size(
 		return size, false, nil
 
 	case reflect.Struct:
+		if value.CanAddr() {
+			if m, ok := c.marshaler(value.Addr()); ok {
+				size, err := m.Size(&fieldCodec{c: c, typeStack: typeStack})
+				return size, false, err
+			}
+		}
+
 		serializedFields, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
 			return 0, false, err
@@ -294,7 +317,7 @@ func (c *genericCodec) size(
 // To marshal an interface, [value] must be a pointer to the interface
 func (c *genericCodec) MarshalInto(value interface{}, p *wrappers.Packer) error {
 	if value == nil {
-		return errMarshalNil // can't marshal nil
+		return codec.ErrMarshalNil // can't marshal nil
 	}
 
 	return c.marshal(reflect.ValueOf(value), p, nil /*=typeStack*/)
@@ -341,13 +364,13 @@ func (c *genericCodec) marshal(
 		return p.Err
 	case reflect.Ptr:
 		if value.IsNil() {
-			return errMarshalNil
+			return codec.ErrMarshalNil
 		}
 
 		return c.marshal(value.Elem(), p, typeStack)
 	case reflect.Interface:
 		if value.IsNil() {
-			return errMarshalNil
+			return codec.ErrMarshalNil
 		}
 
 		underlyingValue := value.Interface()
@@ -413,6 +436,12 @@ func (c *genericCodec) marshal(
 		}
 		return nil
 	case reflect.Struct:
+		if value.CanAddr() {
+			if m, ok := c.marshaler(value.Addr()); ok {
+				return m.MarshalInto(&fieldCodec{c: c, typeStack: typeStack}, p)
+			}
+		}
+
 		serializedFields, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
 			return err
@@ -670,6 +699,10 @@ func (c *genericCodec) unmarshal(
 		value.Set(intfImplementor)
 		return nil
 	case reflect.Struct:
+		if u, ok := c.unmarshaler(value.Addr()); ok {
+			return u.Unmarshal(&fieldCodec{c: c, typeStack: typeStack}, p)
+		}
+
 		// Get indices of fields that will be unmarshaled into
 		serializedFieldIndices, err := c.fielder.GetSerializedFields(value.Type())
 		if err != nil {
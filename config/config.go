@@ -19,6 +19,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/MetalBlockchain/metalgo/api/server"
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -28,6 +29,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/node"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/staking"
@@ -344,6 +346,8 @@ func getNetworkConfig(
 		ProxyEnabled:           v.GetBool(NetworkTCPProxyEnabledKey),
 		ProxyReadHeaderTimeout: v.GetDuration(NetworkTCPProxyReadTimeoutKey),
 
+		QUICEnabled: v.GetBool(NetworkQUICEnabledKey),
+
 		DialerConfig: dialer.Config{
 			ThrottleRps:       v.GetUint32(NetworkOutboundConnectionThrottlingRpsKey),
 			ConnectionTimeout: v.GetDuration(NetworkOutboundConnectionTimeoutKey),
@@ -417,6 +421,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.QUICEnabled && config.ProxyEnabled:
+		return network.Config{}, fmt.Errorf("%s can't be used with %s", NetworkQUICEnabledKey, NetworkTCPProxyEnabledKey)
 	}
 	return config, nil
 }
@@ -432,12 +438,25 @@ func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters)
 		Duration:               v.GetDuration(BenchlistDurationKey),
 		MinimumFailingDuration: v.GetDuration(BenchlistMinFailingDurationKey),
 		MaxPortion:             (1.0 - (float64(alpha) / float64(k))) / 3.0,
+		MinReputation:          v.GetFloat64(BenchlistMinReputationKey),
 	}
 	switch {
 	case config.Duration < 0:
 		return benchlist.Config{}, fmt.Errorf("%q must be >= 0", BenchlistDurationKey)
 	case config.MinimumFailingDuration < 0:
 		return benchlist.Config{}, fmt.Errorf("%q must be >= 0", BenchlistMinFailingDurationKey)
+	case config.MinReputation < 0 || config.MinReputation > 1:
+		return benchlist.Config{}, fmt.Errorf("%q must be in [0, 1]", BenchlistMinReputationKey)
+	}
+	return config, nil
+}
+
+func getReputationConfig(v *viper.Viper) (reputation.Config, error) {
+	config := reputation.Config{
+		Halflife: v.GetDuration(ReputationHalflifeKey),
+	}
+	if config.Halflife <= 0 {
+		return reputation.Config{}, fmt.Errorf("%q must be > 0", ReputationHalflifeKey)
 	}
 	return config, nil
 }
@@ -1230,6 +1249,10 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	}
 
 	nodeConfig.UseCurrentHeight = v.GetBool(ProposerVMUseCurrentHeightKey)
+	nodeConfig.ProposerVMCachePolicy = cache.Policy(v.GetString(ProposerVMCachePolicyKey))
+	if err := nodeConfig.ProposerVMCachePolicy.Valid(); err != nil {
+		return node.Config{}, fmt.Errorf("%s: %w", ProposerVMCachePolicyKey, err)
+	}
 
 	// Logging
 	nodeConfig.LoggingConfig, err = getLoggingConfig(v)
@@ -1330,6 +1353,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Reputation
+	nodeConfig.ReputationConfig, err = getReputationConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// File Descriptor Limit
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
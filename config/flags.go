@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database/leveldb"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/database/pebble"
@@ -172,12 +173,18 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// a timeout of 0 should generally not be provided.
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
+	fs.Bool(NetworkQUICEnabledKey, constants.DefaultNetworkQUICEnabled, "If true, P2P connections are made over QUIC when the peer supports it, falling back to TCP otherwise. App messages are sent over a separate QUIC stream so that they can't block consensus messages. The inbound message throttler's budgets are split between the two streams")
+
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
 	fs.Duration(BenchlistMinFailingDurationKey, constants.DefaultBenchlistMinFailingDuration, "Minimum amount of time messages to a peer must be failing before the peer is benched")
+	fs.Float64(BenchlistMinReputationKey, constants.DefaultBenchlistMinReputation, fmt.Sprintf("Reputation score in [0, 1] below which a peer is benched once it has been failing for %q, regardless of %q. 0 disables this", BenchlistMinFailingDurationKey, BenchlistFailThresholdKey))
+
+	// Reputation
+	fs.Duration(ReputationHalflifeKey, constants.DefaultReputationHalflife, "Halflife of the penalties given to peers for misbehaving")
 
 	// Router
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
@@ -303,6 +310,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	// ProposerVM
 	fs.Bool(ProposerVMUseCurrentHeightKey, false, "Have the ProposerVM always report the last accepted P-chain block height")
+	fs.String(ProposerVMCachePolicyKey, string(cache.LRUPolicy), fmt.Sprintf("Eviction policy of the ProposerVM block caches. Must be one of %q, %q or %q", cache.LRUPolicy, cache.TwoQueuePolicy, cache.TinyLFUPolicy))
 
 	// Metrics
 	fs.Bool(MeterVMsEnabledKey, true, "Enable Meter VMs to track VM performance with more granularity")
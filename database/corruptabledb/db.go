@@ -12,8 +12,13 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database           = (*Database)(nil)
+	_ database.ReverseIteratee    = (*Database)(nil)
+	_ database.Snapshotter        = (*Database)(nil)
+	_ database.DiskUsageEstimator = (*Database)(nil)
+	_ database.Batch              = (*batch)(nil)
+	_ database.Snapshot           = (*snapshot)(nil)
+	_ database.ReverseIteratee    = (*snapshot)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -71,6 +76,14 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
 
+func (db *Database) EstimateDiskUsage(prefix []byte) (uint64, error) {
+	if err := db.corrupted(); err != nil {
+		return 0, err
+	}
+	size, err := database.EstimateDiskUsage(db.Database, prefix)
+	return size, db.handleError(err)
+}
+
 func (db *Database) Close() error {
 	return db.handleError(db.Database.Close())
 }
@@ -117,6 +130,39 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: database.NewReverseIteratorWithStartAndPrefix(db.Database, start, prefix),
+		db:       db,
+	}
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	snap, err := database.NewSnapshot(db.Database)
+	if err != nil {
+		return nil, db.handleError(err)
+	}
+	return &snapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) corrupted() error {
 	db.errorLock.RLock()
 	defer db.errorLock.RUnlock()
@@ -126,7 +172,12 @@ func (db *Database) corrupted() error {
 
 func (db *Database) handleError(err error) error {
 	switch err {
-	case nil, database.ErrNotFound, database.ErrClosed:
+	case nil,
+		database.ErrNotFound,
+		database.ErrClosed,
+		database.ErrSnapshotNotSupported,
+		database.ErrReverseIterationNotSupported,
+		database.ErrDiskUsageEstimationNotSupported:
 	// If we get an error other than "not found" or "closed", disallow future
 	// database operations to avoid possible corruption
 	default:
@@ -156,6 +207,77 @@ func (b *batch) Write() error {
 	return b.db.handleError(b.Batch.Write())
 }
 
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if err := s.db.corrupted(); err != nil {
+		return false, err
+	}
+	has, err := s.Snapshot.Has(key)
+	return has, s.db.handleError(err)
+}
+
+// Get returns the value the key mapped to in the database when the snapshot
+// was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if err := s.db.corrupted(); err != nil {
+		return nil, err
+	}
+	value, err := s.Snapshot.Get(key)
+	return value, s.db.handleError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIterator(),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStart(start),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: database.NewReverseIteratorWithStartAndPrefix(s.Snapshot, start, prefix),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
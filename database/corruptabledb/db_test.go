@@ -42,6 +42,10 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
 	database.FuzzNewIteratorWithStartAndPrefix(f, newDB())
 }
 
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, newDB())
+}
+
 // TestCorruption tests to make sure corruptabledb wrapper works as expected.
 func TestCorruption(t *testing.T) {
 	key := []byte("hello")
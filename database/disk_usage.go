@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import "errors"
+
+var ErrDiskUsageEstimationNotSupported = errors.New("disk usage estimation not supported")
+
+// DiskUsageEstimator wraps the EstimateDiskUsage method of a backing data
+// store.
+type DiskUsageEstimator interface {
+	// EstimateDiskUsage returns an approximation of the number of bytes on
+	// disk used by the keys starting with [prefix]. The estimate may not
+	// include recently written data.
+	//
+	// Returns [ErrDiskUsageEstimationNotSupported] if the backing data store
+	// is unable to provide an estimate.
+	EstimateDiskUsage(prefix []byte) (uint64, error)
+}
+
+// EstimateDiskUsage returns the estimated disk usage of the keys in [db]
+// starting with [prefix] if [db] implements [DiskUsageEstimator]. Otherwise
+// [ErrDiskUsageEstimationNotSupported] is returned.
+func EstimateDiskUsage(db Database, prefix []byte) (uint64, error) {
+	e, ok := db.(DiskUsageEstimator)
+	if !ok {
+		return 0, ErrDiskUsageEstimationNotSupported
+	}
+	return e.EstimateDiskUsage(prefix)
+}
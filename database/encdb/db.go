@@ -4,40 +4,81 @@
 package encdb
 
 import (
+	"bytes"
 	"context"
-	"crypto/cipher"
-	"crypto/rand"
+	"errors"
 	"slices"
 	"sync"
 
-	"golang.org/x/crypto/chacha20poly1305"
-
 	"github.com/MetalBlockchain/metalgo/database"
-	"github.com/MetalBlockchain/metalgo/utils/hashing"
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database        = (*Database)(nil)
+	_ database.ReverseIteratee = (*Database)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Iterator        = (*iterator)(nil)
+
+	// ErrHashedKeysIteration is returned by iterators with a start or in
+	// reverse when keys are hashed.
+	ErrHashedKeysIteration = errors.New("only unordered iteration over a prefix is supported when keys are hashed")
+
+	errUnexpectedKey = errors.New("unexpected key")
 )
 
-// Database encrypts all values that are provided
+// Database encrypts all values that are provided.
+//
+// If key hashing is enabled, key names are replaced with a deterministic keyed
+// hash of every prefix of the key. This hides the key names from the
+// underlying database, at the cost of iteration order: only iteration over a
+// prefix is supported, and it returns keys in an unspecified order. The length
+// of the keys, and which keys share a prefix, are not hidden.
 type Database struct {
-	lock   sync.RWMutex
-	cipher cipher.AEAD
-	db     database.Database
-	closed bool
+	lock sync.RWMutex
+	// current is used to encrypt all values that are written.
+	current *keyring
+	// previous, if non-nil, is the keyring that values may still be encrypted
+	// under while a rotation is in progress.
+	previous *keyring
+	hashKeys bool
+	db       database.Database
+	closed   bool
+}
+
+type Config struct {
+	// HashKeys hides key names from the underlying database. When enabled,
+	// iteration doesn't return keys in sorted order, and iterators with a
+	// start or in reverse return [ErrHashedKeysIteration].
+	HashKeys bool
+	// PreviousPassword should be provided if a rotation away from this
+	// password was interrupted. Values encrypted under it can be read, and
+	// calling Rotate with the new password will finish the rotation.
+	PreviousPassword []byte
 }
 
 // New returns a new encrypted database
 func New(password []byte, db database.Database) (*Database, error) {
-	h := hashing.ComputeHash256(password)
-	aead, err := chacha20poly1305.NewX(h)
-	return &Database{
-		cipher: aead,
-		db:     db,
-	}, err
+	return NewWithConfig(password, db, Config{})
+}
+
+// NewWithConfig returns a new encrypted database with the provided config
+func NewWithConfig(password []byte, db database.Database, config Config) (*Database, error) {
+	current, err := newKeyring(password)
+	if err != nil {
+		return nil, err
+	}
+	encDB := &Database{
+		current:  current,
+		hashKeys: config.HashKeys,
+		db:       db,
+	}
+	if config.PreviousPassword != nil {
+		encDB.previous, err = newKeyring(config.PreviousPassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return encDB, nil
 }
 
 func (db *Database) Has(key []byte) (bool, error) {
@@ -47,7 +88,13 @@ func (db *Database) Has(key []byte) (bool, error) {
 	if db.closed {
 		return false, database.ErrClosed
 	}
-	return db.db.Has(key)
+	for _, encKey := range db.encodeKey(key) {
+		has, err := db.db.Has(encKey)
+		if err != nil || has {
+			return has, err
+		}
+	}
+	return false, nil
 }
 
 func (db *Database) Get(key []byte) ([]byte, error) {
@@ -57,11 +104,25 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 	if db.closed {
 		return nil, database.ErrClosed
 	}
-	encVal, err := db.db.Get(key)
-	if err != nil {
-		return nil, err
+	for _, encKey := range db.encodeKey(key) {
+		encVal, err := db.db.Get(encKey)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		decKey, value, _, err := db.decryptEntry(db.keyrings(), encKey, encVal)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(decKey, key) {
+			return nil, errUnexpectedKey
+		}
+		return value, nil
 	}
-	return db.decrypt(encVal)
+	return nil, database.ErrNotFound
 }
 
 func (db *Database) Put(key, value []byte) error {
@@ -71,12 +132,16 @@ func (db *Database) Put(key, value []byte) error {
 	if db.closed {
 		return database.ErrClosed
 	}
+	if !db.isRenaming() {
+		return db.put(db.db, key, value)
+	}
 
-	encValue, err := db.encrypt(value)
-	if err != nil {
+	// The entry must be atomically moved away from its previous name.
+	batch := db.db.NewBatch()
+	if err := db.put(batch, key, value); err != nil {
 		return err
 	}
-	return db.db.Put(key, encValue)
+	return batch.Write()
 }
 
 func (db *Database) Delete(key []byte) error {
@@ -86,13 +151,26 @@ func (db *Database) Delete(key []byte) error {
 	if db.closed {
 		return database.ErrClosed
 	}
-	return db.db.Delete(key)
+	if !db.isRenaming() {
+		return db.delete(db.db, key)
+	}
+
+	batch := db.db.NewBatch()
+	if err := db.delete(batch, key); err != nil {
+		return err
+	}
+	return batch.Write()
 }
 
 func (db *Database) NewBatch() database.Batch {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
 	return &batch{
-		Batch: db.db.NewBatch(),
-		db:    db,
+		Batch:    db.db.NewBatch(),
+		db:       db,
+		current:  db.current,
+		previous: db.previous,
 	}
 }
 
@@ -117,10 +195,68 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 			Err: database.ErrClosed,
 		}
 	}
-	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+	return db.newIterator(start, prefix, false)
+}
+
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return db.newIterator(start, prefix, true)
+}
+
+// Assumes [db.lock] is held.
+func (db *Database) newIterator(start, prefix []byte, reverse bool) database.Iterator {
+	if db.hashKeys && (len(start) != 0 || reverse) {
+		// Hashed keys don't preserve order, so the keys in a range can't be
+		// returned in order.
+		return &database.IteratorError{
+			Err: ErrHashedKeysIteration,
+		}
+	}
+
+	it := &iterator{
 		db:       db,
+		keyrings: db.keyrings(),
+	}
+	if !db.hashKeys {
+		var inner database.Iterator
+		if reverse {
+			inner = database.NewReverseIteratorWithStartAndPrefix(db.db, start, prefix)
+		} else {
+			inner = db.db.NewIteratorWithStartAndPrefix(start, prefix)
+		}
+		it.sources = []source{{Iterator: inner}}
+		return it
 	}
+
+	// Entries are filtered by keyring so that an entry being renamed by a
+	// rotation isn't returned twice.
+	for _, keyring := range it.keyrings {
+		it.sources = append(it.sources, source{
+			Iterator: db.db.NewIteratorWithPrefix(keyring.hashPrefix(prefix)),
+			keyring:  keyring,
+			prefix:   prefix,
+		})
+	}
+	return it
 }
 
 func (db *Database) Compact(start, limit []byte) error {
@@ -130,6 +266,11 @@ func (db *Database) Compact(start, limit []byte) error {
 	if db.closed {
 		return database.ErrClosed
 	}
+	if db.hashKeys {
+		// The range of hashed keys can't be determined from the range of
+		// the original keys.
+		return db.db.Compact(nil, nil)
+	}
 	return db.db.Compact(start, limit)
 }
 
@@ -161,11 +302,113 @@ func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 	return db.db.HealthCheck(ctx)
 }
 
+// keyrings returns the keyrings that values may be encrypted under, starting
+// with the current keyring.
+//
+// Assumes [db.lock] is held.
+func (db *Database) keyrings() []*keyring {
+	if db.previous == nil {
+		return []*keyring{db.current}
+	}
+	return []*keyring{db.current, db.previous}
+}
+
+// isRenaming returns true if writes must also remove the key from its
+// previous name.
+//
+// Assumes [db.lock] is held.
+func (db *Database) isRenaming() bool {
+	return db.hashKeys && db.previous != nil
+}
+
+// encodeKey returns the names that [key] may be stored under in the
+// underlying database, starting with the name that new values are written
+// to.
+//
+// Assumes [db.lock] is held.
+func (db *Database) encodeKey(key []byte) [][]byte {
+	if !db.hashKeys {
+		return [][]byte{key}
+	}
+	encKeys := make([][]byte, 0, 2)
+	for _, keyring := range db.keyrings() {
+		encKeys = append(encKeys, keyring.hashKey(key))
+	}
+	return encKeys
+}
+
+// put writes [value] to [key] under the current keyring into [w].
+//
+// Assumes [db.lock] is held.
+func (db *Database) put(w database.KeyValueWriterDeleter, key, value []byte) error {
+	plaintext := value
+	if db.hashKeys {
+		var err error
+		plaintext, err = Codec.Marshal(CodecVersion, &keyValue{
+			Key:   key,
+			Value: value,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	encValue, err := db.current.encrypt(plaintext)
+	if err != nil {
+		return err
+	}
+
+	encKeys := db.encodeKey(key)
+	if err := w.Put(encKeys[0], encValue); err != nil {
+		return err
+	}
+	for _, encKey := range encKeys[1:] {
+		if err := w.Delete(encKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// delete removes [key] from [w] under every keyring.
+//
+// Assumes [db.lock] is held.
+func (db *Database) delete(w database.KeyValueWriterDeleter, key []byte) error {
+	for _, encKey := range db.encodeKey(key) {
+		if err := w.Delete(encKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decryptEntry returns the original key and value of an entry in the
+// underlying database, along with the keyring it was encrypted under.
+func (db *Database) decryptEntry(keyrings []*keyring, encKey, encValue []byte) ([]byte, []byte, *keyring, error) {
+	plaintext, keyring, err := decrypt(keyrings, encValue)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !db.hashKeys {
+		return encKey, plaintext, keyring, nil
+	}
+
+	entry := keyValue{}
+	if _, err := Codec.Unmarshal(plaintext, &entry); err != nil {
+		return nil, nil, nil, err
+	}
+	return entry.Key, entry.Value, keyring, nil
+}
+
 type batch struct {
 	database.Batch
 
 	db  *Database
 	ops []database.BatchOp
+
+	// current and previous are the keyrings of [db] when the batch was last
+	// encoded. If the keyrings have changed, the batch must be re-encoded
+	// before it is written.
+	current, previous *keyring
 }
 
 func (b *batch) Put(key, value []byte) error {
@@ -173,11 +416,11 @@ func (b *batch) Put(key, value []byte) error {
 		Key:   slices.Clone(key),
 		Value: slices.Clone(value),
 	})
-	encValue, err := b.db.encrypt(value)
-	if err != nil {
-		return err
-	}
-	return b.Batch.Put(key, encValue)
+
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	return b.db.put(b.Batch, key, value)
 }
 
 func (b *batch) Delete(key []byte) error {
@@ -185,7 +428,11 @@ func (b *batch) Delete(key []byte) error {
 		Key:    slices.Clone(key),
 		Delete: true,
 	})
-	return b.Batch.Delete(key)
+
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	return b.db.delete(b.Batch, key)
 }
 
 func (b *batch) Write() error {
@@ -196,6 +443,23 @@ func (b *batch) Write() error {
 		return database.ErrClosed
 	}
 
+	if b.current != b.db.current || b.previous != b.db.previous {
+		// A rotation happened while the batch was being built.
+		b.Batch.Reset()
+		b.current = b.db.current
+		b.previous = b.db.previous
+		for _, op := range b.ops {
+			var err error
+			if op.Delete {
+				err = b.db.delete(b.Batch, op.Key)
+			} else {
+				err = b.db.put(b.Batch, op.Key, op.Value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
 	return b.Batch.Write()
 }
 
@@ -223,9 +487,24 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
-type iterator struct {
+// source is an iterator over the underlying database.
+type source struct {
 	database.Iterator
-	db *Database
+	// keyring, if non-nil, is the only keyring whose entries are returned
+	// from this source. This prevents an entry from being returned twice
+	// when iterating over multiple keyrings.
+	keyring *keyring
+	// prefix, if non-empty, is the prefix that returned keys must have.
+	// Hashed prefixes are truncated, so keys with a different prefix may be
+	// returned by the underlying iterator.
+	prefix []byte
+}
+
+type iterator struct {
+	db       *Database
+	keyrings []*keyring
+	// sources are iterated over in order.
+	sources []source
 
 	val, key []byte
 	err      error
@@ -240,28 +519,39 @@ func (it *iterator) Next() bool {
 		return false
 	}
 
-	next := it.Iterator.Next()
-	if next {
-		encVal := it.Iterator.Value()
-		val, err := it.db.decrypt(encVal)
+	for it.err == nil && len(it.sources) > 0 {
+		source := it.sources[0]
+		if !source.Next() {
+			it.err = source.Error()
+			source.Release()
+			it.sources = it.sources[1:]
+			continue
+		}
+
+		key, val, keyring, err := it.db.decryptEntry(it.keyrings, source.Key(), source.Value())
 		if err != nil {
 			it.err = err
-			return false
+			break
+		}
+		if source.keyring != nil && source.keyring != keyring {
+			continue
 		}
+		if !bytes.HasPrefix(key, source.prefix) {
+			continue
+		}
+
+		it.key = key
 		it.val = val
-		it.key = it.Iterator.Key()
-	} else {
-		it.val = nil
-		it.key = nil
+		return true
 	}
-	return next
+
+	it.val = nil
+	it.key = nil
+	return false
 }
 
 func (it *iterator) Error() error {
-	if it.err != nil {
-		return it.err
-	}
-	return it.Iterator.Error()
+	return it.err
 }
 
 func (it *iterator) Key() []byte {
@@ -272,27 +562,20 @@ func (it *iterator) Value() []byte {
 	return it.val
 }
 
+func (it *iterator) Release() {
+	for _, source := range it.sources {
+		source.Release()
+	}
+	it.sources = nil
+}
+
 type encryptedValue struct {
 	Ciphertext []byte `serialize:"true"`
 	Nonce      []byte `serialize:"true"`
 }
 
-func (db *Database) encrypt(plaintext []byte) ([]byte, error) {
-	nonce := make([]byte, chacha20poly1305.NonceSizeX)
-	if _, err := rand.Read(nonce); err != nil {
-		return nil, err
-	}
-	ciphertext := db.cipher.Seal(nil, nonce, plaintext, nil)
-	return Codec.Marshal(CodecVersion, &encryptedValue{
-		Ciphertext: ciphertext,
-		Nonce:      nonce,
-	})
-}
-
-func (db *Database) decrypt(ciphertext []byte) ([]byte, error) {
-	val := encryptedValue{}
-	if _, err := Codec.Unmarshal(ciphertext, &val); err != nil {
-		return nil, err
-	}
-	return db.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+// keyValue is the plaintext of an entry when keys are hashed.
+type keyValue struct {
+	Key   []byte `serialize:"true"`
+	Value []byte `serialize:"true"`
 }
@@ -4,6 +4,7 @@
 package encdb
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -46,6 +47,10 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
 	database.FuzzNewIteratorWithStartAndPrefix(f, newDB(f))
 }
 
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, newDB(f))
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
@@ -56,3 +61,240 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+func TestHashKeys(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := NewWithConfig([]byte(testPassword), baseDB, Config{
+		HashKeys: true,
+	})
+	require.NoError(err)
+
+	entries := map[string]string{
+		"":          "empty",
+		"user1":     "value1",
+		"user1/a":   "value2",
+		"user1/b":   "value3",
+		"user2":     "value4",
+		"user2/abc": "value5",
+	}
+	for key, value := range entries {
+		require.NoError(db.Put([]byte(key), []byte(value)))
+	}
+
+	for key, value := range entries {
+		has, err := db.Has([]byte(key))
+		require.NoError(err)
+		require.True(has)
+
+		gotValue, err := db.Get([]byte(key))
+		require.NoError(err)
+		require.Equal([]byte(value), gotValue)
+
+		has, err = baseDB.Has([]byte(key))
+		require.NoError(err)
+		require.False(has)
+	}
+
+	// The key names must not be present in the underlying database.
+	it := baseDB.NewIterator()
+	for it.Next() {
+		require.NotContains(string(it.Key()), "user")
+	}
+	require.NoError(it.Error())
+	it.Release()
+
+	it = db.NewIterator()
+	keys := []string{}
+	for it.Next() {
+		key := string(it.Key())
+		require.Equal([]byte(entries[key]), it.Value())
+		keys = append(keys, key)
+	}
+	require.NoError(it.Error())
+	it.Release()
+
+	// Hashed keys aren't returned in order.
+	require.ElementsMatch([]string{"", "user1", "user1/a", "user1/b", "user2", "user2/abc"}, keys)
+
+	// Iteration over a prefix only returns keys with that prefix.
+	it = db.NewIteratorWithPrefix([]byte("user1"))
+	keys = []string{}
+	for it.Next() {
+		key := string(it.Key())
+		require.Equal([]byte(entries[key]), it.Value())
+		keys = append(keys, key)
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.ElementsMatch([]string{"user1", "user1/a", "user1/b"}, keys)
+
+	// Iterators that would need to return keys in order aren't supported.
+	for _, it := range []database.Iterator{
+		db.NewIteratorWithStart([]byte("user1")),
+		db.NewReverseIterator(),
+	} {
+		require.False(it.Next())
+		require.ErrorIs(it.Error(), ErrHashedKeysIteration)
+		it.Release()
+	}
+
+	require.NoError(db.Delete([]byte("user1")))
+	_, err = db.Get([]byte("user1"))
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestRotate(t *testing.T) {
+	newPassword := []byte("an even more secure password")
+	for _, hashKeys := range []bool{false, true} {
+		t.Run(fmt.Sprintf("hashKeys=%t", hashKeys), func(t *testing.T) {
+			require := require.New(t)
+
+			baseDB := memdb.New()
+			config := Config{
+				HashKeys: hashKeys,
+			}
+			db, err := NewWithConfig([]byte(testPassword), baseDB, config)
+			require.NoError(err)
+
+			const numEntries = 2*rotationBatchSize + 1
+			for i := 0; i < numEntries; i++ {
+				require.NoError(db.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))))
+			}
+
+			require.NoError(db.Rotate(context.Background(), newPassword))
+			require.Equal(numEntries, requireRotated(t, baseDB, newPassword))
+
+			newDB, err := NewWithConfig(newPassword, baseDB, config)
+			require.NoError(err)
+			for i := 0; i < numEntries; i++ {
+				key := []byte(fmt.Sprintf("key%d", i))
+				expectedValue := []byte(fmt.Sprintf("value%d", i))
+
+				value, err := db.Get(key)
+				require.NoError(err)
+				require.Equal(expectedValue, value)
+
+				value, err = newDB.Get(key)
+				require.NoError(err)
+				require.Equal(expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestRotateResume(t *testing.T) {
+	newPassword := []byte("an even more secure password")
+	for _, hashKeys := range []bool{false, true} {
+		t.Run(fmt.Sprintf("hashKeys=%t", hashKeys), func(t *testing.T) {
+			require := require.New(t)
+
+			baseDB := memdb.New()
+			db, err := NewWithConfig([]byte(testPassword), baseDB, Config{
+				HashKeys: hashKeys,
+			})
+			require.NoError(err)
+
+			key1 := []byte("hello1")
+			value1 := []byte("world1")
+			key2 := []byte("hello2")
+			value2 := []byte("world2")
+			require.NoError(db.Put(key1, value1))
+
+			// Interrupt the rotation before any values are re-encrypted.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			require.ErrorIs(db.Rotate(ctx, newPassword), context.Canceled)
+			require.ErrorIs(db.Rotate(context.Background(), []byte("another password")), ErrRotationInProgress)
+
+			// Values written during the rotation use the new password.
+			require.NoError(db.Put(key2, value2))
+
+			// Re-open the database as if the process restarted.
+			db, err = NewWithConfig(newPassword, baseDB, Config{
+				HashKeys:         hashKeys,
+				PreviousPassword: []byte(testPassword),
+			})
+			require.NoError(err)
+
+			value, err := db.Get(key1)
+			require.NoError(err)
+			require.Equal(value1, value)
+
+			it := db.NewIterator()
+			numEntries := 0
+			for it.Next() {
+				numEntries++
+			}
+			require.NoError(it.Error())
+			it.Release()
+			require.Equal(2, numEntries)
+
+			require.NoError(db.Rotate(context.Background(), newPassword))
+			require.Equal(2, requireRotated(t, baseDB, newPassword))
+
+			newDB, err := NewWithConfig(newPassword, baseDB, Config{
+				HashKeys: hashKeys,
+			})
+			require.NoError(err)
+			for key, expectedValue := range map[string][]byte{
+				string(key1): value1,
+				string(key2): value2,
+			} {
+				value, err := newDB.Get([]byte(key))
+				require.NoError(err)
+				require.Equal(expectedValue, value)
+			}
+		})
+	}
+}
+
+func TestBatchWriteAfterRotate(t *testing.T) {
+	require := require.New(t)
+
+	newPassword := []byte("an even more secure password")
+	baseDB := memdb.New()
+	db, err := New([]byte(testPassword), baseDB)
+	require.NoError(err)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put(key, value))
+
+	require.NoError(db.Rotate(context.Background(), newPassword))
+	require.NoError(batch.Write())
+
+	newDB, err := New(newPassword, baseDB)
+	require.NoError(err)
+
+	gotValue, err := newDB.Get(key)
+	require.NoError(err)
+	require.Equal(value, gotValue)
+}
+
+// requireRotated requires that every value in [db] is encrypted under
+// [password] and returns the number of values.
+func requireRotated(t *testing.T, db database.Database, password []byte) int {
+	require := require.New(t)
+
+	current, err := newKeyring(password)
+	require.NoError(err)
+	previous, err := newKeyring([]byte(testPassword))
+	require.NoError(err)
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	numEntries := 0
+	for it.Next() {
+		_, keyring, err := decrypt([]*keyring{current, previous}, it.Value())
+		require.NoError(err)
+		require.Equal(current, keyring)
+		numEntries++
+	}
+	require.NoError(it.Error())
+	return numEntries
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"slices"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/MetalBlockchain/metalgo/utils/hashing"
+)
+
+// keyHashingDomain separates the key used to hash key names from the key used
+// to encrypt values.
+var keyHashingDomain = []byte("encdb key hashing")
+
+// keyring holds the keys derived from a password.
+type keyring struct {
+	cipher cipher.AEAD
+	macKey []byte
+}
+
+func newKeyring(password []byte) (*keyring, error) {
+	h := hashing.ComputeHash256(password)
+	aead, err := chacha20poly1305.NewX(h)
+	if err != nil {
+		return nil, err
+	}
+	return &keyring{
+		cipher: aead,
+		macKey: hashing.ComputeHash256(append(slices.Clone(keyHashingDomain), password...)),
+	}, nil
+}
+
+// equal returns true if [k] and [o] were derived from the same password.
+func (k *keyring) equal(o *keyring) bool {
+	return hmac.Equal(k.macKey, o.macKey)
+}
+
+func (k *keyring) encrypt(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	ciphertext := k.cipher.Seal(nil, nonce, plaintext, nil)
+	return Codec.Marshal(CodecVersion, &encryptedValue{
+		Ciphertext: ciphertext,
+		Nonce:      nonce,
+	})
+}
+
+// decrypt returns the plaintext of [ciphertext] along with the keyring in
+// [keyrings] that it was encrypted under.
+func decrypt(keyrings []*keyring, ciphertext []byte) ([]byte, *keyring, error) {
+	val := encryptedValue{}
+	if _, err := Codec.Unmarshal(ciphertext, &val); err != nil {
+		return nil, nil, err
+	}
+
+	var err error
+	for _, k := range keyrings {
+		var plaintext []byte
+		plaintext, err = k.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+		if err == nil {
+			return plaintext, k, nil
+		}
+	}
+	return nil, nil, err
+}
+
+// hashKey returns the name that [key] is stored under when keys are hashed.
+//
+// The name is the hash of every prefix of [key], truncated to a single byte,
+// followed by a full hash of [key]. The full hash prevents different keys from
+// sharing a name.
+func (k *keyring) hashKey(key []byte) []byte {
+	encKey := make([]byte, len(key), len(key)+sha256.Size)
+	state := k.hashPrefixes(encKey, key)
+
+	mac := hmac.New(sha256.New, k.macKey)
+	_, _ = mac.Write(state)
+	return mac.Sum(encKey)
+}
+
+// hashPrefix returns the prefix that the names of all keys starting with
+// [prefix] share when keys are hashed.
+func (k *keyring) hashPrefix(prefix []byte) []byte {
+	encPrefix := make([]byte, len(prefix))
+	_ = k.hashPrefixes(encPrefix, prefix)
+	return encPrefix
+}
+
+// hashPrefixes writes a byte of the keyed hash of every prefix of [key] into
+// [dst] and returns the full hash of [key].
+func (k *keyring) hashPrefixes(dst, key []byte) []byte {
+	var (
+		mac   = hmac.New(sha256.New, k.macKey)
+		state = make([]byte, sha256.Size)
+		input = make([]byte, sha256.Size+1)
+	)
+	for i, b := range key {
+		// Each state commits to the entire prefix before it.
+		copy(input, state)
+		input[sha256.Size] = b
+
+		mac.Reset()
+		_, _ = mac.Write(input)
+		state = mac.Sum(state[:0])
+		dst[i] = state[0]
+	}
+	return state
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+// rotationBatchSize is the number of entries re-encrypted while holding the
+// lock during a rotation.
+const rotationBatchSize = 1024
+
+var ErrRotationInProgress = errors.New("rotation to a different password in progress")
+
+// Rotate re-encrypts all values under [password]. Reads and writes can be
+// performed concurrently with the rotation, so Rotate can be run in the
+// background.
+//
+// If the rotation is interrupted, either by [ctx] being cancelled or the
+// process stopping, values may be encrypted under either password. The
+// rotation can be finished by calling Rotate again with the same password. If
+// the database is re-opened, the prior password must be provided as
+// [Config.PreviousPassword].
+func (db *Database) Rotate(ctx context.Context, password []byte) error {
+	next, err := newKeyring(password)
+	if err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	switch {
+	case db.closed:
+		db.lock.Unlock()
+		return database.ErrClosed
+	case db.previous == nil:
+		db.previous = db.current
+		db.current = next
+	case !db.current.equal(next):
+		db.lock.Unlock()
+		return ErrRotationInProgress
+	}
+	db.lock.Unlock()
+
+	var (
+		start []byte
+		done  bool
+	)
+	for !done {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		start, done, err = db.rotateBatch(start)
+		if err != nil {
+			return err
+		}
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.previous = nil
+	return nil
+}
+
+// rotateBatch re-encrypts up to [rotationBatchSize] entries at or after
+// [start] in the underlying database. It returns the key to continue the
+// rotation from and whether the rotation has reached the end of the database.
+func (db *Database) rotateBatch(start []byte) ([]byte, bool, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, false, database.ErrClosed
+	}
+
+	var (
+		keyrings = db.keyrings()
+		it       = db.db.NewIteratorWithStart(start)
+		ops      []database.BatchOp
+		next     []byte
+		count    int
+	)
+	for ; count < rotationBatchSize && it.Next(); count++ {
+		encKey := it.Key()
+		key, value, keyring, err := db.decryptEntry(keyrings, encKey, it.Value())
+		if err != nil {
+			it.Release()
+			return nil, false, err
+		}
+		if keyring != db.current {
+			ops = append(ops, database.BatchOp{
+				Key:   slices.Clone(key),
+				Value: value,
+			})
+		}
+		// The next batch starts immediately after [encKey].
+		next = append(slices.Clone(encKey), 0x00)
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return nil, false, err
+	}
+
+	batch := db.db.NewBatch()
+	for _, op := range ops {
+		if err := db.put(batch, op.Key, op.Value); err != nil {
+			return nil, false, err
+		}
+	}
+	return next, count < rotationBatchSize, batch.Write()
+}
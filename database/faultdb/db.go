@@ -0,0 +1,385 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultdb
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+var (
+	_ database.Database        = (*Database)(nil)
+	_ database.ReverseIteratee = (*Database)(nil)
+	_ database.Snapshotter     = (*Database)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Iterator        = (*iterator)(nil)
+	_ database.Snapshot        = (*snapshot)(nil)
+	_ database.ReverseIteratee = (*snapshot)(nil)
+)
+
+// Database is a wrapper around a database that injects faults into operations
+// according to a set of rules. All randomness is drawn from a seeded source,
+// so a test that performs the same operations in the same order will observe
+// the same faults.
+type Database struct {
+	database.Database
+
+	lock       sync.Mutex
+	rng        *rand.Rand
+	rules      []*rule
+	injections []Injection
+}
+
+// New returns a new database that injects faults into [db] according to
+// [rules].
+func New(db database.Database, seed int64, rules ...Rule) *Database {
+	faultDB := &Database{
+		Database: db,
+		rng:      rand.New(rand.NewSource(seed)), // #nosec G404
+	}
+	faultDB.SetRules(rules...)
+	return faultDB
+}
+
+// SetRules replaces the rules of the database. Faults injected by the previous
+// rules are still reported by Injections.
+func (db *Database) SetRules(rules ...Rule) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.rules = make([]*rule, len(rules))
+	for i, r := range rules {
+		db.rules[i] = &rule{Rule: r}
+	}
+}
+
+// Injections returns the faults that have been injected, in the order they
+// were injected.
+func (db *Database) Injections() []Injection {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	injections := make([]Injection, len(db.injections))
+	copy(injections, db.injections)
+	return injections
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	if fault, err := db.inject(OpHas, key); fault != FaultNone {
+		return false, err
+	}
+	return db.Database.Has(key)
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	if fault, err := db.inject(OpGet, key); fault != FaultNone {
+		return nil, err
+	}
+	return db.Database.Get(key)
+}
+
+func (db *Database) Put(key, value []byte) error {
+	fault, injectedErr := db.inject(OpPut, key)
+	switch fault {
+	case FaultNone:
+		return db.Database.Put(key, value)
+	case FaultTornWrite:
+		if err := db.Database.Put(key, db.tear(value)); err != nil {
+			return err
+		}
+	}
+	return injectedErr
+}
+
+func (db *Database) Delete(key []byte) error {
+	if fault, err := db.inject(OpDelete, key); fault != FaultNone {
+		return err
+	}
+	return db.Database.Delete(key)
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		db: db,
+	}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: db.Database.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: database.NewReverseIteratorWithStartAndPrefix(db.Database, start, prefix),
+		db:       db,
+	}
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snap, err := database.NewSnapshot(db.Database)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	if fault, err := db.inject(OpCompact); fault != FaultNone {
+		return err
+	}
+	return db.Database.Compact(start, limit)
+}
+
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	if fault, err := db.inject(OpHealthCheck); fault != FaultNone {
+		return nil, err
+	}
+	return db.Database.HealthCheck(ctx)
+}
+
+// inject applies the latency of every rule matching [op] on [keys] and returns
+// the fault of the first matching rule that specifies one, along with the
+// error that should be returned.
+func (db *Database) inject(op Op, keys ...[]byte) (Fault, error) {
+	db.lock.Lock()
+	var (
+		latency time.Duration
+		fault   = FaultNone
+		err     error
+	)
+	for i, r := range db.rules {
+		if !r.matches(op, keys) {
+			continue
+		}
+		r.calls++
+		if r.calls <= r.After {
+			continue
+		}
+		if r.Count != 0 && r.injected >= r.Count {
+			continue
+		}
+		if r.Probability != 0 && db.rng.Float64() >= r.Probability {
+			continue
+		}
+
+		r.injected++
+		latency += r.Latency
+		db.injections = append(db.injections, Injection{
+			Rule:  i,
+			Op:    op,
+			Fault: r.Fault,
+		})
+		if fault == FaultNone && r.Fault != FaultNone {
+			fault = r.Fault
+			err = r.err()
+		}
+	}
+	db.lock.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	return fault, err
+}
+
+// intn returns a deterministic random number in [0, n).
+func (db *Database) intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	return db.rng.Intn(n)
+}
+
+// tear returns a strict prefix of [value], or [value] if it's empty.
+func (db *Database) tear(value []byte) []byte {
+	return value[:db.intn(len(value))]
+}
+
+type batch struct {
+	database.BatchOps
+
+	db *Database
+}
+
+func (b *batch) Write() error {
+	keys := make([][]byte, len(b.Ops))
+	for i, op := range b.Ops {
+		keys[i] = op.Key
+	}
+
+	ops := b.Ops
+	fault, injectedErr := b.db.inject(OpBatchWrite, keys...)
+	switch fault {
+	case FaultNone:
+	case FaultPartialWrite, FaultTornWrite:
+		ops = ops[:b.db.intn(len(ops))]
+	default:
+		return injectedErr
+	}
+
+	batch := b.db.Database.NewBatch()
+	for _, op := range ops {
+		var err error
+		if op.Delete {
+			err = batch.Delete(op.Key)
+		} else {
+			err = batch.Put(op.Key, op.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if fault == FaultTornWrite && len(ops) < len(b.Ops) {
+		// Tear the first operation that wasn't written.
+		if op := b.Ops[len(ops)]; !op.Delete {
+			if err := batch.Put(op.Key, b.db.tear(op.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return injectedErr
+}
+
+func (b *batch) Inner() database.Batch {
+	return b
+}
+
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if fault, err := s.db.inject(OpHas, key); fault != FaultNone {
+		return false, err
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if fault, err := s.db.inject(OpGet, key); fault != FaultNone {
+		return nil, err
+	}
+	return s.Snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: database.NewReverseIteratorWithStartAndPrefix(s.Snapshot, start, prefix),
+		db:       s.db,
+	}
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+
+	err error
+}
+
+// Next injects faults based on the key that the underlying iterator moved to.
+// Once a fault is injected, the iterator is exhausted.
+func (it *iterator) Next() bool {
+	if it.err != nil || !it.Iterator.Next() {
+		return false
+	}
+	if fault, err := it.db.inject(OpIteratorNext, it.Iterator.Key()); fault != FaultNone {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *iterator) Key() []byte {
+	if it.err != nil {
+		return nil
+	}
+	return it.Iterator.Key()
+}
+
+func (it *iterator) Value() []byte {
+	if it.err != nil {
+		return nil
+	}
+	return it.Iterator.Value()
+}
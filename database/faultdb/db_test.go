@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultdb
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+)
+
+var errTest = errors.New("non-nil error")
+
+func newDB() *Database {
+	return New(memdb.New(), 0)
+}
+
+func TestInterface(t *testing.T) {
+	for name, test := range database.Tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newDB())
+		})
+	}
+}
+
+func FuzzKeyValue(f *testing.F) {
+	database.FuzzKeyValue(f, newDB())
+}
+
+func FuzzNewIteratorWithPrefix(f *testing.F) {
+	database.FuzzNewIteratorWithPrefix(f, newDB())
+}
+
+func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewIteratorWithStartAndPrefix(f, newDB())
+}
+
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, newDB())
+}
+
+func TestErrorFault(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB, 0, Rule{
+		Ops:    []Op{OpPut, OpGet},
+		Prefix: []byte("fail"),
+		After:  1,
+		Count:  2,
+		Fault:  FaultError,
+		Err:    errTest,
+	})
+
+	key := []byte("fail1")
+	value := []byte("world")
+
+	// The first matching operation is let through.
+	require.NoError(db.Put(key, value))
+
+	// Operations on other keys don't match.
+	require.NoError(db.Put([]byte("hello"), value))
+
+	_, err := db.Get(key)
+	require.ErrorIs(err, errTest)
+	require.ErrorIs(db.Put(key, value), errTest)
+
+	// The fault was only injected [Count] times.
+	got, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, got)
+
+	// Ops not specified by the rule don't match.
+	require.NoError(db.Delete(key))
+
+	require.Equal(
+		[]Injection{
+			{Rule: 0, Op: OpGet, Fault: FaultError},
+			{Rule: 0, Op: OpPut, Fault: FaultError},
+		},
+		db.Injections(),
+	)
+}
+
+func TestTornPut(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB, 0, Rule{
+		Fault: FaultTornWrite,
+	})
+
+	key := []byte("hello")
+	value := []byte("world")
+	require.ErrorIs(db.Put(key, value), ErrInjected)
+
+	got, err := baseDB.Get(key)
+	require.NoError(err)
+	require.Less(len(got), len(value))
+	require.Equal(value[:len(got)], got)
+}
+
+func TestPartialBatchWrite(t *testing.T) {
+	for _, fault := range []Fault{FaultPartialWrite, FaultTornWrite} {
+		t.Run(fault.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			baseDB := memdb.New()
+			db := New(baseDB, 0, Rule{
+				Ops:   []Op{OpBatchWrite},
+				Fault: fault,
+			})
+
+			const numOps = 16
+			value := []byte("world")
+			batch := db.NewBatch()
+			for i := 0; i < numOps; i++ {
+				require.NoError(batch.Put([]byte(fmt.Sprintf("key%02d", i)), value))
+			}
+			require.ErrorIs(batch.Write(), ErrInjected)
+
+			// A prefix of the batch must have been written.
+			written := 0
+			for ; written < numOps; written++ {
+				got, err := baseDB.Get([]byte(fmt.Sprintf("key%02d", written)))
+				if err == database.ErrNotFound {
+					break
+				}
+				require.NoError(err)
+				if fault == FaultTornWrite && len(got) < len(value) {
+					// Nothing is written after the torn value.
+					written++
+					break
+				}
+				require.Equal(value, got)
+			}
+			require.Less(written, numOps)
+			for i := written; i < numOps; i++ {
+				has, err := baseDB.Has([]byte(fmt.Sprintf("key%02d", i)))
+				require.NoError(err)
+				require.False(has)
+			}
+		})
+	}
+}
+
+func TestIteratorFault(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New(), 0, Rule{
+		Ops:    []Op{OpIteratorNext},
+		Prefix: []byte("b"),
+		Fault:  FaultError,
+	})
+
+	value := []byte("world")
+	require.NoError(db.Put([]byte("a"), value))
+	require.NoError(db.Put([]byte("b"), value))
+	require.NoError(db.Put([]byte("c"), value))
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	require.True(it.Next())
+	require.Equal([]byte("a"), it.Key())
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrInjected)
+	require.Nil(it.Key())
+	require.False(it.Next())
+}
+
+func TestDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	run := func(seed int64) []bool {
+		db := New(memdb.New(), seed, Rule{
+			Probability: .5,
+			Fault:       FaultError,
+		})
+
+		failed := make([]bool, 100)
+		for i := range failed {
+			failed[i] = db.Put([]byte{byte(i)}, nil) != nil
+		}
+		return failed
+	}
+
+	require.Equal(run(1), run(1))
+	require.NotEqual(run(1), run(2))
+}
+
+func TestSetRules(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New(), 0, Rule{
+		Fault: FaultError,
+	})
+
+	key := []byte("hello")
+	require.ErrorIs(db.Put(key, nil), ErrInjected)
+
+	db.SetRules()
+	require.NoError(db.Put(key, nil))
+	require.Len(db.Injections(), 1)
+}
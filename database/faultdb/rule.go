@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package faultdb
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrInjected is returned by operations that a fault was injected into if the
+// rule doesn't specify an error.
+var ErrInjected = errors.New("injected fault")
+
+// Op is a database operation that faults can be injected into.
+type Op byte
+
+const (
+	OpHas Op = iota
+	OpGet
+	OpPut
+	OpDelete
+	OpBatchWrite
+	OpIteratorNext
+	OpCompact
+	OpHealthCheck
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpHas:
+		return "has"
+	case OpGet:
+		return "get"
+	case OpPut:
+		return "put"
+	case OpDelete:
+		return "delete"
+	case OpBatchWrite:
+		return "batch write"
+	case OpIteratorNext:
+		return "iterator next"
+	case OpCompact:
+		return "compact"
+	case OpHealthCheck:
+		return "health check"
+	default:
+		return "unknown"
+	}
+}
+
+// Fault is the failure injected into an operation.
+type Fault byte
+
+const (
+	// FaultNone only injects the rule's latency.
+	FaultNone Fault = iota
+	// FaultError fails the operation without performing it.
+	FaultError
+	// FaultPartialWrite performs a prefix of the operations in a batch before
+	// failing. For other operations, it behaves like FaultError.
+	FaultPartialWrite
+	// FaultTornWrite performs a prefix of the operations in a batch, followed
+	// by a put with a truncated value, before failing. A single put writes a
+	// truncated value before failing. For other operations, it behaves like
+	// FaultError.
+	FaultTornWrite
+)
+
+func (f Fault) String() string {
+	switch f {
+	case FaultNone:
+		return "none"
+	case FaultError:
+		return "error"
+	case FaultPartialWrite:
+		return "partial write"
+	case FaultTornWrite:
+		return "torn write"
+	default:
+		return "unknown"
+	}
+}
+
+// Rule describes which operations a fault is injected into.
+type Rule struct {
+	// Ops this rule applies to. If empty, the rule applies to all operations.
+	Ops []Op
+	// Prefix restricts this rule to operations on keys with this prefix. A
+	// batch write matches if any key in the batch has the prefix. Operations
+	// without keys, such as Compact, never match a non-empty prefix.
+	Prefix []byte
+	// After is the number of matching operations to let through before
+	// injecting the fault.
+	After uint64
+	// Count is the maximum number of times the fault is injected. If 0, the
+	// fault is injected indefinitely.
+	Count uint64
+	// Probability of injecting the fault into a matching operation, drawn from
+	// the database's seeded source of randomness. If 0, the fault is always
+	// injected.
+	Probability float64
+
+	// Latency is added to the operation before it is performed.
+	Latency time.Duration
+	// Fault is the failure injected into the operation.
+	Fault Fault
+	// Err is returned by operations that fail. If nil, ErrInjected is
+	// returned.
+	Err error
+}
+
+func (r *Rule) matches(op Op, keys [][]byte) bool {
+	if len(r.Ops) != 0 && !slices.Contains(r.Ops, op) {
+		return false
+	}
+	if len(r.Prefix) == 0 {
+		return true
+	}
+	for _, key := range keys {
+		if bytes.HasPrefix(key, r.Prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) err() error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return ErrInjected
+}
+
+// Injection records a fault that was injected.
+type Injection struct {
+	// Rule is the index of the rule that caused the injection.
+	Rule  int
+	Op    Op
+	Fault Fault
+}
+
+// rule tracks how many times a rule has matched.
+type rule struct {
+	Rule
+
+	calls    uint64
+	injected uint64
+}
@@ -7,7 +7,13 @@
 
 package database
 
-var _ Iterator = (*IteratorError)(nil)
+import "errors"
+
+var (
+	_ Iterator = (*IteratorError)(nil)
+
+	ErrReverseIterationNotSupported = errors.New("reverse iteration not supported")
+)
 
 // Iterator iterates over a database's key/value pairs.
 //
@@ -67,6 +73,44 @@ type Iteratee interface {
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
 }
 
+// ReverseIteratee wraps the NewReverseIterator methods of a backing data
+// store. Reverse iterators yield key/value pairs in descending
+// binary-alphabetical order.
+type ReverseIteratee interface {
+	// NewReverseIterator creates a reverse iterator over the entire keyspace
+	// contained within the key-value database, starting at the last key.
+	NewReverseIterator() Iterator
+
+	// NewReverseIteratorWithStart creates a reverse iterator over a subset of
+	// database content starting at a particular initial key. Only keys less
+	// than or equal to [start] are returned. An empty [start] is treated as a
+	// key after all keys in the database.
+	NewReverseIteratorWithStart(start []byte) Iterator
+
+	// NewReverseIteratorWithPrefix creates a reverse iterator over a subset of
+	// database content with a particular key prefix, starting at the last key
+	// with the prefix.
+	NewReverseIteratorWithPrefix(prefix []byte) Iterator
+
+	// NewReverseIteratorWithStartAndPrefix creates a reverse iterator over a
+	// subset of database content with a particular key prefix starting at a
+	// specified key.
+	NewReverseIteratorWithStartAndPrefix(start, prefix []byte) Iterator
+}
+
+// NewReverseIteratorWithStartAndPrefix returns a reverse iterator over [db] if
+// it implements [ReverseIteratee]. Otherwise an iterator reporting
+// [ErrReverseIterationNotSupported] is returned.
+func NewReverseIteratorWithStartAndPrefix(db Iteratee, start, prefix []byte) Iterator {
+	r, ok := db.(ReverseIteratee)
+	if !ok {
+		return &IteratorError{
+			Err: ErrReverseIterationNotSupported,
+		}
+	}
+	return r.NewReverseIteratorWithStartAndPrefix(start, prefix)
+}
+
 // IteratorError does nothing and returns the provided error
 type IteratorError struct {
 	Err error
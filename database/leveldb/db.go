@@ -63,9 +63,14 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iter)(nil)
+	_ database.Database           = (*Database)(nil)
+	_ database.ReverseIteratee    = (*Database)(nil)
+	_ database.Snapshotter        = (*Database)(nil)
+	_ database.DiskUsageEstimator = (*Database)(nil)
+	_ database.Batch              = (*batch)(nil)
+	_ database.Iterator           = (*iter)(nil)
+	_ database.Snapshot           = (*snapshot)(nil)
+	_ database.ReverseIteratee    = (*snapshot)(nil)
 
 	ErrInvalidConfig = errors.New("invalid config")
 	ErrCouldNotOpen  = errors.New("could not open")
@@ -327,16 +332,57 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
+	return &iter{
+		db:       db,
+		Iterator: db.DB.NewIterator(iteratorRange(start, prefix), nil),
 	}
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the database
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewReverseIteratorWithStart creates a reverse lexicographically ordered
+// iterator over the database starting at the provided key
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the database ignoring keys that do not start with the provided
+// prefix
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewReverseIteratorWithStartAndPrefix creates a reverse lexicographically
+// ordered iterator over the database starting at start and ignoring keys that
+// do not start with the provided prefix
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
 	return &iter{
 		db:       db,
-		Iterator: db.DB.NewIterator(iterRange, nil),
+		Iterator: db.DB.NewIterator(reverseIteratorRange(start, prefix), nil),
+		reverse:  true,
 	}
 }
 
+// NewSnapshot returns a read-only view of the database as of now
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.closed.Get() {
+		return nil, database.ErrClosed
+	}
+	snap, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		Snapshot: snap,
+	}, nil
+}
+
 // This comment is basically copy pasted from the underlying levelDB library:
 
 // Compact the underlying DB for the given key range.
@@ -352,6 +398,32 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+func (db *Database) EstimateDiskUsage(prefix []byte) (uint64, error) {
+	keyRange := util.BytesPrefix(prefix)
+	if keyRange.Limit == nil {
+		// A nil limit is treated as a key before all keys by SizeOf, so the
+		// limit is set to the smallest key after the last key in the range.
+		it := db.DB.NewIterator(keyRange, nil)
+		hasLast := it.Last()
+		if hasLast {
+			keyRange.Limit = append(slices.Clone(it.Key()), 0x00)
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return 0, updateError(err)
+		}
+		if !hasLast {
+			return 0, nil
+		}
+	}
+
+	sizes, err := db.DB.SizeOf([]util.Range{*keyRange})
+	if err != nil {
+		return 0, updateError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
 func (db *Database) Close() error {
 	db.closed.Set(true)
 	db.closeOnce.Do(func() {
@@ -443,6 +515,9 @@ type iter struct {
 	db *Database
 	iterator.Iterator
 
+	// reverse is true if the iterator moves from the last key to the first.
+	reverse, initialized bool
+
 	key, val []byte
 	err      error
 }
@@ -456,7 +531,16 @@ func (it *iter) Next() bool {
 		return false
 	}
 
-	hasNext := it.Iterator.Next()
+	var hasNext bool
+	switch {
+	case !it.reverse:
+		hasNext = it.Iterator.Next()
+	case !it.initialized:
+		hasNext = it.Iterator.Last()
+		it.initialized = true
+	default:
+		hasNext = it.Iterator.Prev()
+	}
 	if hasNext {
 		it.key = slices.Clone(it.Iterator.Key())
 		it.val = slices.Clone(it.Iterator.Value())
@@ -482,9 +566,95 @@ func (it *iter) Value() []byte {
 	return it.val
 }
 
+// snapshot is a wrapper around a levelDB snapshot to convert errors and
+// iterators.
+type snapshot struct {
+	db *Database
+	*leveldb.Snapshot
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to in the database when the snapshot
+// was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(iteratorRange(start, prefix), nil),
+	}
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.Snapshot.NewIterator(reverseIteratorRange(start, prefix), nil),
+		reverse:  true,
+	}
+}
+
+// iteratorRange returns the range of keys that start at [start] and have the
+// provided [prefix].
+func iteratorRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
+
+// reverseIteratorRange returns the range of keys that are less than or equal
+// to [start] and have the provided [prefix].
+func reverseIteratorRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if len(start) == 0 {
+		return iterRange
+	}
+
+	// The limit is exclusive, so it is set to the smallest key after [start].
+	limit := make([]byte, len(start)+1)
+	copy(limit, start)
+	if iterRange.Limit == nil || bytes.Compare(limit, iterRange.Limit) == -1 {
+		iterRange.Limit = limit
+	}
+	return iterRange
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -56,6 +56,13 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
 	database.FuzzNewIteratorWithStartAndPrefix(f, db)
 }
 
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	db := newDB(f)
+	defer db.Close()
+
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, db)
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
@@ -5,6 +5,7 @@ package memdb
 
 import (
 	"context"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -21,9 +22,13 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database        = (*Database)(nil)
+	_ database.ReverseIteratee = (*Database)(nil)
+	_ database.Snapshotter     = (*Database)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Iterator        = (*iterator)(nil)
+	_ database.Snapshot        = (*snapshot)(nil)
+	_ database.ReverseIteratee = (*snapshot)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -133,25 +138,46 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 			Err: database.ErrClosed,
 		}
 	}
+	return newIterator(db, db.db, start, prefix, false)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.db))
-	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
 		}
 	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([][]byte, 0, len(keys))
-	for _, key := range keys {
-		values = append(values, db.db[key])
-	}
-	return &iterator{
-		db:     db,
-		keys:   keys,
-		values: values,
+	return newIterator(db, db.db, start, prefix, true)
+}
+
+// NewSnapshot returns a copy of the current contents of the database. Values
+// are never modified in place, so only the key index is copied.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
 	}
+	return &snapshot{
+		db:   db,
+		data: maps.Clone(db.db),
+	}, nil
 }
 
 func (db *Database) Compact(_, _ []byte) error {
@@ -171,6 +197,41 @@ func (db *Database) HealthCheck(context.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// newIterator returns an iterator over the entries of [data]. If [reverse] is
+// true, the keys are returned in descending order. Assumes that [data] will not
+// be modified while the iterator is being created.
+func newIterator(db *Database, data map[string][]byte, start, prefix []byte, reverse bool) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if !strings.HasPrefix(key, prefixString) {
+			continue
+		}
+		// An empty [start] doesn't bound reverse iteration.
+		inRange := key >= startString
+		if reverse {
+			inRange = len(start) == 0 || key <= startString
+		}
+		if inRange {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys) // Keys need to be in sorted order
+	if reverse {
+		slices.Reverse(keys)
+	}
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, data[key])
+	}
+	return &iterator{
+		db:     db,
+		keys:   keys,
+		values: values,
+	}
+}
+
 type batch struct {
 	database.BatchOps
 
@@ -253,3 +314,88 @@ func (it *iterator) Release() {
 	it.keys = nil
 	it.values = nil
 }
+
+type snapshot struct {
+	lock sync.RWMutex
+	db   *Database
+	data map[string][]byte
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	_, ok := s.data[string(key)]
+	return ok, nil
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if entry, ok := s.data[string(key)]; ok {
+		return slices.Clone(entry), nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return newIterator(s.db, s.data, start, prefix, false)
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.data == nil || s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return newIterator(s.db, s.data, start, prefix, true)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.data = nil
+}
@@ -13,9 +13,14 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database           = (*Database)(nil)
+	_ database.ReverseIteratee    = (*Database)(nil)
+	_ database.Snapshotter        = (*Database)(nil)
+	_ database.DiskUsageEstimator = (*Database)(nil)
+	_ database.Batch              = (*batch)(nil)
+	_ database.Iterator           = (*iterator)(nil)
+	_ database.Snapshot           = (*snapshot)(nil)
+	_ database.ReverseIteratee    = (*snapshot)(nil)
 )
 
 // Database tracks the amount of time each operation takes and how many bytes
@@ -116,6 +121,46 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: database.NewReverseIteratorWithStartAndPrefix(db.db, start, prefix),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	snap, err := database.NewSnapshot(db.db)
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		snapshot: snap,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	startTime := db.clock.Time()
 	err := db.db.Compact(start, limit)
@@ -124,6 +169,10 @@ func (db *Database) Compact(start, limit []byte) error {
 	return err
 }
 
+func (db *Database) EstimateDiskUsage(prefix []byte) (uint64, error) {
+	return database.EstimateDiskUsage(db.db, prefix)
+}
+
 func (db *Database) Close() error {
 	start := db.clock.Time()
 	err := db.db.Close()
@@ -205,6 +254,87 @@ func (b *batch) Inner() database.Batch {
 	return inner
 }
 
+type snapshot struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.readSize.Observe(float64(len(key)))
+	s.db.has.Observe(float64(end.Sub(start)))
+	s.db.hasSize.Observe(float64(len(key)))
+	return has, err
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.readSize.Observe(float64(len(key) + len(value)))
+	s.db.get.Observe(float64(end.Sub(start)))
+	s.db.getSize.Observe(float64(len(key) + len(value)))
+	return value, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: database.NewReverseIteratorWithStartAndPrefix(s.snapshot, start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snapshot) Release() {
+	s.snapshot.Release()
+}
+
 type iterator struct {
 	iterator database.Iterator
 	db       *Database
@@ -45,6 +45,10 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
 	database.FuzzNewIteratorWithStartAndPrefix(f, newDB(f))
 }
 
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, newDB(f))
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
@@ -41,6 +41,7 @@ type metrics struct {
 	delete, deleteSize,
 	newBatch,
 	newIterator,
+	newSnapshot,
 	compact,
 	close,
 	healthCheck,
@@ -73,6 +74,7 @@ func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
 		deleteSize:  newSizeMetric(namespace, "delete", reg, &errs),
 		newBatch:    newTimeMetric(namespace, "new_batch", reg, &errs),
 		newIterator: newTimeMetric(namespace, "new_iterator", reg, &errs),
+		newSnapshot: newTimeMetric(namespace, "new_snapshot", reg, &errs),
 		compact:     newTimeMetric(namespace, "compact", reg, &errs),
 		close:       newTimeMetric(namespace, "close", reg, &errs),
 		healthCheck: newTimeMetric(namespace, "health_check", reg, &errs),
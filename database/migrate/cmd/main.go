@@ -0,0 +1,344 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/leveldb"
+	"github.com/MetalBlockchain/metalgo/database/migrate"
+	"github.com/MetalBlockchain/metalgo/database/pebble"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/version"
+)
+
+const (
+	dbDirKey             = "db-dir"
+	srcDBTypeKey         = "src-db-type"
+	srcDBConfigFileKey   = "src-db-config-file"
+	dstDBTypeKey         = "dst-db-type"
+	dstDBConfigFileKey   = "dst-db-config-file"
+	batchSizeKey         = "batch-size"
+	skipVerificationKey  = "skip-verification"
+	progressFrequencyKey = "progress-frequency"
+
+	verifyReportFrequency = 100_000
+)
+
+var (
+	errDBDirRequired   = fmt.Errorf("--%s is required", dbDirKey)
+	errSameDBType      = errors.New("source and destination database types must differ")
+	errUnknownDBType   = errors.New("unknown database type")
+	errAlreadyMigrated = errors.New("destination database was already migrated")
+)
+
+type config struct {
+	dbDir             string
+	srcType           string
+	srcConfigFile     string
+	dstType           string
+	dstConfigFile     string
+	batchSize         int
+	skipVerification  bool
+	progressFrequency time.Duration
+}
+
+// checkpoint is persisted next to the destination database so that an
+// interrupted migration can be resumed.
+type checkpoint struct {
+	Progress migrate.Progress `json:"progress"`
+	Done     bool             `json:"done"`
+}
+
+func main() {
+	var c config
+	rootCmd := &cobra.Command{
+		Use:   "dbmigrate",
+		Short: "Copies a node's database from one backend to another",
+		Long: `Copies every key/value pair of a stopped node's database from one backend
+into another. The databases are located in --db-dir using the same layout as the
+node, so after a successful migration the node can be restarted with
+--db-type set to the destination type.
+
+If the migration is interrupted, re-running the same command resumes from the
+last written batch.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return run(cmd.Context(), c, false /*=verifyOnly*/)
+		},
+	}
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&c.dbDir, dbDirKey, "", "Database directory of the node, including the network name (e.g. ~/.metalgo/db/mainnet)")
+	flags.StringVar(&c.srcType, srcDBTypeKey, leveldb.Name, fmt.Sprintf("Database type to migrate from. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	flags.StringVar(&c.srcConfigFile, srcDBConfigFileKey, "", "Path to the config file of the source database")
+	flags.StringVar(&c.dstType, dstDBTypeKey, pebble.Name, fmt.Sprintf("Database type to migrate to. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	flags.StringVar(&c.dstConfigFile, dstDBConfigFileKey, "", "Path to the config file of the destination database")
+	flags.IntVar(&c.batchSize, batchSizeKey, migrate.DefaultBatchSize, "Number of bytes to buffer before writing to the destination database")
+	flags.BoolVar(&c.skipVerification, skipVerificationKey, false, "If true, the databases will not be compared after the migration")
+	flags.DurationVar(&c.progressFrequency, progressFrequencyKey, 10*time.Second, "Frequency to report progress")
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies that the source and destination databases contain the same key/value pairs",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return run(cmd.Context(), c, true /*=verifyOnly*/)
+		},
+	}
+	rootCmd.AddCommand(verifyCmd)
+
+	// Interrupting the migration stops it after the current batch is written,
+	// so that it can be resumed later.
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	cancel()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dbmigrate failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, c config, verifyOnly bool) error {
+	switch {
+	case c.dbDir == "":
+		return errDBDirRequired
+	case c.srcType == c.dstType:
+		return errSameDBType
+	}
+
+	log := logging.NewLogger(
+		"dbmigrate",
+		logging.NewWrappedCore(
+			logging.Info,
+			os.Stdout,
+			logging.Colors.ConsoleEncoder(),
+		),
+	)
+
+	srcPath, err := dbPath(c.dbDir, c.srcType)
+	if err != nil {
+		return err
+	}
+	// Opening a database that doesn't exist would silently create an empty
+	// one.
+	if _, err := os.Stat(srcPath); err != nil {
+		return fmt.Errorf("couldn't find source database: %w", err)
+	}
+	src, err := openDB(log, srcPath, c.srcType, c.srcConfigFile)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dstPath, err := dbPath(c.dbDir, c.dstType)
+	if err != nil {
+		return err
+	}
+	dst, err := openDB(log, dstPath, c.dstType, c.dstConfigFile)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	checkpointPath := filepath.Join(c.dbDir, c.dstType+"-migration.json")
+	if !verifyOnly {
+		if err := copyDB(ctx, log, c, src, dst, checkpointPath); err != nil {
+			return err
+		}
+	}
+	if verifyOnly || !c.skipVerification {
+		return verifyDB(ctx, log, c, src, dst)
+	}
+	return nil
+}
+
+func copyDB(
+	ctx context.Context,
+	log logging.Logger,
+	c config,
+	src database.Database,
+	dst database.Database,
+	checkpointPath string,
+) error {
+	cp, err := readCheckpoint(checkpointPath)
+	if err != nil {
+		return err
+	}
+	if cp.Done {
+		return fmt.Errorf("%w: remove %s to migrate again", errAlreadyMigrated, checkpointPath)
+	}
+	if cp.Progress.LastKey != nil {
+		log.Info("resuming migration",
+			zap.Uint64("keys", cp.Progress.Keys),
+			zap.Uint64("bytes", cp.Progress.Bytes),
+		)
+	} else {
+		log.Info("starting migration",
+			zap.String("from", c.srcType),
+			zap.String("to", c.dstType),
+		)
+	}
+
+	var (
+		startTime      = time.Now()
+		lastReportTime = startTime
+	)
+	progress, err := migrate.Copy(
+		ctx,
+		src,
+		dst,
+		cp.Progress,
+		c.batchSize,
+		func(progress migrate.Progress) error {
+			if err := writeCheckpoint(checkpointPath, checkpoint{Progress: progress}); err != nil {
+				return err
+			}
+			if now := time.Now(); now.Sub(lastReportTime) >= c.progressFrequency {
+				lastReportTime = now
+				log.Info("migrating",
+					zap.Uint64("keys", progress.Keys),
+					zap.Uint64("bytes", progress.Bytes),
+					zap.String("percent", fmt.Sprintf("%.2f%%", progress.Percent())),
+				)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("migration interrupted after %d keys: %w", progress.Keys, err)
+	}
+
+	log.Info("finished migration",
+		zap.Uint64("keys", progress.Keys),
+		zap.Uint64("bytes", progress.Bytes),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return writeCheckpoint(checkpointPath, checkpoint{
+		Progress: progress,
+		Done:     true,
+	})
+}
+
+func verifyDB(
+	ctx context.Context,
+	log logging.Logger,
+	c config,
+	src database.Database,
+	dst database.Database,
+) error {
+	log.Info("starting verification")
+
+	var (
+		startTime      = time.Now()
+		lastReportTime = startTime
+	)
+	progress, err := migrate.Verify(
+		ctx,
+		src,
+		dst,
+		verifyReportFrequency,
+		func(progress migrate.Progress) error {
+			if now := time.Now(); now.Sub(lastReportTime) >= c.progressFrequency {
+				lastReportTime = now
+				log.Info("verifying",
+					zap.Uint64("keys", progress.Keys),
+					zap.Uint64("bytes", progress.Bytes),
+					zap.String("percent", fmt.Sprintf("%.2f%%", progress.Percent())),
+				)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("verification failed after %d keys: %w", progress.Keys, err)
+	}
+
+	log.Info("finished verification",
+		zap.Uint64("keys", progress.Keys),
+		zap.Uint64("bytes", progress.Bytes),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// dbPath returns the path of the database of type [dbType] using the same
+// directory layout as the node.
+func dbPath(dbDir, dbType string) (string, error) {
+	switch dbType {
+	case leveldb.Name:
+		return filepath.Join(dbDir, version.CurrentDatabase.String()), nil
+	case pebble.Name:
+		return filepath.Join(dbDir, pebble.Name), nil
+	default:
+		return "", fmt.Errorf("%w: %q", errUnknownDBType, dbType)
+	}
+}
+
+func openDB(log logging.Logger, dbPath, dbType, configFile string) (database.Database, error) {
+	var configBytes []byte
+	if configFile != "" {
+		var err error
+		configBytes, err = os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read %s config file: %w", dbType, err)
+		}
+	}
+
+	switch dbType {
+	case leveldb.Name:
+		db, err := leveldb.New(dbPath, configBytes, log, "", prometheus.NewRegistry())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create leveldb at %s: %w", dbPath, err)
+		}
+		return db, nil
+	case pebble.Name:
+		db, err := pebble.New(dbPath, configBytes, log, "", prometheus.NewRegistry())
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create pebbledb at %s: %w", dbPath, err)
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownDBType, dbType)
+	}
+}
+
+func readCheckpoint(path string) (checkpoint, error) {
+	var cp checkpoint
+	cpBytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	return cp, json.Unmarshal(cpBytes, &cp)
+}
+
+func writeCheckpoint(path string, cp checkpoint) error {
+	cpBytes, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that an interruption never leaves a
+	// partially written checkpoint behind.
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, cpBytes, perms.ReadWrite); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migrate copies the contents of one database into another and
+// verifies that the two databases contain the same key/value pairs.
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+// DefaultBatchSize is the default number of bytes to buffer before writing
+// them to the destination database.
+const DefaultBatchSize = 4 * 1024 * 1024 // 4 MiB
+
+var (
+	ErrMissingKey    = errors.New("missing key")
+	ErrUnexpectedKey = errors.New("unexpected key")
+	ErrValueMismatch = errors.New("value mismatch")
+)
+
+// Progress describes how much of the source database has been processed.
+type Progress struct {
+	// Keys is the number of key/value pairs processed.
+	Keys uint64 `json:"keys"`
+	// Bytes is the number of key and value bytes processed.
+	Bytes uint64 `json:"bytes"`
+	// LastKey is the last key that was processed. Keys are processed in
+	// binary-alphabetical order.
+	LastKey []byte `json:"lastKey"`
+}
+
+// Percent estimates how much of the keyspace has been processed by
+// interpreting the first bytes of [LastKey] as a position in the keyspace.
+//
+// The estimate is accurate when keys are uniformly distributed, which is the
+// case for databases partitioned with prefixdb.
+func (p Progress) Percent() float64 {
+	var position [8]byte
+	copy(position[:], p.LastKey)
+	return 100 * float64(binary.BigEndian.Uint64(position[:])) / (1 << 64)
+}
+
+// ResumeKey returns the first key that should be processed to resume from
+// this progress.
+func (p Progress) ResumeKey() []byte {
+	if p.LastKey == nil {
+		return nil
+	}
+	// The smallest key after [LastKey] is [LastKey] followed by a 0 byte.
+	return append(slices.Clone(p.LastKey), 0)
+}
+
+// Copy writes every key/value pair in [src] that is greater than or equal to
+// [progress.ResumeKey] into [dst].
+//
+// Writes are buffered into batches of approximately [batchSize] bytes. After
+// each batch has been written, [onBatch] is called with the updated progress.
+// If the copy is interrupted, it can be resumed by passing in the last
+// reported progress.
+func Copy(
+	ctx context.Context,
+	src database.Iteratee,
+	dst database.Batcher,
+	progress Progress,
+	batchSize int,
+	onBatch func(Progress) error,
+) (Progress, error) {
+	it := src.NewIteratorWithStart(progress.ResumeKey())
+	defer it.Release()
+
+	batch := dst.NewBatch()
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		if err := batch.Put(key, value); err != nil {
+			return progress, err
+		}
+
+		progress.Keys++
+		progress.Bytes += uint64(len(key) + len(value))
+		progress.LastKey = slices.Clone(key)
+
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := writeBatch(batch, progress, onBatch); err != nil {
+			return progress, err
+		}
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return progress, err
+	}
+	return progress, writeBatch(batch, progress, onBatch)
+}
+
+func writeBatch(batch database.Batch, progress Progress, onBatch func(Progress) error) error {
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
+	return onBatch(progress)
+}
+
+// Verify ensures that [expected] and [actual] contain exactly the same
+// key/value pairs.
+//
+// [onProgress] is called after every [reportFrequency] key/value pairs have
+// been compared.
+func Verify(
+	ctx context.Context,
+	expected database.Iteratee,
+	actual database.Iteratee,
+	reportFrequency uint64,
+	onProgress func(Progress) error,
+) (Progress, error) {
+	expectedIt := expected.NewIterator()
+	defer expectedIt.Release()
+
+	actualIt := actual.NewIterator()
+	defer actualIt.Release()
+
+	var progress Progress
+	for {
+		hasExpected := expectedIt.Next()
+		hasActual := actualIt.Next()
+		if !hasExpected || !hasActual {
+			if err := expectedIt.Error(); err != nil {
+				return progress, err
+			}
+			if err := actualIt.Error(); err != nil {
+				return progress, err
+			}
+		}
+
+		switch {
+		case !hasExpected && !hasActual:
+			return progress, onProgress(progress)
+		case !hasActual:
+			return progress, fmt.Errorf("%w: 0x%x", ErrMissingKey, expectedIt.Key())
+		case !hasExpected:
+			return progress, fmt.Errorf("%w: 0x%x", ErrUnexpectedKey, actualIt.Key())
+		}
+
+		key := expectedIt.Key()
+		switch cmp := bytes.Compare(key, actualIt.Key()); {
+		case cmp < 0:
+			return progress, fmt.Errorf("%w: 0x%x", ErrMissingKey, key)
+		case cmp > 0:
+			return progress, fmt.Errorf("%w: 0x%x", ErrUnexpectedKey, actualIt.Key())
+		}
+
+		value := expectedIt.Value()
+		if !bytes.Equal(value, actualIt.Value()) {
+			return progress, fmt.Errorf("%w: 0x%x", ErrValueMismatch, key)
+		}
+
+		progress.Keys++
+		progress.Bytes += uint64(len(key) + len(value))
+		progress.LastKey = slices.Clone(key)
+
+		if progress.Keys%reportFrequency != 0 {
+			continue
+		}
+		if err := onProgress(progress); err != nil {
+			return progress, err
+		}
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+	}
+}
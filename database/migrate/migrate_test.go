@@ -0,0 +1,168 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+var errTest = errors.New("non-nil error")
+
+func newTestDB(t *testing.T, numKeys int) database.Database {
+	db := memdb.New()
+	for i := 0; i < numKeys; i++ {
+		require.NoError(t, db.Put(utils.RandomBytes(32), utils.RandomBytes(64)))
+	}
+	return db
+}
+
+func TestCopy(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestDB(t, 1_000)
+	dst := memdb.New()
+
+	var numBatches int
+	progress, err := Copy(
+		context.Background(),
+		src,
+		dst,
+		Progress{},
+		1024,
+		func(Progress) error {
+			numBatches++
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.Equal(uint64(1_000), progress.Keys)
+	require.Equal(uint64(1_000*(32+64)), progress.Bytes)
+	require.Greater(numBatches, 1)
+
+	_, err = Verify(context.Background(), src, dst, 1, func(Progress) error {
+		return nil
+	})
+	require.NoError(err)
+}
+
+func TestCopyResume(t *testing.T) {
+	require := require.New(t)
+
+	src := newTestDB(t, 1_000)
+	dst := memdb.New()
+
+	// Interrupt the copy after the first batch was written.
+	var lastProgress Progress
+	_, err := Copy(
+		context.Background(),
+		src,
+		dst,
+		Progress{},
+		1024,
+		func(progress Progress) error {
+			lastProgress = progress
+			return errTest
+		},
+	)
+	require.ErrorIs(err, errTest)
+	require.NotZero(lastProgress.Keys)
+
+	numCopied, err := database.Count(dst)
+	require.NoError(err)
+	require.Equal(lastProgress.Keys, uint64(numCopied))
+
+	progress, err := Copy(
+		context.Background(),
+		src,
+		dst,
+		lastProgress,
+		1024,
+		func(Progress) error {
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.Equal(uint64(1_000), progress.Keys)
+
+	_, err = Verify(context.Background(), src, dst, 1, func(Progress) error {
+		return nil
+	})
+	require.NoError(err)
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*require.Assertions, database.Database, []byte)
+		expectedErr error
+	}{
+		{
+			name:        "equal",
+			modify:      func(*require.Assertions, database.Database, []byte) {},
+			expectedErr: nil,
+		},
+		{
+			name: "missing key",
+			modify: func(require *require.Assertions, db database.Database, key []byte) {
+				require.NoError(db.Delete(key))
+			},
+			expectedErr: ErrMissingKey,
+		},
+		{
+			name: "unexpected key",
+			modify: func(require *require.Assertions, db database.Database, key []byte) {
+				require.NoError(db.Put(append(key, 0), nil))
+			},
+			expectedErr: ErrUnexpectedKey,
+		},
+		{
+			name: "value mismatch",
+			modify: func(require *require.Assertions, db database.Database, key []byte) {
+				require.NoError(db.Put(key, []byte("mismatch")))
+			},
+			expectedErr: ErrValueMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			src := newTestDB(t, 100)
+			dst := memdb.New()
+
+			_, err := Copy(context.Background(), src, dst, Progress{}, 1024, func(Progress) error {
+				return nil
+			})
+			require.NoError(err)
+
+			it := src.NewIterator()
+			require.True(it.Next())
+			key := it.Key()
+			it.Release()
+
+			test.modify(require, dst, key)
+
+			_, err = Verify(context.Background(), src, dst, 1, func(Progress) error {
+				return nil
+			})
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestProgressPercent(t *testing.T) {
+	require := require.New(t)
+
+	require.Zero(Progress{}.Percent())
+	require.InDelta(50, Progress{LastKey: []byte{0x80}}.Percent(), 0.001)
+	require.InDelta(100, Progress{LastKey: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}}.Percent(), 0.001)
+}
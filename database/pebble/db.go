@@ -30,7 +30,10 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
+	_ database.Database           = (*Database)(nil)
+	_ database.ReverseIteratee    = (*Database)(nil)
+	_ database.Snapshotter        = (*Database)(nil)
+	_ database.DiskUsageEstimator = (*Database)(nil)
 
 	errInvalidOperation = errors.New("invalid operation")
 
@@ -61,6 +64,7 @@ type Database struct {
 	pebbleDB      *pebble.DB
 	closed        bool
 	openIterators set.Set[*iter]
+	openSnapshots set.Set[*snapshot]
 }
 
 type Config struct {
@@ -103,9 +107,44 @@ func New(file string, configBytes []byte, log logging.Logger, _ string, _ promet
 	return &Database{
 		pebbleDB:      db,
 		openIterators: set.Set[*iter]{},
+		openSnapshots: set.Set[*snapshot]{},
 	}, err
 }
 
+func (db *Database) EstimateDiskUsage(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+
+	start := prefix
+	end := prefixToUpperBound(prefix)
+	if end == nil {
+		// A nil [end] is treated as a key before all keys, so the greatest key
+		// in the range is used as [end] instead.
+		it := db.pebbleDB.NewIter(&pebble.IterOptions{
+			LowerBound: prefix,
+		})
+		if !it.Last() {
+			// There are no keys in the range.
+			return 0, it.Close()
+		}
+
+		end = slices.Clone(it.Key())
+		if err := it.Close(); err != nil {
+			return 0, err
+		}
+	}
+	if start == nil {
+		start = []byte{}
+	}
+
+	size, err := db.pebbleDB.EstimateDiskUsage(start, end)
+	return size, updateError(err)
+}
+
 func (db *Database) Close() error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -123,6 +162,14 @@ func (db *Database) Close() error {
 	}
 	db.openIterators.Clear()
 
+	// Snapshots must be closed after any iterators that reference them.
+	for snapshot := range db.openSnapshots {
+		snapshot.lock.Lock()
+		snapshot.release()
+		snapshot.lock.Unlock()
+	}
+	db.openSnapshots.Clear()
+
 	return updateError(db.pebbleDB.Close())
 }
 
@@ -256,6 +303,55 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return iter
 }
 
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return &iter{
+			db:     db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	iter := &iter{
+		db:      db,
+		iter:    db.pebbleDB.NewIter(reverseKeyRange(start, prefix)),
+		reverse: true,
+	}
+	db.openIterators.Add(iter)
+	return iter
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	snapshot := &snapshot{
+		db:       db,
+		snapshot: db.pebbleDB.NewSnapshot(),
+	}
+	db.openSnapshots.Add(snapshot)
+	return snapshot, nil
+}
+
 // Converts a pebble-specific error to its Avalanche equivalent, if applicable.
 func updateError(err error) error {
 	switch err {
@@ -279,6 +375,25 @@ func keyRange(start, prefix []byte) *pebble.IterOptions {
 	return opt
 }
 
+func reverseKeyRange(start, prefix []byte) *pebble.IterOptions {
+	opt := &pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixToUpperBound(prefix),
+	}
+	if len(start) == 0 {
+		return opt
+	}
+
+	// The upper bound is exclusive, so it is set to the smallest key after
+	// [start].
+	upperBound := make([]byte, len(start)+1)
+	copy(upperBound, start)
+	if opt.UpperBound == nil || bytes.Compare(upperBound, opt.UpperBound) == -1 {
+		opt.UpperBound = upperBound
+	}
+	return opt
+}
+
 // Returns an upper bound that stops after all keys with the given [prefix].
 // Assumes the Database uses bytes.Compare for key comparison and not a custom
 // comparer.
@@ -49,6 +49,12 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
 	_ = db.Close()
 }
 
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	db := newDB(f)
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, db)
+	_ = db.Close()
+}
+
 func BenchmarkInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
@@ -30,6 +30,8 @@ type iter struct {
 	db   *Database
 	iter *pebble.Iterator
 
+	// reverse is true if the iterator moves from the last key to the first.
+	reverse     bool
 	initialized bool
 	closed      bool
 	err         error
@@ -52,9 +54,14 @@ func (it *iter) Next() bool {
 		it.hasNext = false
 		it.err = database.ErrClosed
 		return false
+	case !it.initialized && it.reverse:
+		it.hasNext = it.iter.Last()
+		it.initialized = true
 	case !it.initialized:
 		it.hasNext = it.iter.First()
 		it.initialized = true
+	case it.reverse:
+		it.hasNext = it.iter.Prev()
 	default:
 		it.hasNext = it.iter.Next()
 	}
@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+var (
+	_ database.Snapshot        = (*snapshot)(nil)
+	_ database.ReverseIteratee = (*snapshot)(nil)
+)
+
+type snapshot struct {
+	// [lock] ensures that [release] isn't executed concurrently with a read
+	// of [snapshot].
+	// Invariant: [Database.lock] is never grabbed while holding [lock].
+	lock sync.RWMutex
+
+	db       *Database
+	snapshot *pebble.Snapshot
+	closed   bool
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return false, database.ErrClosed
+	}
+
+	_, closer, err := s.snapshot.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return nil, database.ErrClosed
+	}
+
+	data, closer, err := s.snapshot.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return slices.Clone(data), closer.Close()
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// Must not be called with [s.lock] held.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return &iter{
+			db:     s.db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	iter := &iter{
+		db:   s.db,
+		iter: s.snapshot.NewIter(keyRange(start, prefix)),
+	}
+	s.db.openIterators.Add(iter)
+	return iter
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// Must not be called with [s.lock] held.
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return &iter{
+			db:     s.db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	iter := &iter{
+		db:      s.db,
+		iter:    s.snapshot.NewIter(reverseKeyRange(start, prefix)),
+		reverse: true,
+	}
+	s.db.openIterators.Add(iter)
+	return iter
+}
+
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.release()
+}
+
+// Assumes [s.lock] and [s.db.lock] are held.
+func (s *snapshot) release() {
+	if s.closed {
+		return
+	}
+
+	// Remove the snapshot from the list of open snapshots.
+	s.db.openSnapshots.Remove(s)
+
+	s.closed = true
+	_ = s.snapshot.Close()
+}
@@ -17,9 +17,14 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database           = (*Database)(nil)
+	_ database.ReverseIteratee    = (*Database)(nil)
+	_ database.Snapshotter        = (*Database)(nil)
+	_ database.DiskUsageEstimator = (*Database)(nil)
+	_ database.Batch              = (*batch)(nil)
+	_ database.Iterator           = (*iterator)(nil)
+	_ database.Snapshot           = (*snapshot)(nil)
+	_ database.ReverseIteratee    = (*snapshot)(nil)
 )
 
 // Database partitions a database into a sub-database by prefixing all keys with
@@ -36,6 +41,10 @@ type Database struct {
 	// The underlying storage
 	db     database.Database
 	closed bool
+
+	// onNew, if non-nil, is called when a database is created from this
+	// database with New.
+	onNew func(prefix, dbPrefix []byte)
 }
 
 func newDB(prefix []byte, db database.Database) *Database {
@@ -53,8 +62,12 @@ func newDB(prefix []byte, db database.Database) *Database {
 // New returns a new prefixed database
 func New(prefix []byte, db database.Database) *Database {
 	if prefixDB, ok := db.(*Database); ok {
+		dbPrefix := JoinPrefixes(prefixDB.dbPrefix, prefix)
+		if prefixDB.onNew != nil {
+			prefixDB.onNew(prefix, dbPrefix)
+		}
 		return newDB(
-			JoinPrefixes(prefixDB.dbPrefix, prefix),
+			dbPrefix,
 			prefixDB.db,
 		)
 	}
@@ -64,6 +77,16 @@ func New(prefix []byte, db database.Database) *Database {
 	)
 }
 
+// NewObserved returns a new prefixed database that calls [onNew] whenever a
+// database is created from it with New. [onNew] is provided the prefix passed
+// to New and the prefix of the keys of the new database in the underlying
+// database. Databases created from the new database aren't observed.
+func NewObserved(prefix []byte, db database.Database, onNew func(prefix, dbPrefix []byte)) *Database {
+	prefixDB := New(prefix, db)
+	prefixDB.onNew = onNew
+	return prefixDB
+}
+
 // NewNested returns a new prefixed database without attempting to compress
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
@@ -91,6 +114,12 @@ func PrefixKey(prefix, key []byte) []byte {
 	return prefixedKey
 }
 
+// Prefix returns the prefix of the keys of this database in the underlying
+// database.
+func (db *Database) Prefix() []byte {
+	return slices.Clone(db.dbPrefix)
+}
+
 // Assumes that it is OK for the argument to db.db.Has
 // to be modified after db.db.Has returns
 // [key] may be modified after this method returns.
@@ -197,6 +226,78 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+//
+// Returns an iterator reporting [database.ErrReverseIterationNotSupported] if
+// the underlying database doesn't support reverse iteration.
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return db.newReverseIterator(db.db, start, prefix)
+}
+
+// newReverseIterator returns a reverse iterator over [iteratee] that strips
+// this db's prefix from the returned keys.
+//
+// Assumes it is safe to modify the arguments to
+// iteratee.NewReverseIteratorWithStartAndPrefix after it returns.
+func (db *Database) newReverseIterator(iteratee database.Iteratee, start, prefix []byte) database.Iterator {
+	// An empty start means that reverse iteration should begin at the last key
+	// with the prefix, so it must not be replaced with this db's prefix.
+	var prefixedStart []byte
+	if len(start) != 0 {
+		prefixedStart = db.prefix(start)
+		defer db.bufferPool.Put(prefixedStart)
+	}
+	prefixedPrefix := db.prefix(prefix)
+	defer db.bufferPool.Put(prefixedPrefix)
+
+	return &iterator{
+		Iterator: database.NewReverseIteratorWithStartAndPrefix(iteratee, prefixedStart, prefixedPrefix),
+		db:       db,
+	}
+}
+
+// NewSnapshot returns a snapshot of the underlying database that only exposes
+// the keys of this prefixed database.
+//
+// Returns [database.ErrSnapshotNotSupported] if the underlying database
+// doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	snap, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -207,6 +308,19 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(db.prefix(start), db.prefix(limit))
 }
 
+func (db *Database) EstimateDiskUsage(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+	prefixedPrefix := db.prefix(prefix)
+	size, err := database.EstimateDiskUsage(db.db, prefixedPrefix)
+	db.bufferPool.Put(prefixedPrefix)
+	return size, err
+}
+
 func (db *Database) Close() error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -342,6 +456,91 @@ func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
 	return nil
 }
 
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// Assumes that it is OK for the argument to s.Snapshot.Has
+// to be modified after s.Snapshot.Has returns
+// [key] may be modified after this method returns.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// Assumes that it is OK for the argument to s.Snapshot.Get
+// to be modified after s.Snapshot.Get returns.
+// [key] may be modified after this method returns.
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// Assumes it is safe to modify the arguments to
+// s.Snapshot.NewIteratorWithStartAndPrefix after it returns.
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return s.db.newReverseIterator(s.Snapshot, start, prefix)
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
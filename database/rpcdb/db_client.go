@@ -18,9 +18,10 @@ import (
 )
 
 var (
-	_ database.Database = (*DatabaseClient)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database        = (*DatabaseClient)(nil)
+	_ database.ReverseIteratee = (*DatabaseClient)(nil)
+	_ database.Batch           = (*batch)(nil)
+	_ database.Iterator        = (*iterator)(nil)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -111,6 +112,33 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	return newIterator(db, resp.Id)
 }
 
+func (db *DatabaseClient) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *DatabaseClient) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *DatabaseClient) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewReverseIteratorWithStartAndPrefix returns a new empty reverse iterator
+func (db *DatabaseClient) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbpb.NewIteratorWithStartAndPrefixRequest{
+		Start:   start,
+		Prefix:  prefix,
+		Reverse: true,
+	})
+	if err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	return newIterator(db, resp.Id)
+}
+
 // Compact attempts to optimize the space utilization in the provided range
 func (db *DatabaseClient) Compact(start, limit []byte) error {
 	resp, err := db.client.Compact(context.Background(), &rpcdbpb.CompactRequest{
@@ -130,7 +130,12 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbpb.WriteBatchR
 // NewIteratorWithStartAndPrefix allocates an iterator and returns the iterator
 // ID
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbpb.NewIteratorWithStartAndPrefixRequest) (*rpcdbpb.NewIteratorWithStartAndPrefixResponse, error) {
-	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	var it database.Iterator
+	if req.Reverse {
+		it = database.NewReverseIteratorWithStartAndPrefix(db.db, req.Start, req.Prefix)
+	} else {
+		it = db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	}
 
 	db.iteratorLock.Lock()
 	defer db.iteratorLock.Unlock()
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import "errors"
+
+var ErrSnapshotNotSupported = errors.New("snapshot not supported")
+
+// Snapshot is a read-only view of a database frozen at the time it was
+// created. Writes performed on the database after the snapshot was created are
+// not visible through the snapshot.
+//
+// A snapshot must be released after use. Once released, or once the
+// underlying database has been closed, all reads return [ErrClosed].
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed and
+	// can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a consistent, point-in-time view of the database.
+	//
+	// Returns [ErrSnapshotNotSupported] if the backing data store is unable
+	// to provide a snapshot.
+	NewSnapshot() (Snapshot, error)
+}
+
+// NewSnapshot returns a snapshot of [db] if it implements [Snapshotter].
+// Otherwise [ErrSnapshotNotSupported] is returned.
+func NewSnapshot(db Database) (Snapshot, error) {
+	s, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+	return s.NewSnapshot()
+}
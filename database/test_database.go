@@ -46,6 +46,11 @@ var Tests = map[string]func(t *testing.T, db Database){
 	"IteratorClosed":                   TestIteratorClosed,
 	"IteratorError":                    TestIteratorError,
 	"IteratorErrorAfterRelease":        TestIteratorErrorAfterRelease,
+	"ReverseIterator":                  TestReverseIterator,
+	"ReverseIteratorStart":             TestReverseIteratorStart,
+	"ReverseIteratorPrefix":            TestReverseIteratorPrefix,
+	"ReverseIteratorStartPrefix":       TestReverseIteratorStartPrefix,
+	"ReverseIteratorClosed":            TestReverseIteratorClosed,
 	"CompactNoPanic":                   TestCompactNoPanic,
 	"MemorySafetyDatabase":             TestMemorySafetyDatabase,
 	"MemorySafetyBatch":                TestMemorySafetyBatch,
@@ -59,6 +64,10 @@ var Tests = map[string]func(t *testing.T, db Database){
 	"ConcurrentBatches":                TestConcurrentBatches,
 	"ManySmallConcurrentKVPairBatches": TestManySmallConcurrentKVPairBatches,
 	"PutGetEmpty":                      TestPutGetEmpty,
+	"Snapshot":                         TestSnapshot,
+	"SnapshotIterator":                 TestSnapshotIterator,
+	"SnapshotRelease":                  TestSnapshotRelease,
+	"SnapshotClosed":                   TestSnapshotClosed,
 }
 
 // TestSimpleKeyValue tests to make sure that simple Put + Get + Delete + Has
@@ -913,6 +922,375 @@ func TestIteratorErrorAfterRelease(t *testing.T, db Database) {
 	require.Equal(ErrClosed, iterator.Error())
 }
 
+// newTestReverseIteratee returns [db] as a [ReverseIteratee] or skips the test
+// if [db] doesn't support reverse iteration.
+func newTestReverseIteratee(t *testing.T, db Database) ReverseIteratee {
+	reverseIteratee, ok := db.(ReverseIteratee)
+	if !ok {
+		t.Skip("database doesn't support reverse iteration")
+	}
+	return reverseIteratee
+}
+
+// TestReverseIterator tests to make sure the database iterates over the
+// database contents in reverse lexicographical order.
+func TestReverseIterator(t *testing.T, db Database) {
+	require := require.New(t)
+
+	reverseIteratee := newTestReverseIteratee(t, db)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	iterator := reverseIteratee.NewReverseIterator()
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+}
+
+// TestReverseIteratorStart tests to make sure the reverse iterator can be
+// configured to start mid way through the database and that the start key is
+// inclusive.
+func TestReverseIteratorStart(t *testing.T, db Database) {
+	require := require.New(t)
+
+	reverseIteratee := newTestReverseIteratee(t, db)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Put(key3, value3))
+
+	iterator := reverseIteratee.NewReverseIteratorWithStart(key2)
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+}
+
+// TestReverseIteratorPrefix tests to make sure the reverse iterator can be
+// configured to skip keys missing the provided prefix.
+func TestReverseIteratorPrefix(t *testing.T, db Database) {
+	require := require.New(t)
+
+	reverseIteratee := newTestReverseIteratee(t, db)
+
+	key1 := []byte("hello")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello1")
+	value2 := []byte("world2")
+
+	key3 := []byte("goodbye")
+	value3 := []byte("world3")
+
+	key4 := []byte("joy")
+	value4 := []byte("world4")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.Put(key4, value4))
+
+	iterator := reverseIteratee.NewReverseIteratorWithPrefix([]byte("h"))
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+}
+
+// TestReverseIteratorStartPrefix tests to make sure that the reverse iterator
+// can start mid way through the database while skipping a prefix.
+func TestReverseIteratorStartPrefix(t *testing.T, db Database) {
+	require := require.New(t)
+
+	reverseIteratee := newTestReverseIteratee(t, db)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("z")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	key4 := []byte("hello4")
+	value4 := []byte("world4")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.Put(key4, value4))
+
+	iterator := reverseIteratee.NewReverseIteratorWithStartAndPrefix([]byte("hello35"), []byte("h"))
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key3, iterator.Key())
+	require.Equal(value3, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+
+	// A start key after every key with the prefix shouldn't skip any keys.
+	iterator = reverseIteratee.NewReverseIteratorWithStartAndPrefix(key2, []byte("h"))
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key4, iterator.Key())
+	require.Equal(value4, iterator.Value())
+}
+
+// TestReverseIteratorClosed tests to make sure that a reverse iterator that was
+// created with a closed database will report a closed error correctly.
+func TestReverseIteratorClosed(t *testing.T, db Database) {
+	require := require.New(t)
+
+	reverseIteratee := newTestReverseIteratee(t, db)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Close())
+
+	iterator := reverseIteratee.NewReverseIteratorWithStartAndPrefix(nil, nil)
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.Equal(ErrClosed, iterator.Error())
+}
+
+// newTestSnapshot returns a snapshot of [db] or skips the test if [db] doesn't
+// support snapshots.
+func newTestSnapshot(t *testing.T, db Database) Snapshot {
+	snapshot, err := NewSnapshot(db)
+	if err == ErrSnapshotNotSupported {
+		t.Skip("database doesn't support snapshots")
+	}
+	require.NoError(t, err)
+	return snapshot
+}
+
+// TestSnapshot tests to make sure that writes performed after a snapshot was
+// created are not visible through the snapshot.
+func TestSnapshot(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+
+	snapshot := newTestSnapshot(t, db)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Delete(key1))
+
+	has, err := snapshot.Has(key1)
+	require.NoError(err)
+	require.True(has)
+
+	v, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, v)
+
+	has, err = snapshot.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = snapshot.Get(key2)
+	require.Equal(ErrNotFound, err)
+
+	has, err = db.Has(key1)
+	require.NoError(err)
+	require.False(has)
+}
+
+// TestSnapshotIterator tests to make sure that iterators created from a
+// snapshot only report the contents of the database at the time the snapshot
+// was created.
+func TestSnapshotIterator(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("goodbye3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot := newTestSnapshot(t, db)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.Delete(key2))
+	require.NoError(db.Put(key1, value2))
+
+	iterator := snapshot.NewIteratorWithPrefix([]byte("hello"))
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+
+	iterator = snapshot.NewIterator()
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.False(iterator.Next())
+	require.NoError(iterator.Error())
+}
+
+// TestSnapshotRelease tests to make sure that a released snapshot reports
+// [ErrClosed] and that releasing it multiple times is safe.
+func TestSnapshotRelease(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello1")
+	value := []byte("world1")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot := newTestSnapshot(t, db)
+	snapshot.Release()
+	snapshot.Release()
+
+	_, err := snapshot.Has(key)
+	require.Equal(ErrClosed, err)
+
+	_, err = snapshot.Get(key)
+	require.Equal(ErrClosed, err)
+
+	iterator := snapshot.NewIterator()
+	require.NotNil(iterator)
+
+	defer iterator.Release()
+
+	require.False(iterator.Next())
+	require.Equal(ErrClosed, iterator.Error())
+
+	v, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value, v)
+}
+
+// TestSnapshotClosed tests to make sure that a snapshot reports [ErrClosed]
+// once the database has been closed.
+func TestSnapshotClosed(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key := []byte("hello1")
+	value := []byte("world1")
+
+	require.NoError(db.Put(key, value))
+
+	snapshot := newTestSnapshot(t, db)
+	defer snapshot.Release()
+
+	require.NoError(db.Close())
+
+	_, err := snapshot.Has(key)
+	require.Equal(ErrClosed, err)
+
+	_, err = snapshot.Get(key)
+	require.Equal(ErrClosed, err)
+
+	_, err = NewSnapshot(db)
+	require.Equal(ErrClosed, err)
+}
+
 // TestCompactNoPanic tests to make sure compact never panics.
 func TestCompactNoPanic(t *testing.T, db Database) {
 	require := require.New(t)
@@ -1336,3 +1714,76 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F, db Database) {
 		require.NoError(AtomicClear(db, db))
 	})
 }
+
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F, db Database) {
+	const (
+		maxKeyLen   = 32
+		maxValueLen = 32
+	)
+
+	reverseIteratee, ok := db.(ReverseIteratee)
+	if !ok {
+		f.Skip("database doesn't support reverse iteration")
+	}
+
+	f.Fuzz(func(
+		t *testing.T,
+		randSeed int64,
+		start []byte,
+		prefix []byte,
+		numKeyValues uint,
+	) {
+		require := require.New(t)
+		r := rand.New(rand.NewSource(randSeed)) // #nosec G404
+
+		expected := map[string][]byte{}
+
+		// Put a bunch of key-values
+		for i := 0; i < int(numKeyValues); i++ {
+			key := make([]byte, r.Intn(maxKeyLen))
+			_, _ = r.Read(key) // #nosec G404
+
+			value := make([]byte, r.Intn(maxValueLen))
+			_, _ = r.Read(value) // #nosec G404
+
+			if len(value) == 0 {
+				// Consistently treat zero length values as nil
+				// so that we can compare [expected] and [got] with
+				// require.Equal, which treats nil and empty byte
+				// as being unequal, whereas the database treats
+				// them as being equal.
+				value = nil
+			}
+
+			if bytes.HasPrefix(key, prefix) && (len(start) == 0 || bytes.Compare(key, start) <= 0) {
+				expected[string(key)] = value
+			}
+
+			require.NoError(db.Put(key, value))
+		}
+
+		expectedList := maps.Keys(expected)
+		slices.Sort(expectedList)
+		slices.Reverse(expectedList)
+
+		iter := reverseIteratee.NewReverseIteratorWithStartAndPrefix(start, prefix)
+		defer iter.Release()
+
+		// Assert the iterator returns the expected key-values.
+		numIterElts := 0
+		for iter.Next() {
+			val := iter.Value()
+			if len(val) == 0 {
+				val = nil
+			}
+			keyStr := string(iter.Key())
+			require.Equal(expectedList[numIterElts], keyStr)
+			require.Equal(expected[keyStr], val)
+			numIterElts++
+		}
+		require.Len(expectedList, numIterElts)
+
+		// Clear the database for the next fuzz iteration.
+		require.NoError(AtomicClear(db, db))
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ttldb
+
+import (
+	"slices"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+)
+
+var (
+	_ Batch          = (*batch)(nil)
+	_ database.Batch = (*batch)(nil)
+)
+
+// Batch is a database.Batch that supports writing keys that expire. Batches
+// returned by Database.NewBatch implement Batch.
+type Batch interface {
+	database.Batch
+	Writer
+}
+
+type op struct {
+	database.BatchOp
+	expiry int64
+}
+
+type batch struct {
+	db   *Database
+	ops  []op
+	size int
+}
+
+func (b *batch) Put(key, value []byte) error {
+	return b.put(key, value, noExpiry)
+}
+
+func (b *batch) PutWithExpiry(key, value []byte, expiry time.Time) error {
+	return b.put(key, value, encodeExpiry(expiry))
+}
+
+func (b *batch) PutWithTTL(key, value []byte, ttl time.Duration) error {
+	return b.put(key, value, encodeExpiry(b.db.clock.Time().Add(ttl)))
+}
+
+func (b *batch) put(key, value []byte, expiry int64) error {
+	b.ops = append(b.ops, op{
+		BatchOp: database.BatchOp{
+			Key:   slices.Clone(key),
+			Value: slices.Clone(value),
+		},
+		expiry: expiry,
+	})
+	b.size += len(key) + len(value)
+	return nil
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.ops = append(b.ops, op{
+		BatchOp: database.BatchOp{
+			Key:    slices.Clone(key),
+			Delete: true,
+		},
+	})
+	b.size += len(key)
+	return nil
+}
+
+func (b *batch) Size() int {
+	return b.size
+}
+
+// Write applies the operations to the underlying database atomically, along
+// with the changes to the expiry index that they require.
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.closed {
+		return database.ErrClosed
+	}
+
+	var (
+		dataPrefix  = b.db.data.Prefix()
+		indexPrefix = b.db.index.Prefix()
+		batch       = b.db.db.NewBatch()
+		// expiries tracks the expiry of keys that were already modified by
+		// this batch, as they haven't been written to the database yet.
+		expiries = make(map[string]int64)
+	)
+	for _, op := range b.ops {
+		oldExpiry, ok := expiries[string(op.Key)]
+		if !ok {
+			var err error
+			oldExpiry, err = b.db.expiry(op.Key)
+			if err != nil {
+				return err
+			}
+		}
+		if oldExpiry != noExpiry {
+			indexKey := newIndexKey(op.Key, oldExpiry)
+			if err := batch.Delete(prefixdb.PrefixKey(indexPrefix, indexKey)); err != nil {
+				return err
+			}
+		}
+
+		dataKey := prefixdb.PrefixKey(dataPrefix, op.Key)
+		if op.Delete {
+			expiries[string(op.Key)] = noExpiry
+			if err := batch.Delete(dataKey); err != nil {
+				return err
+			}
+			continue
+		}
+
+		expiries[string(op.Key)] = op.expiry
+		if err := batch.Put(dataKey, newValue(op.Value, op.expiry)); err != nil {
+			return err
+		}
+		if op.expiry != noExpiry {
+			indexKey := newIndexKey(op.Key, op.expiry)
+			if err := batch.Put(prefixdb.PrefixKey(indexPrefix, indexKey), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
+
+func (b *batch) Reset() {
+	if cap(b.ops) > len(b.ops)*database.MaxExcessCapacityFactor {
+		b.ops = make([]op, 0, cap(b.ops)/database.CapacityReductionFactor)
+	} else {
+		clear(b.ops)
+		b.ops = b.ops[:0]
+	}
+	b.size = 0
+}
+
+// Replay replays the operations of the batch on [w]. Expirations are preserved
+// if [w] implements Writer.
+func (b *batch) Replay(w database.KeyValueWriterDeleter) error {
+	writer, supportsExpiry := w.(Writer)
+	for _, op := range b.ops {
+		var err error
+		switch {
+		case op.Delete:
+			err = w.Delete(op.Key)
+		case op.expiry != noExpiry && supportsExpiry:
+			err = writer.PutWithExpiry(op.Key, op.Value, time.Unix(0, op.expiry))
+		default:
+			err = w.Put(op.Key, op.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *batch) Inner() database.Batch {
+	return b
+}
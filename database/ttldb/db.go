@@ -0,0 +1,449 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ttldb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	// noExpiry is the encoded expiry of keys that never expire.
+	noExpiry = 0
+	// minExpiry is the smallest encoded expiry of keys that expire. Encoded
+	// expiries are sorted as unsigned integers, so they must be positive.
+	minExpiry = 1
+
+	defaultSweepBatchSize = 1024
+)
+
+var (
+	_ database.Database        = (*Database)(nil)
+	_ database.ReverseIteratee = (*Database)(nil)
+	_ Writer                   = (*Database)(nil)
+
+	dataPrefix  = []byte("data")
+	indexPrefix = []byte("expiry")
+
+	errInvalidValue    = errors.New("invalid value")
+	errInvalidIndexKey = errors.New("invalid index key")
+)
+
+// Writer writes keys that expire.
+type Writer interface {
+	// PutWithExpiry inserts [key] with [value], which will be treated as
+	// deleted once [expiry] has been reached.
+	PutWithExpiry(key, value []byte, expiry time.Time) error
+
+	// PutWithTTL inserts [key] with [value], which will be treated as deleted
+	// once [ttl] has elapsed.
+	PutWithTTL(key, value []byte, ttl time.Duration) error
+}
+
+type Config struct {
+	// SweepInterval is how often expired keys are removed from the underlying
+	// database. If 0, no background sweeper is started and Sweep must be
+	// called manually.
+	SweepInterval time.Duration `json:"sweepInterval"`
+	// SweepBatchSize is the maximum number of expired keys removed in a
+	// single write. If 0, a default is used.
+	SweepBatchSize int `json:"sweepBatchSize"`
+}
+
+// Database is a database wrapper that supports per-key expirations.
+//
+// Keys written with Put never expire. Keys written with PutWithExpiry or
+// PutWithTTL are hidden from Has, Get and iterators once they expire, and are
+// eventually removed from the underlying database by Sweep.
+//
+// Every value is stored alongside its expiry. Keys that expire are additionally
+// indexed by their expiry, so that sweeping only inspects expired keys.
+type Database struct {
+	metrics
+
+	// lock is held for writing during writes, so that the expiry index is
+	// updated consistently with the values, and during Close.
+	lock   sync.RWMutex
+	closed bool
+
+	db database.Database
+	// data and index are nested directly inside [db], so that their keys can
+	// be written to [db] atomically.
+	data  *prefixdb.Database
+	index *prefixdb.Database
+	clock mockable.Clock
+
+	sweepBatchSize int
+	sweeperCancel  context.CancelFunc
+	sweeperDone    chan struct{}
+}
+
+// New returns a new database that supports expiring keys on top of [db].
+//
+// If [config.SweepInterval] is non-zero, expired keys are removed in the
+// background until the database is closed.
+func New(
+	namespace string,
+	registerer prometheus.Registerer,
+	db database.Database,
+	config Config,
+) (*Database, error) {
+	metrics, err := newMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	sweepBatchSize := config.SweepBatchSize
+	if sweepBatchSize <= 0 {
+		sweepBatchSize = defaultSweepBatchSize
+	}
+
+	ttlDB := &Database{
+		metrics:        metrics,
+		db:             db,
+		data:           prefixdb.NewNested(dataPrefix, db),
+		index:          prefixdb.NewNested(indexPrefix, db),
+		sweepBatchSize: sweepBatchSize,
+	}
+	if config.SweepInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		ttlDB.sweeperCancel = cancel
+		ttlDB.sweeperDone = make(chan struct{})
+		go ttlDB.sweep(ctx, config.SweepInterval)
+	}
+	return ttlDB, nil
+}
+
+func (db *Database) Has(key []byte) (bool, error) {
+	_, err := db.Get(key)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	dbValue, err := db.data.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	value, expiry, err := parseValue(dbValue)
+	if err != nil {
+		return nil, err
+	}
+	if isExpired(expiry, db.now()) {
+		db.hidden.Inc()
+		return nil, database.ErrNotFound
+	}
+	return value, nil
+}
+
+func (db *Database) Put(key, value []byte) error {
+	return db.put(key, value, noExpiry)
+}
+
+func (db *Database) PutWithExpiry(key, value []byte, expiry time.Time) error {
+	return db.put(key, value, encodeExpiry(expiry))
+}
+
+func (db *Database) PutWithTTL(key, value []byte, ttl time.Duration) error {
+	return db.put(key, value, encodeExpiry(db.clock.Time().Add(ttl)))
+}
+
+func (db *Database) put(key, value []byte, expiry int64) error {
+	b := db.newBatch()
+	if err := b.put(key, value, expiry); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
+func (db *Database) Delete(key []byte) error {
+	b := db.newBatch()
+	if err := b.Delete(key); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
+func (db *Database) NewBatch() database.Batch {
+	return db.newBatch()
+}
+
+func (db *Database) newBatch() *batch {
+	return &batch{
+		db: db,
+	}
+}
+
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return &iterator{
+		Iterator: db.data.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+		now:      db.now(),
+	}
+}
+
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return &iterator{
+		Iterator: db.data.NewReverseIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+		now:      db.now(),
+	}
+}
+
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return db.data.Compact(start, limit)
+}
+
+func (db *Database) Close() error {
+	if db.sweeperCancel != nil {
+		db.sweeperCancel()
+		<-db.sweeperDone
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	db.closed = true
+	return db.db.Close()
+}
+
+func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+	return db.db.HealthCheck(ctx)
+}
+
+// Sweep removes all the keys that have expired from the underlying database
+// and returns the number of keys that were removed.
+//
+// Expired keys are removed in batches, so other operations are not blocked
+// for the duration of the sweep.
+func (db *Database) Sweep(ctx context.Context) (int, error) {
+	start := db.clock.Time()
+	defer func() {
+		db.sweeps.Inc()
+		db.sweepTime.Observe(float64(db.clock.Time().Sub(start)))
+	}()
+
+	var swept int
+	for {
+		if err := ctx.Err(); err != nil {
+			return swept, err
+		}
+
+		numSwept, err := db.sweepBatch()
+		swept += numSwept
+		db.swept.Add(float64(numSwept))
+		if err != nil || numSwept < db.sweepBatchSize {
+			return swept, err
+		}
+	}
+}
+
+// sweepBatch removes up to [db.sweepBatchSize] expired keys.
+func (db *Database) sweepBatch() (int, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return 0, database.ErrClosed
+	}
+
+	// The index is sorted by expiry, so iteration can stop at the first key
+	// that hasn't expired yet.
+	var (
+		now   = db.now()
+		it    = db.index.NewIterator()
+		batch = db.db.NewBatch()
+		swept int
+	)
+	defer it.Release()
+
+	for swept < db.sweepBatchSize && it.Next() {
+		indexKey := it.Key()
+		expiry, key, err := parseIndexKey(indexKey)
+		if err != nil {
+			return 0, err
+		}
+		if !isExpired(expiry, now) {
+			break
+		}
+
+		if err := batch.Delete(prefixdb.PrefixKey(db.index.Prefix(), indexKey)); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(prefixdb.PrefixKey(db.data.Prefix(), key)); err != nil {
+			return 0, err
+		}
+		swept++
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	return swept, batch.Write()
+}
+
+func (db *Database) sweep(ctx context.Context, interval time.Duration) {
+	defer close(db.sweeperDone)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := db.Sweep(ctx); err != nil && ctx.Err() == nil {
+			db.sweepErrors.Inc()
+		}
+	}
+}
+
+// expiry returns the expiry of [key], or noExpiry if [key] doesn't exist.
+//
+// Assumes [db.lock] is held.
+func (db *Database) expiry(key []byte) (int64, error) {
+	dbValue, err := db.data.Get(key)
+	if err == database.ErrNotFound {
+		return noExpiry, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	_, expiry, err := parseValue(dbValue)
+	return expiry, err
+}
+
+func (db *Database) now() int64 {
+	return db.clock.Time().UnixNano()
+}
+
+// encodeExpiry returns the encoded [expiry]. Expiries that can't be
+// represented are clamped: expiries at or before the Unix epoch, which have
+// already passed, are encoded as [minExpiry], and expiries too far in the
+// future are encoded as the latest representable expiry.
+func encodeExpiry(expiry time.Time) int64 {
+	switch {
+	case expiry.Before(time.Unix(0, minExpiry)):
+		return minExpiry
+	case expiry.After(time.Unix(0, math.MaxInt64)):
+		return math.MaxInt64
+	default:
+		return expiry.UnixNano()
+	}
+}
+
+func isExpired(expiry, now int64) bool {
+	return expiry != noExpiry && expiry <= now
+}
+
+// newValue prepends [expiry] to [value].
+func newValue(value []byte, expiry int64) []byte {
+	dbValue := make([]byte, wrappers.LongLen+len(value))
+	binary.BigEndian.PutUint64(dbValue, uint64(expiry))
+	copy(dbValue[wrappers.LongLen:], value)
+	return dbValue
+}
+
+func parseValue(dbValue []byte) ([]byte, int64, error) {
+	if len(dbValue) < wrappers.LongLen {
+		return nil, 0, errInvalidValue
+	}
+	expiry := int64(binary.BigEndian.Uint64(dbValue))
+	return dbValue[wrappers.LongLen:], expiry, nil
+}
+
+// newIndexKey returns the key of [key] in the expiry index. Index keys are
+// sorted by expiry.
+func newIndexKey(key []byte, expiry int64) []byte {
+	indexKey := make([]byte, wrappers.LongLen+len(key))
+	binary.BigEndian.PutUint64(indexKey, uint64(expiry))
+	copy(indexKey[wrappers.LongLen:], key)
+	return indexKey
+}
+
+func parseIndexKey(indexKey []byte) (int64, []byte, error) {
+	if len(indexKey) < wrappers.LongLen {
+		return 0, nil, errInvalidIndexKey
+	}
+	expiry := int64(binary.BigEndian.Uint64(indexKey))
+	return expiry, slices.Clone(indexKey[wrappers.LongLen:]), nil
+}
@@ -0,0 +1,267 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ttldb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/database/versiondb"
+)
+
+func TestInterface(t *testing.T) {
+	for name, test := range database.Tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newDB(t))
+		})
+	}
+}
+
+func newDB(t testing.TB) *Database {
+	db, err := New("", prometheus.NewRegistry(), memdb.New(), Config{})
+	require.NoError(t, err)
+	return db
+}
+
+func FuzzKeyValue(f *testing.F) {
+	database.FuzzKeyValue(f, newDB(f))
+}
+
+func FuzzNewIteratorWithPrefix(f *testing.F) {
+	database.FuzzNewIteratorWithPrefix(f, newDB(f))
+}
+
+func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewIteratorWithStartAndPrefix(f, newDB(f))
+}
+
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, newDB(f))
+}
+
+func TestExpiry(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t)
+	now := time.Unix(1_000, 0)
+	db.clock.Set(now)
+
+	require.NoError(db.Put([]byte("forever"), []byte("value")))
+	require.NoError(db.PutWithTTL([]byte("short"), []byte("value"), time.Second))
+	require.NoError(db.PutWithExpiry([]byte("long"), []byte("value"), now.Add(time.Minute)))
+
+	requireKeys(t, db, "forever", "long", "short")
+
+	db.clock.Set(now.Add(time.Second))
+
+	has, err := db.Has([]byte("short"))
+	require.NoError(err)
+	require.False(has)
+
+	_, err = db.Get([]byte("short"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	value, err := db.Get([]byte("long"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+
+	requireKeys(t, db, "forever", "long")
+
+	// Overwriting a key replaces its expiry.
+	require.NoError(db.Put([]byte("long"), []byte("updated")))
+	db.clock.Set(now.Add(time.Hour))
+	requireKeys(t, db, "forever", "long")
+
+	// Expired keys can be written again.
+	require.NoError(db.PutWithTTL([]byte("short"), []byte("value"), time.Second))
+	requireKeys(t, db, "forever", "long", "short")
+}
+
+func TestSweep(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), baseDB, Config{
+		SweepBatchSize: 2,
+	})
+	require.NoError(err)
+
+	now := time.Unix(1_000, 0)
+	db.clock.Set(now)
+
+	require.NoError(db.Put([]byte("forever"), []byte("value")))
+	batch := db.NewBatch().(Batch)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(batch.PutWithTTL([]byte(key), []byte("value"), time.Second))
+	}
+	require.NoError(batch.PutWithTTL([]byte("later"), []byte("value"), time.Minute))
+	// The expiry of [e] is removed, so it shouldn't be swept.
+	require.NoError(batch.Put([]byte("e"), []byte("value")))
+	require.NoError(batch.Write())
+
+	swept, err := db.Sweep(context.Background())
+	require.NoError(err)
+	require.Zero(swept)
+
+	db.clock.Set(now.Add(time.Second))
+
+	swept, err = db.Sweep(context.Background())
+	require.NoError(err)
+	require.Equal(4, swept)
+
+	requireKeys(t, db, "e", "forever", "later")
+
+	// Only the remaining keys and the expiry of [later] should be left in the
+	// underlying database.
+	require.Equal(4, count(t, baseDB))
+
+	db.clock.Set(now.Add(time.Minute))
+
+	swept, err = db.Sweep(context.Background())
+	require.NoError(err)
+	require.Equal(1, swept)
+	require.Equal(2, count(t, baseDB))
+}
+
+func TestExpiryBeforeEpoch(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), baseDB, Config{})
+	require.NoError(err)
+	db.clock.Set(time.Unix(1_000, 0))
+
+	// Expiries at or before the epoch have already passed, and must not be
+	// treated as never expiring.
+	require.NoError(db.PutWithExpiry([]byte("epoch"), []byte("value"), time.Unix(0, 0)))
+	require.NoError(db.PutWithExpiry([]byte("before"), []byte("value"), time.Unix(-1_000, 0)))
+	require.NoError(db.PutWithTTL([]byte("ttl"), []byte("value"), -time.Hour*24*365*100))
+	require.NoError(db.PutWithExpiry([]byte("later"), []byte("value"), time.Unix(2_000, 0)))
+	requireKeys(t, db, "later")
+
+	swept, err := db.Sweep(context.Background())
+	require.NoError(err)
+	require.Equal(3, swept)
+
+	// Only [later] and its expiry should be left in the underlying database.
+	require.Equal(2, count(t, baseDB))
+}
+
+func TestBackgroundSweep(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), baseDB, Config{
+		SweepInterval: time.Millisecond,
+	})
+	require.NoError(err)
+
+	require.NoError(db.PutWithExpiry([]byte("key"), []byte("value"), time.Now()))
+	require.Eventually(
+		func() bool {
+			return count(t, baseDB) == 0
+		},
+		time.Second,
+		time.Millisecond,
+	)
+
+	require.NoError(db.Close())
+	err = db.Close()
+	require.ErrorIs(err, database.ErrClosed)
+}
+
+func TestBatchReplay(t *testing.T) {
+	require := require.New(t)
+
+	db := newDB(t)
+	now := time.Unix(1_000, 0)
+	db.clock.Set(now)
+
+	batch := db.NewBatch().(Batch)
+	require.NoError(batch.PutWithTTL([]byte("key"), []byte("value"), time.Second))
+
+	// Replaying onto a database that supports expiry preserves the expiry.
+	require.NoError(batch.Replay(db))
+	requireKeys(t, db, "key")
+
+	db.clock.Set(now.Add(time.Second))
+	requireKeys(t, db)
+
+	// Otherwise, the key is written without an expiry.
+	memDB := memdb.New()
+	require.NoError(batch.Replay(memDB))
+	value, err := memDB.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestCompose(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	vdb := versiondb.New(baseDB)
+	db, err := New("", prometheus.NewRegistry(), prefixdb.New([]byte("vm"), vdb), Config{})
+	require.NoError(err)
+
+	now := time.Unix(1_000, 0)
+	db.clock.Set(now)
+
+	require.NoError(db.PutWithTTL([]byte("key"), []byte("value"), time.Second))
+	require.Zero(count(t, baseDB))
+
+	// Expiring writes are committed atomically with the rest of the versiondb.
+	require.NoError(vdb.Commit())
+	require.Equal(2, count(t, baseDB))
+
+	db.clock.Set(now.Add(time.Second))
+	requireKeys(t, db)
+
+	swept, err := db.Sweep(context.Background())
+	require.NoError(err)
+	require.Equal(1, swept)
+	require.Equal(2, count(t, baseDB))
+
+	require.NoError(vdb.Commit())
+	require.Zero(count(t, baseDB))
+}
+
+// requireKeys requires that iterating over [db] returns exactly [expected],
+// and that each of them is reported by Has.
+func requireKeys(t *testing.T, db *Database, expected ...string) {
+	require := require.New(t)
+
+	var keys []string
+	it := db.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(it.Error())
+	require.Equal(expected, keys)
+
+	for _, key := range expected {
+		has, err := db.Has([]byte(key))
+		require.NoError(err)
+		require.True(has)
+	}
+}
+
+func count(t *testing.T, db database.Iteratee) int {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var count int
+	for it.Next() {
+		count++
+	}
+	require.NoError(t, it.Error())
+	return count
+}
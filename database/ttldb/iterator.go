@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ttldb
+
+import "github.com/MetalBlockchain/metalgo/database"
+
+var _ database.Iterator = (*iterator)(nil)
+
+// iterator skips the keys that were expired when the iterator was created and
+// strips the expiry from values.
+type iterator struct {
+	database.Iterator
+	db  *Database
+	now int64
+
+	key, value []byte
+	err        error
+}
+
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.Iterator.Next() {
+		value, expiry, err := parseValue(it.Iterator.Value())
+		if err != nil {
+			it.err = err
+			break
+		}
+		if isExpired(expiry, it.now) {
+			it.db.hidden.Inc()
+			continue
+		}
+
+		it.key = it.Iterator.Key()
+		it.value = value
+		return true
+	}
+
+	it.key = nil
+	it.value = nil
+	return false
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ttldb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/utils/metric"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+type metrics struct {
+	hidden      prometheus.Counter
+	sweeps      prometheus.Counter
+	swept       prometheus.Counter
+	sweepErrors prometheus.Counter
+	sweepTime   metric.Averager
+}
+
+func newMetrics(namespace string, reg prometheus.Registerer) (metrics, error) {
+	errs := wrappers.Errs{}
+	m := metrics{
+		hidden: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "expired_reads",
+			Help:      "number of expired keys that were hidden from reads before being swept",
+		}),
+		sweeps: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sweeps",
+			Help:      "number of sweeps of expired keys",
+		}),
+		swept: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "swept_keys",
+			Help:      "number of expired keys removed by sweeps",
+		}),
+		sweepErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sweep_errors",
+			Help:      "number of background sweeps that failed",
+		}),
+		sweepTime: metric.NewAveragerWithErrs(
+			namespace,
+			"sweep",
+			"time (in ns) of a sweep",
+			reg,
+			&errs,
+		),
+	}
+	errs.Add(
+		reg.Register(m.hidden),
+		reg.Register(m.sweeps),
+		reg.Register(m.swept),
+		reg.Register(m.sweepErrors),
+	)
+	return m, errs.Err
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package usage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const namespaceLabel = "namespace"
+
+// Tracker records the namespaces of a database and reports the usage of each
+// namespace as metrics.
+type Tracker struct {
+	db database.Database
+
+	lock       sync.RWMutex
+	namespaces map[string]Namespace // prefix -> namespace
+
+	keys               *prometheus.GaugeVec
+	keyBytes           *prometheus.GaugeVec
+	valueBytes         *prometheus.GaugeVec
+	estimatedDiskBytes *prometheus.GaugeVec
+}
+
+func NewTracker(
+	db database.Database,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Tracker, error) {
+	labels := []string{namespaceLabel}
+	t := &Tracker{
+		db:         db,
+		namespaces: make(map[string]Namespace),
+		keys: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "keys",
+				Help:      "number of keys in the namespace as of the last walk",
+			},
+			labels,
+		),
+		keyBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "key_bytes",
+				Help:      "total size of the keys in the namespace as of the last walk",
+			},
+			labels,
+		),
+		valueBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "value_bytes",
+				Help:      "total size of the values in the namespace as of the last walk",
+			},
+			labels,
+		),
+		estimatedDiskBytes: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "estimated_disk_bytes",
+				Help:      "estimated disk space used by the namespace as of the last estimate",
+			},
+			labels,
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(t.keys),
+		registerer.Register(t.keyBytes),
+		registerer.Register(t.valueBytes),
+		registerer.Register(t.estimatedDiskBytes),
+	)
+	return t, errs.Err
+}
+
+// Register attributes keys starting with [prefix] to [name]. If [prefix] was
+// previously registered, its name is replaced.
+func (t *Tracker) Register(name string, prefix []byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.namespaces[string(prefix)] = Namespace{
+		Name:   name,
+		Prefix: prefix,
+	}
+}
+
+// Namespaces returns the registered namespaces sorted by name.
+func (t *Tracker) Namespaces() []Namespace {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	namespaces := make([]Namespace, 0, len(t.namespaces))
+	for _, namespace := range t.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+	return namespaces
+}
+
+// Walk iterates over the entire database to calculate the usage of each
+// namespace. If the database supports it, the estimated disk usage is also
+// populated.
+func (t *Tracker) Walk(ctx context.Context) (map[string]*Stats, error) {
+	namespaces := t.Namespaces()
+	stats, err := Walk(ctx, t.db, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	for name, s := range stats {
+		t.keys.WithLabelValues(name).Set(float64(s.Keys))
+		t.keyBytes.WithLabelValues(name).Set(float64(s.KeyBytes))
+		t.valueBytes.WithLabelValues(name).Set(float64(s.ValueBytes))
+	}
+
+	err = t.estimate(namespaces, stats)
+	if errors.Is(err, database.ErrDiskUsageEstimationNotSupported) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Estimate populates the estimated disk usage of each namespace without
+// walking the database.
+//
+// Returns [database.ErrDiskUsageEstimationNotSupported] if the database
+// doesn't support estimation.
+func (t *Tracker) Estimate() (map[string]*Stats, error) {
+	namespaces := t.Namespaces()
+	stats := newStats(namespaces)
+	if err := t.estimate(namespaces, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (t *Tracker) estimate(namespaces []Namespace, stats map[string]*Stats) error {
+	if err := estimate(t.db, namespaces, stats); err != nil {
+		return err
+	}
+	for name, s := range stats {
+		t.estimatedDiskBytes.WithLabelValues(name).Set(float64(s.EstimatedDiskBytes))
+	}
+	return nil
+}
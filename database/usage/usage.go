@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package usage attributes the keys stored in a database to named key
+// prefixes, such as the prefixes of each chain.
+package usage
+
+import (
+	"context"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+// UnknownNamespace is the name that keys not matching any namespace are
+// attributed to.
+const UnknownNamespace = "unknown"
+
+// contextCheckFrequency is the number of keys walked between checks of whether
+// the context has been cancelled.
+const contextCheckFrequency = 1024
+
+// Namespace is a named key prefix.
+type Namespace struct {
+	Name   string
+	Prefix []byte
+}
+
+// Stats describe the keys in a namespace.
+type Stats struct {
+	Keys       uint64 `json:"keys"`
+	KeyBytes   uint64 `json:"keyBytes"`
+	ValueBytes uint64 `json:"valueBytes"`
+	// EstimatedDiskBytes is the backend's estimate of the disk space used by
+	// the namespace. It is 0 if the database doesn't support estimation.
+	EstimatedDiskBytes uint64 `json:"estimatedDiskBytes"`
+}
+
+// Walk iterates over every key in [db] and attributes it to the namespace
+// with the longest prefix of the key. Keys that don't match any namespace are
+// attributed to [UnknownNamespace]. Multiple namespaces with the same name are
+// combined.
+//
+// The returned stats contain an entry for every namespace, even if no keys
+// were attributed to it.
+func Walk(ctx context.Context, db database.Iteratee, namespaces []Namespace) (map[string]*Stats, error) {
+	stats := newStats(namespaces)
+
+	// namesByLength maps each prefix length to the names of the prefixes of
+	// that length.
+	namesByLength := make(map[int]map[string]string)
+	for _, namespace := range namespaces {
+		names, ok := namesByLength[len(namespace.Prefix)]
+		if !ok {
+			names = make(map[string]string)
+			namesByLength[len(namespace.Prefix)] = names
+		}
+		names[string(namespace.Prefix)] = namespace.Name
+	}
+	lengths := make([]int, 0, len(namesByLength))
+	for length := range namesByLength {
+		lengths = append(lengths, length)
+	}
+	// Check the longest prefixes first.
+	slices.Sort(lengths)
+	slices.Reverse(lengths)
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for numKeysWalked := uint64(1); it.Next(); numKeysWalked++ {
+		if numKeysWalked%contextCheckFrequency == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		key := it.Key()
+		name := UnknownNamespace
+		for _, length := range lengths {
+			if length > len(key) {
+				continue
+			}
+			if prefixName, ok := namesByLength[length][string(key[:length])]; ok {
+				name = prefixName
+				break
+			}
+		}
+
+		namespaceStats := stats[name]
+		namespaceStats.Keys++
+		namespaceStats.KeyBytes += uint64(len(key))
+		namespaceStats.ValueBytes += uint64(len(it.Value()))
+	}
+	return stats, it.Error()
+}
+
+// Estimate uses the disk usage estimates of [db] to populate the estimated
+// disk usage of every namespace. Like [Walk], the disk usage of a key range is
+// attributed to the namespace with the longest prefix of the range. The disk
+// usage that isn't attributed to any namespace is reported as
+// [UnknownNamespace].
+//
+// Returns [database.ErrDiskUsageEstimationNotSupported] if [db] doesn't
+// support estimation.
+func Estimate(db database.Database, namespaces []Namespace) (map[string]*Stats, error) {
+	stats := newStats(namespaces)
+	if err := estimate(db, namespaces, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func estimate(db database.Database, namespaces []Namespace, stats map[string]*Stats) error {
+	total, err := database.EstimateDiskUsage(db, nil)
+	if err != nil {
+		return err
+	}
+
+	// As in [Walk], if multiple namespaces have the same prefix, the last one
+	// is used.
+	namesByPrefix := make(map[string]string, len(namespaces))
+	for _, namespace := range namespaces {
+		namesByPrefix[string(namespace.Prefix)] = namespace.Name
+	}
+
+	sizesByPrefix := make(map[string]uint64, len(namesByPrefix))
+	for prefix := range namesByPrefix {
+		size, err := database.EstimateDiskUsage(db, []byte(prefix))
+		if err != nil {
+			return err
+		}
+		sizesByPrefix[prefix] = size
+	}
+
+	var attributed uint64
+	for prefix, name := range namesByPrefix {
+		// The estimate of [prefix] includes the estimates of the namespaces
+		// nested in it, which are attributed to the nested namespaces instead.
+		size := sizesByPrefix[prefix]
+		for nestedPrefix := range namesByPrefix {
+			if !isDirectlyNested(namesByPrefix, prefix, nestedPrefix) {
+				continue
+			}
+			size -= min(size, sizesByPrefix[nestedPrefix])
+		}
+		stats[name].EstimatedDiskBytes += size
+		attributed += size
+	}
+	// The estimates of each namespace may not add up exactly to the total.
+	if total > attributed {
+		stats[UnknownNamespace].EstimatedDiskBytes = total - attributed
+	}
+	return nil
+}
+
+// isDirectlyNested returns true if [nested] is a longer prefix than [prefix]
+// and no other prefix in [prefixes] is between them.
+func isDirectlyNested(prefixes map[string]string, prefix, nested string) bool {
+	if len(nested) <= len(prefix) || !strings.HasPrefix(nested, prefix) {
+		return false
+	}
+	for between := range prefixes {
+		if len(between) > len(prefix) && len(between) < len(nested) &&
+			strings.HasPrefix(between, prefix) && strings.HasPrefix(nested, between) {
+			return false
+		}
+	}
+	return true
+}
+
+func newStats(namespaces []Namespace) map[string]*Stats {
+	stats := map[string]*Stats{
+		UnknownNamespace: {},
+	}
+	for _, namespace := range namespaces {
+		stats[namespace.Name] = &Stats{}
+	}
+	return stats
+}
+
+// FormatPrefix returns a human readable name for [prefix]. Prefixes consisting
+// of printable ASCII characters are returned as is, all other prefixes are hex
+// encoded.
+func FormatPrefix(prefix []byte) string {
+	for _, b := range prefix {
+		if b < ' ' || b > '~' {
+			return "0x" + hex.EncodeToString(prefix)
+		}
+	}
+	return string(prefix)
+}
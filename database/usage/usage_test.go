@@ -0,0 +1,210 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package usage
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/leveldb"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/database/pebble"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func TestWalk(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	for key, value := range map[string]string{
+		"a1":   "1",
+		"a2":   "22",
+		"ab1":  "333",
+		"b1":   "4444",
+		"c1":   "55555",
+		"cccc": "",
+	} {
+		require.NoError(db.Put([]byte(key), []byte(value)))
+	}
+
+	stats, err := Walk(context.Background(), db, []Namespace{
+		{Name: "a", Prefix: []byte("a")},
+		{Name: "ab", Prefix: []byte("ab")},
+		{Name: "b", Prefix: []byte("b")},
+		{Name: "d", Prefix: []byte("d")},
+		// Namespaces with the same name are combined.
+		{Name: "b", Prefix: []byte("cc")},
+	})
+	require.NoError(err)
+	require.Equal(
+		map[string]*Stats{
+			"a": {
+				Keys:       2,
+				KeyBytes:   4,
+				ValueBytes: 3,
+			},
+			"ab": {
+				Keys:       1,
+				KeyBytes:   3,
+				ValueBytes: 3,
+			},
+			"b": {
+				Keys:       2,
+				KeyBytes:   6,
+				ValueBytes: 4,
+			},
+			"d": {},
+			UnknownNamespace: {
+				Keys:       1,
+				KeyBytes:   2,
+				ValueBytes: 5,
+			},
+		},
+		stats,
+	)
+}
+
+func TestWalkCancelled(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	for i := 0; i < contextCheckFrequency; i++ {
+		require.NoError(db.Put([]byte{byte(i), byte(i >> 8)}, nil))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Walk(ctx, db, nil)
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name  string
+		newDB func(t *testing.T) database.Database
+	}{
+		{
+			name: leveldb.Name,
+			newDB: func(t *testing.T) database.Database {
+				db, err := leveldb.New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+				require.NoError(t, err)
+				return db
+			},
+		},
+		{
+			name: pebble.Name,
+			newDB: func(t *testing.T) database.Database {
+				db, err := pebble.New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+				require.NoError(t, err)
+				return db
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := test.newDB(t)
+			defer func() {
+				require.NoError(db.Close())
+			}()
+
+			largeDB := prefixdb.New([]byte("large"), db)
+			smallDB := prefixdb.New([]byte("small"), db)
+			// The nested namespace is inside of the large namespace.
+			nestedPrefix := append(slices.Clone(largeDB.Prefix()), "nested"...)
+			value := make([]byte, 1024)
+			for i := 0; i < 1024; i++ {
+				key := []byte{byte(i), byte(i >> 8)}
+				require.NoError(largeDB.Put(key, value))
+				if i%16 == 0 {
+					require.NoError(smallDB.Put(key, value))
+				}
+				if i%4 == 0 {
+					require.NoError(db.Put(append(slices.Clone(nestedPrefix), key...), value))
+				}
+			}
+			// Flush the writes to disk so they are included in the estimates.
+			require.NoError(db.Compact(nil, nil))
+
+			stats, err := Estimate(db, []Namespace{
+				{Name: "large", Prefix: largeDB.Prefix()},
+				{Name: "small", Prefix: smallDB.Prefix()},
+				{Name: "empty", Prefix: prefixdb.MakePrefix([]byte("empty"))},
+				{Name: "nested", Prefix: nestedPrefix},
+			})
+			require.NoError(err)
+
+			require.Zero(stats["empty"].EstimatedDiskBytes)
+			require.Positive(stats["small"].EstimatedDiskBytes)
+			require.Positive(stats["nested"].EstimatedDiskBytes)
+			require.Greater(stats["large"].EstimatedDiskBytes, stats["small"].EstimatedDiskBytes)
+
+			// Estimates can also be made through the prefixed database. The
+			// nested namespace isn't included in the large namespace.
+			size, err := database.EstimateDiskUsage(largeDB, nil)
+			require.NoError(err)
+			require.Equal(size, stats["large"].EstimatedDiskBytes+stats["nested"].EstimatedDiskBytes)
+
+			// No disk usage is attributed to multiple namespaces.
+			total, err := database.EstimateDiskUsage(db, nil)
+			require.NoError(err)
+			var attributed uint64
+			for _, s := range stats {
+				attributed += s.EstimatedDiskBytes
+			}
+			require.Equal(total, attributed)
+		})
+	}
+}
+
+func TestEstimateNotSupported(t *testing.T) {
+	_, err := Estimate(memdb.New(), nil)
+	require.ErrorIs(t, err, database.ErrDiskUsageEstimationNotSupported)
+}
+
+func TestTracker(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	registry := prometheus.NewRegistry()
+	tracker, err := NewTracker(db, "", registry)
+	require.NoError(err)
+
+	vmDB := prefixdb.NewObserved([]byte("vm"), db, func(prefix, dbPrefix []byte) {
+		tracker.Register("vm/"+FormatPrefix(prefix), dbPrefix)
+	})
+	tracker.Register("vm", vmDB.Prefix())
+
+	stateDB := prefixdb.New([]byte("state"), vmDB)
+	require.NoError(stateDB.Put([]byte("key"), []byte("value")))
+	binaryDB := prefixdb.New([]byte{0x00, 0xff}, vmDB)
+	require.NoError(binaryDB.Put([]byte("key"), nil))
+
+	require.Equal(
+		[]Namespace{
+			{Name: "vm", Prefix: vmDB.Prefix()},
+			{Name: "vm/0x00ff", Prefix: binaryDB.Prefix()},
+			{Name: "vm/state", Prefix: stateDB.Prefix()},
+		},
+		tracker.Namespaces(),
+	)
+
+	stats, err := tracker.Walk(context.Background())
+	require.NoError(err)
+	require.Equal(uint64(1), stats["vm/state"].Keys)
+	require.Equal(uint64(1), stats["vm/0x00ff"].Keys)
+	require.Zero(stats["vm"].Keys)
+
+	require.InEpsilon(5, testutil.ToFloat64(tracker.valueBytes.WithLabelValues("vm/state")), 0)
+	require.Zero(testutil.ToFloat64(tracker.valueBytes.WithLabelValues("vm/0x00ff")))
+}
@@ -5,6 +5,7 @@ package versiondb
 
 import (
 	"context"
+	"maps"
 	"slices"
 	"strings"
 	"sync"
@@ -14,10 +15,15 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ Commitable        = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database           = (*Database)(nil)
+	_ database.ReverseIteratee    = (*Database)(nil)
+	_ database.Snapshotter        = (*Database)(nil)
+	_ database.DiskUsageEstimator = (*Database)(nil)
+	_ Commitable                  = (*Database)(nil)
+	_ database.Batch              = (*batch)(nil)
+	_ database.Iterator           = (*iterator)(nil)
+	_ database.Snapshot           = (*snapshot)(nil)
+	_ database.ReverseIteratee    = (*snapshot)(nil)
 )
 
 // Commitable defines the interface that specifies that something may be
@@ -35,6 +41,14 @@ type Database struct {
 	mem   map[string]valueDelete
 	db    database.Database
 	batch database.Batch
+
+	// savepoints is the stack of active savepoints, ordered from oldest to
+	// newest.
+	savepoints    []savepoint
+	nextSavepoint Savepoint
+	// journal records the prior state of every key modified in [mem] while a
+	// savepoint is active. It is only populated if [savepoints] is non-empty.
+	journal []journalEntry
 }
 
 type valueDelete struct {
@@ -87,7 +101,7 @@ func (db *Database) Put(key, value []byte) error {
 	if db.mem == nil {
 		return database.ErrClosed
 	}
-	db.mem[string(key)] = valueDelete{value: slices.Clone(value)}
+	db.set(string(key), valueDelete{value: slices.Clone(value)})
 	return nil
 }
 
@@ -98,7 +112,7 @@ func (db *Database) Delete(key []byte) error {
 	if db.mem == nil {
 		return database.ErrClosed
 	}
-	db.mem[string(key)] = valueDelete{delete: true}
+	db.set(string(key), valueDelete{delete: true})
 	return nil
 }
 
@@ -127,27 +141,64 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 			Err: database.ErrClosed,
 		}
 	}
+	return newIterator(
+		db,
+		db.mem,
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+		false,
+	)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (db *Database) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewReverseIteratorWithStartAndPrefix returns an iterator reporting
+// [database.ErrReverseIterationNotSupported] if the underlying database doesn't
+// support reverse iteration.
+func (db *Database) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
 		}
 	}
-	slices.Sort(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
-	}
+	return newReverseIterator(db, db.mem, db.db, start, prefix)
+}
 
-	return &iterator{
-		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+// NewSnapshot returns a snapshot that contains both the uncommitted changes of
+// this database and a snapshot of the underlying database.
+//
+// Returns [database.ErrSnapshotNotSupported] if the underlying database
+// doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+	snap, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
 	}
+	return &snapshot{
+		db:       db,
+		mem:      maps.Clone(db.mem),
+		snapshot: snap,
+	}, nil
 }
 
 func (db *Database) Compact(start, limit []byte) error {
@@ -160,6 +211,18 @@ func (db *Database) Compact(start, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
 
+// EstimateDiskUsage estimates the disk usage of the underlying database.
+// Uncommitted changes aren't included in the estimate.
+func (db *Database) EstimateDiskUsage(prefix []byte) (uint64, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return 0, database.ErrClosed
+	}
+	return database.EstimateDiskUsage(db.db, prefix)
+}
+
 // SetDatabase changes the underlying database to the specified database
 func (db *Database) SetDatabase(newDB database.Database) error {
 	db.lock.Lock()
@@ -209,6 +272,8 @@ func (db *Database) Abort() {
 
 func (db *Database) abort() {
 	clear(db.mem)
+	db.savepoints = nil
+	db.journal = nil
 }
 
 // CommitBatch returns a batch that contains all uncommitted puts/deletes.
@@ -253,6 +318,8 @@ func (db *Database) Close() error {
 	db.batch = nil
 	db.mem = nil
 	db.db = nil
+	db.savepoints = nil
+	db.journal = nil
 	return nil
 }
 
@@ -288,10 +355,10 @@ func (b *batch) Write() error {
 	}
 
 	for _, op := range b.Ops {
-		b.db.mem[string(op.Key)] = valueDelete{
+		b.db.set(string(op.Key), valueDelete{
 			value:  op.Value,
 			delete: op.Delete,
-		}
+		})
 	}
 	return nil
 }
@@ -300,6 +367,110 @@ func (b *batch) Inner() database.Batch {
 	return b
 }
 
+// snapshot is a frozen copy of the uncommitted changes layered on top of a
+// snapshot of the underlying database.
+type snapshot struct {
+	lock     sync.RWMutex
+	db       *Database
+	mem      map[string]valueDelete
+	snapshot database.Snapshot
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return slices.Clone(val.value), nil
+	}
+	return s.snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return newIterator(
+		s.db,
+		s.mem,
+		s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+		false,
+	)
+}
+
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithStart(start []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewReverseIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil || s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return newReverseIterator(s.db, s.mem, s.snapshot, start, prefix)
+}
+
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+	s.mem = nil
+	s.snapshot.Release()
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -312,7 +483,79 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
-	initialized, exhausted bool
+	// reverse is true if the iterator moves from the last key to the first.
+	reverse, initialized, exhausted bool
+}
+
+// newReverseIterator returns a reverse iterator that merges the entries of
+// [mem] into a reverse iterator over [iteratee].
+func newReverseIterator(
+	db *Database,
+	mem map[string]valueDelete,
+	iteratee database.Iteratee,
+	start,
+	prefix []byte,
+) database.Iterator {
+	reverseIteratee, ok := iteratee.(database.ReverseIteratee)
+	if !ok {
+		return &database.IteratorError{
+			Err: database.ErrReverseIterationNotSupported,
+		}
+	}
+	return newIterator(
+		db,
+		mem,
+		reverseIteratee.NewReverseIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+		true,
+	)
+}
+
+// newIterator returns an iterator that merges the entries of [mem] into
+// [inner]. If [reverse] is true, [inner] must be a reverse iterator and the
+// keys are returned in descending order. Assumes that [mem] will not be
+// modified while the iterator is being created.
+func newIterator(
+	db *Database,
+	mem map[string]valueDelete,
+	inner database.Iterator,
+	start,
+	prefix []byte,
+	reverse bool,
+) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if !strings.HasPrefix(key, prefixString) {
+			continue
+		}
+		// An empty [start] doesn't bound reverse iteration.
+		inRange := key >= startString
+		if reverse {
+			inRange = len(start) == 0 || key <= startString
+		}
+		if inRange {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys) // Keys need to be in sorted order
+	if reverse {
+		slices.Reverse(keys)
+	}
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+
+	return &iterator{
+		db:       db,
+		Iterator: inner,
+		keys:     keys,
+		values:   values,
+		reverse:  reverse,
+	}
 }
 
 // Next moves the iterator to the next key/value pair. It returns whether the
@@ -365,7 +608,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys[0] = ""
 				it.keys = it.keys[1:]
 				it.values[0].value = nil
@@ -376,7 +619,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
@@ -398,6 +641,14 @@ func (it *iterator) Next() bool {
 	}
 }
 
+// before returns true if [a] should be returned by the iterator before [b].
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
+}
+
 func (it *iterator) Error() error {
 	if it.err != nil {
 		return it.err
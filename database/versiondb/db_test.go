@@ -34,6 +34,51 @@ func FuzzNewIteratorWithStartAndPrefix(f *testing.F) {
 	database.FuzzNewIteratorWithStartAndPrefix(f, New(memdb.New()))
 }
 
+func FuzzNewReverseIteratorWithStartAndPrefix(f *testing.F) {
+	database.FuzzNewReverseIteratorWithStartAndPrefix(f, New(memdb.New()))
+}
+
+func TestReverseIterate(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.Commit())
+
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Delete(key3))
+
+	iterator := db.NewReverseIterator()
+	require.NotNil(iterator)
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+
+	require.NoError(iterator.Error())
+}
+
 func TestIterate(t *testing.T) {
 	require := require.New(t)
 
@@ -312,3 +357,123 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+func TestSavepointRollback(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+
+	sp, err := db.Savepoint()
+	require.NoError(err)
+
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Delete(key1))
+
+	require.NoError(db.RollbackTo(sp))
+
+	value, err := db.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err := db.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	// The savepoint remains active after rolling back to it.
+	require.NoError(db.Put(key2, value1))
+	require.NoError(db.RollbackTo(sp))
+
+	has, err = db.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	require.NoError(db.Commit())
+
+	value, err = baseDB.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	has, err = baseDB.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	// Commit discards all savepoints.
+	require.ErrorIs(db.RollbackTo(sp), ErrUnknownSavepoint)
+}
+
+func TestNestedSavepoints(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	key := []byte("hello")
+	value1 := []byte("world1")
+	value2 := []byte("world2")
+	value3 := []byte("world3")
+
+	sp1, err := db.Savepoint()
+	require.NoError(err)
+	require.NoError(db.Put(key, value1))
+
+	sp2, err := db.Savepoint()
+	require.NoError(err)
+	require.NoError(db.Put(key, value2))
+
+	sp3, err := db.Savepoint()
+	require.NoError(err)
+
+	batch := db.NewBatch()
+	require.NoError(batch.Put(key, value3))
+	require.NoError(batch.Write())
+
+	// Rolling back to sp2 discards sp3.
+	require.NoError(db.RollbackTo(sp2))
+	require.ErrorIs(db.RollbackTo(sp3), ErrUnknownSavepoint)
+
+	value, err := db.Get(key)
+	require.NoError(err)
+	require.Equal(value1, value)
+
+	// Releasing sp2 keeps its changes, which can still be undone by sp1.
+	require.NoError(db.Put(key, value2))
+	require.NoError(db.Release(sp2))
+	require.ErrorIs(db.Release(sp2), ErrUnknownSavepoint)
+
+	value, err = db.Get(key)
+	require.NoError(err)
+	require.Equal(value2, value)
+
+	require.NoError(db.RollbackTo(sp1))
+
+	has, err := db.Has(key)
+	require.NoError(err)
+	require.False(has)
+
+	require.NoError(db.Release(sp1))
+	require.Empty(db.journal)
+}
+
+func TestSavepointClosed(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	sp, err := db.Savepoint()
+	require.NoError(err)
+
+	require.NoError(db.Close())
+
+	_, err = db.Savepoint()
+	require.ErrorIs(err, database.ErrClosed)
+	require.ErrorIs(db.RollbackTo(sp), database.ErrClosed)
+	require.ErrorIs(db.Release(sp), database.ErrClosed)
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"errors"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+var ErrUnknownSavepoint = errors.New("unknown savepoint")
+
+// Savepoint identifies a point in the uncommitted changes of a [Database] that
+// can later be rolled back to.
+type Savepoint uint64
+
+type savepoint struct {
+	id Savepoint
+	// journalLen is the length of the journal when the savepoint was created.
+	journalLen int
+}
+
+type journalEntry struct {
+	key string
+	// prev is the value of [key] in memory prior to the modification. If
+	// [existed] is false, [key] wasn't in memory prior to the modification.
+	prev    valueDelete
+	existed bool
+}
+
+// Savepoint marks the current set of uncommitted changes. Changes made after
+// the savepoint can be undone with [RollbackTo] without undoing the changes
+// made before it.
+//
+// Savepoints are nested: creating a savepoint while another one is active
+// pushes a new savepoint onto the stack. All savepoints are discarded by
+// Commit and Abort.
+func (db *Database) Savepoint() (Savepoint, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return 0, database.ErrClosed
+	}
+
+	id := db.nextSavepoint
+	db.nextSavepoint++
+	db.savepoints = append(db.savepoints, savepoint{
+		id:         id,
+		journalLen: len(db.journal),
+	})
+	return id, nil
+}
+
+// RollbackTo undoes all changes made after [sp] was created. Savepoints created
+// after [sp] are discarded, but [sp] remains active so that it can be rolled
+// back to again.
+func (db *Database) RollbackTo(sp Savepoint) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+
+	index, ok := db.savepointIndex(sp)
+	if !ok {
+		return ErrUnknownSavepoint
+	}
+
+	journalLen := db.savepoints[index].journalLen
+	for i := len(db.journal) - 1; i >= journalLen; i-- {
+		entry := db.journal[i]
+		if entry.existed {
+			db.mem[entry.key] = entry.prev
+		} else {
+			delete(db.mem, entry.key)
+		}
+	}
+	clear(db.journal[journalLen:])
+	db.journal = db.journal[:journalLen]
+	db.savepoints = db.savepoints[:index+1]
+	return nil
+}
+
+// Release discards [sp] and all savepoints created after it while keeping the
+// changes made after them. The changes can still be undone by rolling back to
+// an earlier savepoint.
+func (db *Database) Release(sp Savepoint) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+
+	index, ok := db.savepointIndex(sp)
+	if !ok {
+		return ErrUnknownSavepoint
+	}
+
+	db.savepoints = db.savepoints[:index]
+	if len(db.savepoints) == 0 {
+		// Nothing can be rolled back anymore, so the journal is no longer
+		// needed.
+		db.journal = nil
+	}
+	return nil
+}
+
+// savepointIndex returns the index of [sp] in [db.savepoints].
+//
+// Assumes [db.lock] is held.
+func (db *Database) savepointIndex(sp Savepoint) (int, bool) {
+	// Savepoints are sorted by id, so the most recent savepoints are checked
+	// first.
+	for i := len(db.savepoints) - 1; i >= 0; i-- {
+		switch id := db.savepoints[i].id; {
+		case id == sp:
+			return i, true
+		case id < sp:
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// set writes [value] to [key] in memory, recording the previous value if there
+// is an active savepoint.
+//
+// Assumes [db.lock] is held.
+func (db *Database) set(key string, value valueDelete) {
+	if len(db.savepoints) != 0 {
+		prev, existed := db.mem[key]
+		db.journal = append(db.journal, journalEntry{
+			key:     key,
+			prev:    prev,
+			existed: existed,
+		})
+	}
+	db.mem[key] = value
+}
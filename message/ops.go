@@ -174,6 +174,16 @@ var (
 		CrossChainAppResponseOp,
 	}
 
+	// AppOps are the message types that are sent between VMs. Transports that
+	// support multiple streams send them separately from the other message
+	// types so that they can't block consensus.
+	AppOps = set.Of(
+		AppRequestOp,
+		AppErrorOp,
+		AppResponseOp,
+		AppGossipOp,
+	)
+
 	FailedToResponseOps = map[Op]Op{
 		GetStateSummaryFrontierFailedOp: StateSummaryFrontierOp,
 		GetAcceptedStateSummaryFailedOp: AcceptedStateSummaryOp,
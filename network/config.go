@@ -105,6 +105,10 @@ type Config struct {
 	ProxyEnabled           bool          `json:"proxyEnabled"`
 	ProxyReadHeaderTimeout time.Duration `json:"proxyReadHeaderTimeout"`
 
+	// QUICEnabled is true if peer connections should be made over QUIC when
+	// possible. Can't be used with ProxyEnabled.
+	QUICEnabled bool `json:"quicEnabled"`
+
 	DialerConfig dialer.Config `json:"dialerConfig"`
 	TLSConfig    *tls.Config   `json:"-"`
 
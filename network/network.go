@@ -33,6 +33,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/metric"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 	"github.com/MetalBlockchain/metalgo/version"
@@ -130,6 +131,9 @@ type network struct {
 	serverUpgrader peer.Upgrader
 	// Does TLS handshakes for outbound connections
 	clientUpgrader peer.Upgrader
+	// Identifies peers of connections that already did their TLS handshakes,
+	// such as QUIC connections
+	tlsStateUpgrader peer.Upgrader
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -200,12 +204,21 @@ func NewNetwork(
 		}
 	}
 
+	inboundMsgThrottlerConfig := config.ThrottlerConfig.InboundMsgThrottlerConfig
+	var appInboundMsgThrottlerConfig throttling.InboundMsgThrottlerConfig
+	if config.QUICEnabled {
+		// App messages that are read from QUIC connections are throttled
+		// separately, so the configured budgets are split between the
+		// throttlers rather than being given to both of them.
+		inboundMsgThrottlerConfig, appInboundMsgThrottlerConfig = inboundMsgThrottlerConfig.Split()
+	}
+
 	inboundMsgThrottler, err := throttling.NewInboundMsgThrottler(
 		log,
 		config.Namespace,
 		metricsRegisterer,
 		config.Validators,
-		config.ThrottlerConfig.InboundMsgThrottlerConfig,
+		inboundMsgThrottlerConfig,
 		config.ResourceTracker,
 		config.CPUTargeter,
 		config.DiskTargeter,
@@ -271,6 +284,24 @@ func NewNetwork(
 		UptimeCalculator:     config.UptimeCalculator,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
 	}
+	if config.QUICEnabled {
+		// App messages that are read from QUIC connections are throttled
+		// separately, so that waiting to read an app message doesn't block
+		// reading other messages from the same peer.
+		peerConfig.AppInboundMsgThrottler, err = throttling.NewInboundMsgThrottler(
+			log,
+			metric.AppendNamespace(config.Namespace, "app"),
+			metricsRegisterer,
+			config.Validators,
+			appInboundMsgThrottlerConfig,
+			config.ResourceTracker,
+			config.CPUTargeter,
+			config.DiskTargeter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("initializing app inbound message throttler failed with: %w", err)
+		}
+	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
 	n := &network{
@@ -284,6 +315,7 @@ func NewNetwork(
 		dialer:                      dialer,
 		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
 		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		tlsStateUpgrader:            peer.NewTLSStateUpgrader(metrics.tlsConnRejected),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -944,6 +976,10 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
 func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+	if _, ok := conn.(peer.TLSConn); ok {
+		upgrader = n.tlsStateUpgrader
+	}
+
 	upgradeTimeout := n.peerConfig.Clock.Time().Add(n.config.ReadHandshakeTimeout)
 	if err := conn.SetReadDeadline(upgradeTimeout); err != nil {
 		_ = conn.Close()
@@ -1032,19 +1068,38 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 	// peer.Start requires there is only ever one peer instance running with the
 	// same [peerConfig.InboundMsgThrottler]. This is guaranteed by the above
 	// de-duplications for [connectingPeers] and [connectedPeers].
-	peer := peer.Start(
-		n.peerConfig,
-		tlsConn,
-		cert,
+	messageQueue := peer.NewThrottledMessageQueue(
+		n.peerConfig.Metrics,
 		nodeID,
-		peer.NewThrottledMessageQueue(
-			n.peerConfig.Metrics,
-			nodeID,
-			n.peerConfig.Log,
-			n.outboundMsgThrottler,
-		),
+		n.peerConfig.Log,
+		n.outboundMsgThrottler,
 	)
-	n.connectingPeers.Add(peer)
+	var p peer.Peer
+	if appStreamer, ok := tlsConn.(peer.AppStreamer); ok {
+		p = peer.StartWithAppStream(
+			n.peerConfig,
+			tlsConn,
+			appStreamer.AppStream(),
+			cert,
+			nodeID,
+			messageQueue,
+			peer.NewThrottledMessageQueue(
+				n.peerConfig.Metrics,
+				nodeID,
+				n.peerConfig.Log,
+				n.outboundMsgThrottler,
+			),
+		)
+	} else {
+		p = peer.Start(
+			n.peerConfig,
+			tlsConn,
+			cert,
+			nodeID,
+			messageQueue,
+		)
+	}
+	n.connectingPeers.Add(p)
 	n.peersLock.Unlock()
 	return nil
 }
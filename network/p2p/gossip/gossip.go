@@ -17,6 +17,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/buffer"
@@ -25,11 +26,12 @@ import (
 )
 
 const (
-	typeLabel  = "type"
-	pushType   = "push"
-	pullType   = "pull"
-	unsentType = "unsent"
-	sentType   = "sent"
+	typeLabel     = "type"
+	pushType      = "push"
+	pullType      = "pull"
+	reconcileType = "reconcile"
+	unsentType    = "unsent"
+	sentType      = "sent"
 
 	defaultGossipableCount = 64
 )
@@ -37,6 +39,7 @@ const (
 var (
 	_ Gossiper = (*ValidatorGossiper)(nil)
 	_ Gossiper = (*PullGossiper[*testTx])(nil)
+	_ Gossiper = (*ReconcileGossiper[*testTx])(nil)
 	_ Gossiper = (*NoOpGossiper)(nil)
 
 	_ Set[*testTx] = (*EmptySet[*testTx])(nil)
@@ -49,6 +52,9 @@ var (
 	pullLabels = prometheus.Labels{
 		typeLabel: pullType,
 	}
+	reconcileLabels = prometheus.Labels{
+		typeLabel: reconcileType,
+	}
 	unsentLabels = prometheus.Labels{
 		typeLabel: unsentType,
 	}
@@ -73,6 +79,39 @@ type Gossiper interface {
 	Gossip(ctx context.Context) error
 }
 
+// Option configures a gossip handler or gossiper
+type Option interface {
+	apply(options *options)
+}
+
+type optionFunc func(options *options)
+
+func (o optionFunc) apply(options *options) {
+	o(options)
+}
+
+// WithReputation reports peers that send malformed gossip to [reputation].
+func WithReputation(reputation reputation.Reporter) Option {
+	return optionFunc(func(options *options) {
+		options.reputation = reputation
+	})
+}
+
+type options struct {
+	reputation reputation.Reporter
+}
+
+func newOptions(opts []Option) options {
+	options := options{}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+	if options.reputation == nil {
+		options.reputation = reputation.NewNoTracker()
+	}
+	return options
+}
+
 // ValidatorGossiper only calls [Gossip] if the given node is a validator
 type ValidatorGossiper struct {
 	Gossiper
@@ -162,7 +201,9 @@ func NewPullGossiper[T Gossipable](
 	client *p2p.Client,
 	metrics Metrics,
 	pollSize int,
+	opts ...Option,
 ) *PullGossiper[T] {
+	options := newOptions(opts)
 	return &PullGossiper[T]{
 		log:        log,
 		marshaller: marshaller,
@@ -170,6 +211,7 @@ func NewPullGossiper[T Gossipable](
 		client:     client,
 		metrics:    metrics,
 		pollSize:   pollSize,
+		reputation: options.reputation,
 	}
 }
 
@@ -180,6 +222,7 @@ type PullGossiper[T Gossipable] struct {
 	client     *p2p.Client
 	metrics    Metrics
 	pollSize   int
+	reputation reputation.Reporter
 }
 
 func (p *PullGossiper[_]) Gossip(ctx context.Context) error {
@@ -216,31 +259,48 @@ func (p *PullGossiper[_]) handleResponse(
 	gossip, err := ParseAppResponse(responseBytes)
 	if err != nil {
 		p.log.Debug("failed to unmarshal gossip response", zap.Error(err))
+		p.reputation.Report(nodeID, reputation.InvalidGossip)
 		return
 	}
 
+	addGossip(p.log, p.marshaller, p.set, p.metrics, p.reputation, pullLabels, nodeID, gossip)
+}
+
+// addGossip adds the pulled [gossip] that was received from [nodeID] to [set].
+// [nodeID] is reported to [reporter] if it sent malformed gossip.
+func addGossip[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	reporter reputation.Reporter,
+	labels prometheus.Labels,
+	nodeID ids.NodeID,
+	gossip [][]byte,
+) {
 	receivedBytes := 0
 	for _, bytes := range gossip {
 		receivedBytes += len(bytes)
 
-		gossipable, err := p.marshaller.UnmarshalGossip(bytes)
+		gossipable, err := marshaller.UnmarshalGossip(bytes)
 		if err != nil {
-			p.log.Debug(
+			log.Debug(
 				"failed to unmarshal gossip",
 				zap.Stringer("nodeID", nodeID),
 				zap.Error(err),
 			)
+			reporter.Report(nodeID, reputation.InvalidGossip)
 			continue
 		}
 
 		gossipID := gossipable.GossipID()
-		p.log.Debug(
+		log.Debug(
 			"received gossip",
 			zap.Stringer("nodeID", nodeID),
 			zap.Stringer("id", gossipID),
 		)
-		if err := p.set.Add(gossipable); err != nil {
-			p.log.Debug(
+		if err := set.Add(gossipable); err != nil {
+			log.Debug(
 				"failed to add gossip to the known set",
 				zap.Stringer("nodeID", nodeID),
 				zap.Stringer("id", gossipID),
@@ -250,15 +310,15 @@ func (p *PullGossiper[_]) handleResponse(
 		}
 	}
 
-	receivedCountMetric, err := p.metrics.receivedCount.GetMetricWith(pullLabels)
+	receivedCountMetric, err := metrics.receivedCount.GetMetricWith(labels)
 	if err != nil {
-		p.log.Error("failed to get received count metric", zap.Error(err))
+		log.Error("failed to get received count metric", zap.Error(err))
 		return
 	}
 
-	receivedBytesMetric, err := p.metrics.receivedBytes.GetMetricWith(pullLabels)
+	receivedBytesMetric, err := metrics.receivedBytes.GetMetricWith(labels)
 	if err != nil {
-		p.log.Error("failed to get received bytes metric", zap.Error(err))
+		log.Error("failed to get received bytes metric", zap.Error(err))
 		return
 	}
 
@@ -5,6 +5,7 @@ package gossip
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -18,8 +19,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/iblt"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/units"
@@ -184,6 +187,400 @@ func TestGossiperGossip(t *testing.T) {
 	}
 }
 
+func TestNewReconcileGossiper(t *testing.T) {
+	tests := []struct {
+		name         string
+		minTableSize int
+		maxTableSize int
+		expected     error
+	}{
+		{
+			name:         "invalid min table size",
+			minTableSize: 0,
+			maxTableSize: 1,
+			expected:     ErrInvalidMinTableSize,
+		},
+		{
+			name:         "invalid max table size",
+			minTableSize: 2,
+			maxTableSize: 1,
+			expected:     ErrInvalidMaxTableSize,
+		},
+		{
+			name:         "valid",
+			minTableSize: 1,
+			maxTableSize: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReconcileGossiper[*testTx](
+				logging.NoLog{},
+				nil,
+				nil,
+				nil,
+				Metrics{},
+				1,
+				tt.minTableSize,
+				tt.maxTableSize,
+			)
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestReconcileGossiperGossip(t *testing.T) {
+	shared := make([]*testTx, 100)
+	for i := range shared {
+		shared[i] = &testTx{id: ids.GenerateTestID()}
+	}
+	missing := make([]*testTx, 50)
+	for i := range missing {
+		missing[i] = &testTx{id: ids.GenerateTestID()}
+	}
+
+	tests := []struct {
+		name                   string
+		targetResponseSize     int
+		tableSize              int
+		requester              []*testTx // what we have
+		responder              []*testTx // what the peer we're requesting gossip from has
+		expectedPossibleValues []*testTx // possible values we can have
+		expectedLen            int
+		expectedTableSize      int
+	}{
+		{
+			name:              "no gossip - no one knows anything",
+			tableSize:         iblt.NumCells(100),
+			expectedTableSize: iblt.NumCells(0),
+		},
+		{
+			name:                   "no gossip - requester knows more than responder",
+			targetResponseSize:     1024,
+			tableSize:              iblt.NumCells(100),
+			requester:              []*testTx{{id: ids.ID{0}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}},
+			expectedLen:            1,
+			expectedTableSize:      iblt.NumCells(1),
+		},
+		{
+			name:                   "no gossip - requester knows everything responder knows",
+			targetResponseSize:     1024,
+			tableSize:              iblt.NumCells(100),
+			requester:              shared,
+			responder:              shared,
+			expectedPossibleValues: shared,
+			expectedLen:            len(shared),
+			expectedTableSize:      iblt.NumCells(0),
+		},
+		{
+			name:                   "gossip - requester knows less than responder",
+			targetResponseSize:     1024,
+			tableSize:              iblt.NumCells(100),
+			requester:              []*testTx{{id: ids.ID{0}}},
+			responder:              []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			expectedLen:            2,
+			expectedTableSize:      iblt.NumCells(1),
+		},
+		{
+			name:                   "gossip - target response size exceeded",
+			targetResponseSize:     32,
+			tableSize:              iblt.NumCells(100),
+			responder:              []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}, {id: ids.ID{2}}},
+			expectedPossibleValues: []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}, {id: ids.ID{2}}},
+			expectedLen:            2,
+			expectedTableSize:      iblt.NumCells(3),
+		},
+		{
+			name:                   "no gossip - table too small to decode",
+			targetResponseSize:     units.MiB,
+			tableSize:              iblt.NumHashes,
+			requester:              shared,
+			responder:              append(missing, shared...),
+			expectedPossibleValues: shared,
+			expectedLen:            len(shared),
+			expectedTableSize:      2 * iblt.NumHashes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			responseSender := &common.FakeSender{
+				SentAppResponse: make(chan []byte, 1),
+			}
+			responseNetwork, err := p2p.NewNetwork(logging.NoLog{}, responseSender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+
+			responseBloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+			require.NoError(err)
+			responseSet := &testSet{
+				txs:   make(map[ids.ID]*testTx),
+				bloom: responseBloom,
+			}
+			for _, item := range tt.responder {
+				require.NoError(responseSet.Add(item))
+			}
+
+			metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+			require.NoError(err)
+			marshaller := testMarshaller{}
+			handler := NewReconcileHandler[*testTx](
+				logging.NoLog{},
+				marshaller,
+				responseSet,
+				metrics,
+				tt.targetResponseSize,
+				units.KiB,
+			)
+			require.NoError(responseNetwork.AddHandler(0x0, handler))
+
+			requestSender := &common.FakeSender{
+				SentAppRequest: make(chan []byte, 1),
+			}
+
+			requestNetwork, err := p2p.NewNetwork(logging.NoLog{}, requestSender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+			require.NoError(requestNetwork.Connected(context.Background(), ids.EmptyNodeID, nil))
+
+			bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+			require.NoError(err)
+			requestSet := &testSet{
+				txs:   make(map[ids.ID]*testTx),
+				bloom: bloom,
+			}
+			for _, item := range tt.requester {
+				require.NoError(requestSet.Add(item))
+			}
+
+			requestClient := requestNetwork.NewClient(0x0)
+
+			gossiper, err := NewReconcileGossiper[*testTx](
+				logging.NoLog{},
+				marshaller,
+				requestSet,
+				requestClient,
+				metrics,
+				1,
+				iblt.NumHashes,
+				units.KiB,
+			)
+			require.NoError(err)
+			// Decoding can fail with a small probability, so the table starts
+			// out much larger than the difference unless the test expects
+			// decoding to fail.
+			gossiper.tableSize = tt.tableSize
+			received := set.Set[*testTx]{}
+			requestSet.onAdd = func(tx *testTx) {
+				received.Add(tx)
+			}
+
+			require.NoError(gossiper.Gossip(ctx))
+			require.NoError(responseNetwork.AppRequest(ctx, ids.EmptyNodeID, 1, time.Time{}, <-requestSender.SentAppRequest))
+			require.NoError(requestNetwork.AppResponse(ctx, ids.EmptyNodeID, 1, <-responseSender.SentAppResponse))
+
+			require.Len(requestSet.txs, tt.expectedLen)
+			require.Subset(tt.expectedPossibleValues, maps.Values(requestSet.txs))
+			require.Equal(tt.expectedTableSize, gossiper.tableSize)
+
+			// we should not receive anything that we already had before we
+			// requested the gossip
+			for _, tx := range tt.requester {
+				require.NotContains(received, tx)
+			}
+		})
+	}
+}
+
+func TestReconcileHandlerTableTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	handler := NewReconcileHandler[*testTx](
+		logging.NoLog{},
+		testMarshaller{},
+		&testSet{},
+		metrics,
+		units.KiB,
+		iblt.NumHashes,
+	)
+
+	table, err := iblt.New(2*iblt.NumHashes, ids.Empty)
+	require.NoError(err)
+	requestBytes, err := MarshalReconcileRequest(table.Marshal(), ids.Empty[:])
+	require.NoError(err)
+
+	_, err = handler.AppRequest(context.Background(), ids.EmptyNodeID, time.Time{}, requestBytes)
+	require.ErrorIs(err, errTableTooLarge)
+}
+
+func TestHandlerReportsMalformedGossip(t *testing.T) {
+	require := require.New(t)
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	tracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife: time.Hour,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	handler := NewHandler[*testTx](
+		logging.NoLog{},
+		testMarshaller{},
+		&testSet{},
+		metrics,
+		units.KiB,
+		WithReputation(tracker),
+	)
+
+	gossipBytes, err := MarshalAppGossip([][]byte{{1, 2, 3}})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	handler.AppGossip(context.Background(), nodeID, gossipBytes)
+	require.Less(tracker.Score(nodeID), 1.0)
+}
+
+// BenchmarkPullGossip compares the bandwidth used by a round of pull gossip
+// when the requester is missing a small part of a large set.
+func BenchmarkPullGossip(b *testing.B) {
+	const targetResponseSize = units.MiB
+
+	for _, size := range []int{1_000, 10_000} {
+		for _, difference := range []int{1, 10, 100} {
+			shared := make([]*testTx, size-difference)
+			for i := range shared {
+				shared[i] = &testTx{id: ids.GenerateTestID()}
+			}
+			missing := make([]*testTx, difference)
+			for i := range missing {
+				missing[i] = &testTx{id: ids.GenerateTestID()}
+			}
+
+			b.Run(fmt.Sprintf("bloom/size=%d/difference=%d", size, difference), func(b *testing.B) {
+				benchmarkPullGossip(
+					b,
+					shared,
+					missing,
+					func(log logging.Logger, set *testSet, metrics Metrics) p2p.Handler {
+						return NewHandler[*testTx](log, testMarshaller{}, set, metrics, targetResponseSize)
+					},
+					func(log logging.Logger, set *testSet, client *p2p.Client, metrics Metrics) (Gossiper, func(*testSet)) {
+						gossiper := NewPullGossiper[*testTx](log, testMarshaller{}, set, client, metrics, 1)
+						return gossiper, func(set *testSet) {
+							gossiper.set = set
+						}
+					},
+				)
+			})
+
+			b.Run(fmt.Sprintf("reconcile/size=%d/difference=%d", size, difference), func(b *testing.B) {
+				const maxTableSize = 16 * units.KiB
+
+				benchmarkPullGossip(
+					b,
+					shared,
+					missing,
+					func(log logging.Logger, set *testSet, metrics Metrics) p2p.Handler {
+						return NewReconcileHandler[*testTx](log, testMarshaller{}, set, metrics, targetResponseSize, maxTableSize)
+					},
+					func(log logging.Logger, set *testSet, client *p2p.Client, metrics Metrics) (Gossiper, func(*testSet)) {
+						gossiper, err := NewReconcileGossiper[*testTx](log, testMarshaller{}, set, client, metrics, 1, iblt.NumCells(0), maxTableSize)
+						require.NoError(b, err)
+						return gossiper, func(set *testSet) {
+							gossiper.set = set
+						}
+					},
+				)
+			})
+		}
+	}
+}
+
+// benchmarkPullGossip reports the bytes sent in a round of gossip from a
+// requester that has [shared] to a responder that has [shared] and [missing].
+//
+// The gossiper is reused across rounds so that it can adapt to the difference
+// between the sets.
+func benchmarkPullGossip(
+	b *testing.B,
+	shared []*testTx,
+	missing []*testTx,
+	newHandler func(logging.Logger, *testSet, Metrics) p2p.Handler,
+	newGossiper func(logging.Logger, *testSet, *p2p.Client, Metrics) (Gossiper, func(*testSet)),
+) {
+	require := require.New(b)
+	ctx := context.Background()
+
+	newSet := func(txs ...[]*testTx) *testSet {
+		bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", len(shared)+len(missing), 0.01, 0.05)
+		require.NoError(err)
+		set := &testSet{
+			txs:   make(map[ids.ID]*testTx),
+			bloom: bloom,
+		}
+		for _, txs := range txs {
+			for _, tx := range txs {
+				require.NoError(set.Add(tx))
+			}
+		}
+		return set
+	}
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	responseSender := &common.FakeSender{
+		SentAppResponse: make(chan []byte, 1),
+	}
+	responseNetwork, err := p2p.NewNetwork(logging.NoLog{}, responseSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(responseNetwork.AddHandler(0x0, newHandler(logging.NoLog{}, newSet(shared, missing), metrics)))
+
+	requestSender := &common.FakeSender{
+		SentAppRequest: make(chan []byte, 1),
+	}
+	requestNetwork, err := p2p.NewNetwork(logging.NoLog{}, requestSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(requestNetwork.Connected(ctx, ids.EmptyNodeID, nil))
+
+	gossiper, setRequestSet := newGossiper(logging.NoLog{}, newSet(), requestNetwork.NewClient(0x0), metrics)
+
+	var (
+		// The p2p client allocates odd request IDs
+		requestID uint32 = 1
+		sentBytes int
+		received  int
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		requestSet := newSet(shared)
+		setRequestSet(requestSet)
+		b.StartTimer()
+
+		require.NoError(gossiper.Gossip(ctx))
+		requestBytes := <-requestSender.SentAppRequest
+		require.NoError(responseNetwork.AppRequest(ctx, ids.EmptyNodeID, requestID, time.Time{}, requestBytes))
+		responseBytes := <-responseSender.SentAppResponse
+		require.NoError(requestNetwork.AppResponse(ctx, ids.EmptyNodeID, requestID, responseBytes))
+		requestID += 2
+
+		sentBytes += len(requestBytes) + len(responseBytes)
+		received += len(requestSet.txs) - len(shared)
+	}
+
+	b.ReportMetric(float64(sentBytes)/float64(b.N), "bytes/op")
+	b.ReportMetric(float64(received)/float64(b.N), "received/op")
+}
+
 func TestEvery(*testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	calls := 0
@@ -12,6 +12,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
@@ -24,7 +25,9 @@ func NewHandler[T Gossipable](
 	set Set[T],
 	metrics Metrics,
 	targetResponseSize int,
+	opts ...Option,
 ) *Handler[T] {
+	options := newOptions(opts)
 	return &Handler[T]{
 		Handler:            p2p.NoOpHandler{},
 		log:                log,
@@ -32,6 +35,7 @@ func NewHandler[T Gossipable](
 		set:                set,
 		metrics:            metrics,
 		targetResponseSize: targetResponseSize,
+		reputation:         options.reputation,
 	}
 }
 
@@ -42,6 +46,7 @@ type Handler[T Gossipable] struct {
 	set                Set[T]
 	metrics            Metrics
 	targetResponseSize int
+	reputation         reputation.Reporter
 }
 
 func (h Handler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
@@ -98,6 +103,7 @@ func (h Handler[_]) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes
 	gossip, err := ParseAppGossip(gossipBytes)
 	if err != nil {
 		h.log.Debug("failed to unmarshal gossip", zap.Error(err))
+		h.reputation.Report(nodeID, reputation.InvalidGossip)
 		return
 	}
 
@@ -110,6 +116,7 @@ func (h Handler[_]) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes
 				zap.Stringer("nodeID", nodeID),
 				zap.Error(err),
 			)
+			h.reputation.Report(nodeID, reputation.InvalidGossip)
 			continue
 		}
 
@@ -9,6 +9,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
+	"github.com/MetalBlockchain/metalgo/utils/iblt"
 )
 
 func MarshalAppRequest(filter, salt []byte) ([]byte, error) {
@@ -57,3 +58,39 @@ func ParseAppGossip(bytes []byte) ([][]byte, error) {
 	err := proto.Unmarshal(bytes, msg)
 	return msg.Gossip, err
 }
+
+func MarshalReconcileRequest(table, salt []byte) ([]byte, error) {
+	return proto.Marshal(&sdk.ReconcileGossipRequest{
+		Salt:  salt,
+		Table: table,
+	})
+}
+
+func ParseReconcileRequest(bytes []byte) (*iblt.Table, ids.ID, error) {
+	request := &sdk.ReconcileGossipRequest{}
+	if err := proto.Unmarshal(bytes, request); err != nil {
+		return nil, ids.Empty, err
+	}
+
+	salt, err := ids.ToID(request.Salt)
+	if err != nil {
+		return nil, ids.Empty, err
+	}
+
+	table, err := iblt.Parse(request.Table, salt)
+	return table, salt, err
+}
+
+func MarshalReconcileResponse(gossip [][]byte, decoded bool, difference int) ([]byte, error) {
+	return proto.Marshal(&sdk.ReconcileGossipResponse{
+		Gossip:     gossip,
+		Decoded:    decoded,
+		Difference: uint64(difference),
+	})
+}
+
+func ParseReconcileResponse(bytes []byte) (*sdk.ReconcileGossipResponse, error) {
+	response := &sdk.ReconcileGossipResponse{}
+	err := proto.Unmarshal(bytes, response)
+	return response, err
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
+	"github.com/MetalBlockchain/metalgo/utils/iblt"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var (
+	_ p2p.Handler = (*ReconcileHandler[*testTx])(nil)
+
+	ErrInvalidMinTableSize = errors.New("min table size must be positive")
+	ErrInvalidMaxTableSize = errors.New("max table size must be at least the min table size")
+
+	errTableTooLarge = errors.New("table too large")
+)
+
+// NewReconcileGossiper returns a gossiper that pulls the gossip that it is
+// missing from peers by sending them an invertible bloom lookup table of its
+// gossip IDs. Unlike the bloom filter sent by [PullGossiper], the size of the
+// table is proportional to the difference between the sets rather than to the
+// size of the sets, which makes it cheaper when the sets are large and mostly
+// shared.
+//
+// The table starts with [minTableSize] cells. If a peer fails to decode the
+// table, the number of cells is doubled, up to [maxTableSize]. Otherwise, the
+// table is sized to the difference reported by the peer.
+func NewReconcileGossiper[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	client *p2p.Client,
+	metrics Metrics,
+	pollSize int,
+	minTableSize int,
+	maxTableSize int,
+	opts ...Option,
+) (*ReconcileGossiper[T], error) {
+	switch {
+	case minTableSize <= 0:
+		return nil, ErrInvalidMinTableSize
+	case maxTableSize < minTableSize:
+		return nil, ErrInvalidMaxTableSize
+	}
+
+	options := newOptions(opts)
+	return &ReconcileGossiper[T]{
+		log:          log,
+		marshaller:   marshaller,
+		set:          set,
+		client:       client,
+		metrics:      metrics,
+		pollSize:     pollSize,
+		minTableSize: minTableSize,
+		maxTableSize: maxTableSize,
+		reputation:   options.reputation,
+		tableSize:    minTableSize,
+	}, nil
+}
+
+type ReconcileGossiper[T Gossipable] struct {
+	log          logging.Logger
+	marshaller   Marshaller[T]
+	set          Set[T]
+	client       *p2p.Client
+	metrics      Metrics
+	pollSize     int
+	minTableSize int
+	maxTableSize int
+	reputation   reputation.Reporter
+
+	lock      sync.Mutex
+	tableSize int
+}
+
+func (r *ReconcileGossiper[T]) Gossip(ctx context.Context) error {
+	r.lock.Lock()
+	tableSize := r.tableSize
+	r.lock.Unlock()
+
+	var salt ids.ID
+	if _, err := rand.Read(salt[:]); err != nil {
+		return err
+	}
+	table, err := iblt.New(tableSize, salt)
+	if err != nil {
+		return err
+	}
+	r.set.Iterate(func(gossipable T) bool {
+		table.Add(gossipable.GossipID())
+		return true
+	})
+
+	msgBytes, err := MarshalReconcileRequest(table.Marshal(), salt[:])
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < r.pollSize; i++ {
+		err := r.client.AppRequestAny(ctx, msgBytes, r.handleResponse)
+		if err != nil && !errors.Is(err, p2p.ErrNoPeers) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ReconcileGossiper[_]) handleResponse(
+	_ context.Context,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	if err != nil {
+		r.log.Debug(
+			"failed gossip request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	response, err := ParseReconcileResponse(responseBytes)
+	if err != nil {
+		r.log.Debug("failed to unmarshal gossip response", zap.Error(err))
+		r.reputation.Report(nodeID, reputation.InvalidGossip)
+		return
+	}
+
+	r.lock.Lock()
+	if response.Decoded {
+		difference := int(min(response.Difference, uint64(r.maxTableSize)))
+		r.tableSize = min(r.maxTableSize, max(r.minTableSize, iblt.NumCells(difference)))
+	} else {
+		r.tableSize = min(r.maxTableSize, 2*r.tableSize)
+	}
+	r.lock.Unlock()
+
+	addGossip(r.log, r.marshaller, r.set, r.metrics, r.reputation, reconcileLabels, nodeID, response.Gossip)
+}
+
+// NewReconcileHandler returns a handler that responds to the requests of a
+// [ReconcileGossiper]. Requests with tables of more than [maxTableSize] cells
+// are dropped.
+func NewReconcileHandler[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	targetResponseSize int,
+	maxTableSize int,
+) *ReconcileHandler[T] {
+	return &ReconcileHandler[T]{
+		Handler:            p2p.NoOpHandler{},
+		log:                log,
+		marshaller:         marshaller,
+		set:                set,
+		metrics:            metrics,
+		targetResponseSize: targetResponseSize,
+		maxTableSize:       maxTableSize,
+	}
+}
+
+type ReconcileHandler[T Gossipable] struct {
+	p2p.Handler
+	marshaller         Marshaller[T]
+	log                logging.Logger
+	set                Set[T]
+	metrics            Metrics
+	targetResponseSize int
+	maxTableSize       int
+}
+
+func (h ReconcileHandler[T]) AppRequest(_ context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+	remote, salt, err := ParseReconcileRequest(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+	if remote.Len() > h.maxTableSize {
+		return nil, fmt.Errorf("%w: %d > %d", errTableTooLarge, remote.Len(), h.maxTableSize)
+	}
+
+	local, err := iblt.New(remote.Len(), salt)
+	if err != nil {
+		return nil, err
+	}
+	h.set.Iterate(func(gossipable T) bool {
+		local.Add(gossipable.GossipID())
+		return true
+	})
+	if err := remote.Subtract(local); err != nil {
+		return nil, err
+	}
+
+	// Even if the table couldn't be fully decoded, the requesting peer is
+	// still missing the gossip that was decoded.
+	onlyRemote, onlyLocal, decoded := remote.Decode()
+	missing := set.Of(onlyLocal...)
+
+	responseSize := 0
+	gossipBytes := make([][]byte, 0, missing.Len())
+	if missing.Len() > 0 {
+		h.set.Iterate(func(gossipable T) bool {
+			if !missing.Contains(gossipable.GossipID()) {
+				return true
+			}
+
+			var bytes []byte
+			bytes, err = h.marshaller.MarshalGossip(gossipable)
+			if err != nil {
+				return false
+			}
+
+			// check that this doesn't exceed our maximum configured target
+			// response size
+			gossipBytes = append(gossipBytes, bytes)
+			responseSize += len(bytes)
+
+			return responseSize <= h.targetResponseSize
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	sentCountMetric, err := h.metrics.sentCount.GetMetricWith(reconcileLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sent count metric: %w", err)
+	}
+
+	sentBytesMetric, err := h.metrics.sentBytes.GetMetricWith(reconcileLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sent bytes metric: %w", err)
+	}
+
+	sentCountMetric.Add(float64(len(gossipBytes)))
+	sentBytesMetric.Add(float64(responseSize))
+
+	return MarshalReconcileResponse(gossipBytes, decoded, len(onlyRemote)+len(onlyLocal))
+}
@@ -13,6 +13,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/heap"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -38,6 +39,9 @@ const (
 // Tracks the bandwidth of responses coming from peers,
 // preferring to contact peers with known good bandwidth, connecting
 // to new peers with an exponentially decaying probability.
+//
+// The bandwidth of a peer is weighted by its reputation, so peers with a bad
+// reputation are less likely to be selected.
 type PeerTracker struct {
 	// Lock to protect concurrent access to the peer tracker
 	lock sync.RWMutex
@@ -61,6 +65,7 @@ type PeerTracker struct {
 	// The below fields are assumed to be constant and are not protected by the
 	// lock.
 	log          logging.Logger
+	reputation   reputation.Scorer
 	ignoredNodes set.Set[ids.NodeID]
 	minVersion   *version.Application
 	metrics      peerTrackerMetrics
@@ -72,12 +77,18 @@ type peerTrackerMetrics struct {
 	averageBandwidth   prometheus.Gauge
 }
 
+// PeerTrackerOption configures PeerTracker
+type PeerTrackerOption interface {
+	applyPeerTracker(peerTracker *PeerTracker)
+}
+
 func NewPeerTracker(
 	log logging.Logger,
 	metricsNamespace string,
 	registerer prometheus.Registerer,
 	ignoredNodes set.Set[ids.NodeID],
 	minVersion *version.Application,
+	options ...PeerTrackerOption,
 ) (*PeerTracker, error) {
 	t := &PeerTracker{
 		peerBandwidth: make(map[ids.NodeID]safemath.Averager),
@@ -112,6 +123,12 @@ func NewPeerTracker(
 			),
 		},
 	}
+	for _, option := range options {
+		option.applyPeerTracker(t)
+	}
+	if t.reputation == nil {
+		t.reputation = reputation.NewNoTracker()
+	}
 
 	err := utils.Err(
 		registerer.Register(t.metrics.numTrackedPeers),
@@ -250,12 +267,15 @@ func (p *PeerTracker) updateBandwidth(nodeID ids.NodeID, bandwidth float64, resp
 		return
 	}
 
-	now := time.Now()
+	var (
+		now               = time.Now()
+		weightedBandwidth = bandwidth * p.reputation.Score(nodeID)
+	)
 	peerBandwidth, ok := p.peerBandwidth[nodeID]
 	if ok {
-		peerBandwidth.Observe(bandwidth, now)
+		peerBandwidth.Observe(weightedBandwidth, now)
 	} else {
-		peerBandwidth = safemath.NewAverager(bandwidth, bandwidthHalflife, now)
+		peerBandwidth = safemath.NewAverager(weightedBandwidth, bandwidthHalflife, now)
 		p.peerBandwidth[nodeID] = peerBandwidth
 	}
 	p.bandwidthHeap.Push(nodeID, peerBandwidth)
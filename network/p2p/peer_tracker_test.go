@@ -5,11 +5,13 @@ package p2p
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/version"
 )
@@ -102,3 +104,45 @@ func TestPeerTracker(t *testing.T) {
 	require.True(ok)
 	require.Falsef(responsive, "expected connecting to a non-responsive peer, but got a peer that was responsive: peer %s", peer)
 }
+
+func TestPeerTrackerReputation(t *testing.T) {
+	require := require.New(t)
+
+	tracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife: time.Hour,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+		WithReputation(tracker),
+	)
+	require.NoError(err)
+
+	goodNodeID := ids.GenerateTestNodeID()
+	badNodeID := ids.GenerateTestNodeID()
+	tracker.Report(badNodeID, reputation.InvalidBlock)
+
+	for _, nodeID := range []ids.NodeID{goodNodeID, badNodeID} {
+		p.Connected(nodeID, nil)
+		p.RegisterRequest(nodeID)
+	}
+
+	// The peer with a bad reputation has a higher bandwidth, but it's weighted
+	// below the bandwidth of the peer with a good reputation.
+	p.RegisterResponse(goodNodeID, 10)
+	p.RegisterResponse(badNodeID, 15)
+
+	nodeID, bandwidth, ok := p.bandwidthHeap.Peek()
+	require.True(ok)
+	require.Equal(goodNodeID, nodeID)
+	require.InDelta(10, bandwidth.Read(), 1e-6)
+	require.InDelta(7.5, p.peerBandwidth[badNodeID].Read(), 1e-6)
+}
@@ -74,6 +74,7 @@ type router struct {
 	pendingAppRequests           map[uint32]pendingAppRequest
 	pendingCrossChainAppRequests map[uint32]pendingCrossChainAppRequest
 	requestID                    uint32
+	streamID                     uint64
 }
 
 // newRouter returns a new instance of Router
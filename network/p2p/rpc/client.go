@@ -0,0 +1,144 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// NewClient returns a Client that calls the methods of the Server that is
+// registered with the handler ID of [client].
+//
+// Calls time out after [timeout], unless their context has an earlier
+// deadline.
+func NewClient(
+	client *p2p.Client,
+	timeout time.Duration,
+	registerer prometheus.Registerer,
+	namespace string,
+) (*Client, error) {
+	metrics, err := newMetrics(registerer, namespace, "sent")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		client:  client,
+		timeout: timeout,
+		metrics: metrics,
+	}, nil
+}
+
+// Client calls the methods of a Server.
+type Client struct {
+	client  *p2p.Client
+	timeout time.Duration
+	metrics metrics
+}
+
+type result struct {
+	responseBytes []byte
+	err           error
+}
+
+// Call calls [method] of [nodeID] with [request] and waits for the response.
+//
+// If the method fails, the returned error is the *common.AppError returned by
+// the server, which can be matched with errors.Is.
+func Call[Req, Resp proto.Message](
+	ctx context.Context,
+	c *Client,
+	nodeID ids.NodeID,
+	method Method[Req, Resp],
+	request Req,
+) (Resp, error) {
+	var zero Resp
+	start := time.Now()
+
+	response, err := call(ctx, c, nodeID, method, request)
+	if err := c.metrics.observe(method.Name, start, err != nil); err != nil {
+		return zero, err
+	}
+	return response, err
+}
+
+func call[Req, Resp proto.Message](
+	ctx context.Context,
+	c *Client,
+	nodeID ids.NodeID,
+	method Method[Req, Resp],
+	request Req,
+) (Resp, error) {
+	var zero Resp
+
+	methodRequestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return zero, err
+	}
+	requestBytes, err := proto.Marshal(&sdk.RPCRequest{
+		Method:  method.ID,
+		Request: methodRequestBytes,
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// The callback may be called after the call has timed out, so it must not
+	// block.
+	results := make(chan result, 1)
+	err = c.client.AppRequest(
+		ctx,
+		set.Of(nodeID),
+		requestBytes,
+		func(_ context.Context, _ ids.NodeID, responseBytes []byte, err error) {
+			results <- result{
+				responseBytes: responseBytes,
+				err:           err,
+			}
+		},
+	)
+	if err != nil {
+		return zero, err
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+	if res.err != nil {
+		return zero, res.err
+	}
+
+	response := &sdk.RPCResponse{}
+	if err := proto.Unmarshal(res.responseBytes, response); err != nil {
+		return zero, err
+	}
+	if response.Error != nil {
+		return zero, &common.AppError{
+			Code:    response.Error.Code,
+			Message: response.Error.Message,
+		}
+	}
+
+	methodResponse := newMessage[Resp]()
+	if err := proto.Unmarshal(response.Response, methodResponse); err != nil {
+		return zero, err
+	}
+	return methodResponse, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package rpc implements typed request/response methods on top of p2p.Handler.
+//
+// Methods are declared once with their protobuf request and response types:
+//
+//	var GetBlock = rpc.Method[*pb.GetBlockRequest, *pb.GetBlockResponse]{
+//		ID:   0,
+//		Name: "get_block",
+//	}
+//
+// The method is served by registering a typed handler with a Server, which is
+// added to a p2p.Network, and is called with Call on a Client of the same
+// handler ID.
+package rpc
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+)
+
+var (
+	// ErrUnknownMethod is returned if the server doesn't have the requested
+	// method.
+	ErrUnknownMethod = &common.AppError{
+		Code:    -2,
+		Message: "unknown method",
+	}
+	// ErrInvalidRequest is returned if the request couldn't be parsed.
+	ErrInvalidRequest = &common.AppError{
+		Code:    -3,
+		Message: "invalid request",
+	}
+)
+
+// Method is a method that responds to a Req with a Resp.
+//
+// Negative AppError codes are reserved for errors returned by this package.
+type Method[Req, Resp proto.Message] struct {
+	// ID identifies the method among the methods of a server.
+	ID uint64
+	// Name is the name of the method in metrics and logs.
+	Name string
+}
+
+// newMessage returns an empty message of type [T], which must be a pointer to
+// a generated protobuf message.
+func newMessage[T proto.Message]() T {
+	var zero T
+	return zero.ProtoReflect().New().Interface().(T)
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+const methodLabel = "method"
+
+var methodLabels = []string{methodLabel}
+
+type metrics struct {
+	count       *prometheus.CounterVec
+	time        *prometheus.CounterVec
+	failedCount *prometheus.CounterVec
+}
+
+// newMetrics registers per-method metrics of requests that are [kind], which
+// is either "sent" or "handled".
+func newMetrics(
+	registerer prometheus.Registerer,
+	namespace string,
+	kind string,
+) (metrics, error) {
+	m := metrics{
+		count: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_" + kind + "_count",
+			Help:      "rpc requests " + kind + " (n)",
+		}, methodLabels),
+		time: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_" + kind + "_time",
+			Help:      "time spent on rpc requests " + kind + " (ns)",
+		}, methodLabels),
+		failedCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_" + kind + "_failed_count",
+			Help:      "rpc requests " + kind + " that failed (n)",
+		}, methodLabels),
+	}
+	err := utils.Err(
+		registerer.Register(m.count),
+		registerer.Register(m.time),
+		registerer.Register(m.failedCount),
+	)
+	return m, err
+}
+
+// observe records a request of [method] that started at [start].
+func (m *metrics) observe(method string, start time.Time, failed bool) error {
+	labels := prometheus.Labels{
+		methodLabel: method,
+	}
+
+	metricCount, err := m.count.GetMetricWith(labels)
+	if err != nil {
+		return err
+	}
+
+	metricTime, err := m.time.GetMetricWith(labels)
+	if err != nil {
+		return err
+	}
+
+	metricCount.Inc()
+	metricTime.Add(float64(time.Since(start)))
+	if !failed {
+		return nil
+	}
+
+	metricFailedCount, err := m.failedCount.GetMetricWith(labels)
+	if err != nil {
+		return err
+	}
+	metricFailedCount.Inc()
+	return nil
+}
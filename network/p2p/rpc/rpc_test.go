@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+const handlerID = 1
+
+var (
+	echo = Method[*sdk.PushGossip, *sdk.PushGossip]{
+		ID:   0,
+		Name: "echo",
+	}
+	count = Method[*sdk.PushGossip, *sdk.PullGossipResponse]{
+		ID:   1,
+		Name: "count",
+	}
+	unregistered = Method[*sdk.PushGossip, *sdk.PushGossip]{
+		ID:   2,
+		Name: "unregistered",
+	}
+
+	errTestApp = &common.AppError{
+		Code:    1,
+		Message: "test",
+	}
+	errTest = errors.New("test")
+)
+
+// newTestClient returns a client of [server], which is served by another
+// network. Messages are delivered asynchronously.
+func newTestClient(t *testing.T, server *Server, timeout time.Duration) *Client {
+	require := require.New(t)
+
+	var (
+		clientNodeID  = ids.GenerateTestNodeID()
+		serverNodeID  = ids.GenerateTestNodeID()
+		clientNetwork *p2p.Network
+		serverNetwork *p2p.Network
+	)
+
+	clientSender := &common.SenderTest{
+		SendAppRequestF: func(ctx context.Context, _ set.Set[ids.NodeID], requestID uint32, request []byte) error {
+			go func() {
+				require.NoError(serverNetwork.AppRequest(ctx, clientNodeID, requestID, time.Time{}, request))
+			}()
+			return nil
+		},
+	}
+	serverSender := &common.SenderTest{
+		SendAppResponseF: func(ctx context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+			go func() {
+				require.NoError(clientNetwork.AppResponse(ctx, serverNodeID, requestID, response))
+			}()
+			return nil
+		},
+	}
+
+	var err error
+	clientNetwork, err = p2p.NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	serverNetwork, err = p2p.NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(serverNetwork.AddHandler(handlerID, server))
+
+	client, err := NewClient(clientNetwork.NewClient(handlerID), timeout, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	return client
+}
+
+func newTestServer(t *testing.T) *Server {
+	require := require.New(t)
+
+	server, err := NewServer(logging.NoLog{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(Register(server, echo, func(_ context.Context, _ ids.NodeID, request *sdk.PushGossip) (*sdk.PushGossip, error) {
+		switch {
+		case len(request.Gossip) == 0:
+			return nil, errTest
+		case string(request.Gossip[0]) == "app error":
+			return nil, errTestApp
+		default:
+			return request, nil
+		}
+	}))
+	require.NoError(Register(server, count, func(_ context.Context, _ ids.NodeID, request *sdk.PushGossip) (*sdk.PullGossipResponse, error) {
+		response := &sdk.PullGossipResponse{}
+		for i := 0; i < len(request.Gossip); i++ {
+			response.Gossip = append(response.Gossip, []byte{byte(i)})
+		}
+		return response, nil
+	}))
+	return server
+}
+
+func TestCall(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           Method[*sdk.PushGossip, *sdk.PushGossip]
+		request          *sdk.PushGossip
+		expectedResponse [][]byte
+		expectedErr      error
+	}{
+		{
+			name:   "success",
+			method: echo,
+			request: &sdk.PushGossip{
+				Gossip: [][]byte{{1}, {2}},
+			},
+			expectedResponse: [][]byte{{1}, {2}},
+		},
+		{
+			name:   "app error",
+			method: echo,
+			request: &sdk.PushGossip{
+				Gossip: [][]byte{[]byte("app error")},
+			},
+			expectedErr: errTestApp,
+		},
+		{
+			name:        "undefined error",
+			method:      echo,
+			request:     &sdk.PushGossip{},
+			expectedErr: common.ErrUndefined,
+		},
+		{
+			name:        "unknown method",
+			method:      unregistered,
+			request:     &sdk.PushGossip{},
+			expectedErr: ErrUnknownMethod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			server := newTestServer(t)
+			client := newTestClient(t, server, time.Minute)
+
+			response, err := Call(context.Background(), client, ids.EmptyNodeID, tt.method, tt.request)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.Equal(tt.expectedResponse, response.Gossip)
+		})
+	}
+}
+
+func TestCallDifferentTypes(t *testing.T) {
+	require := require.New(t)
+
+	server := newTestServer(t)
+	client := newTestClient(t, server, time.Minute)
+
+	response, err := Call(context.Background(), client, ids.EmptyNodeID, count, &sdk.PushGossip{
+		Gossip: [][]byte{{5}, {5}, {5}},
+	})
+	require.NoError(err)
+	require.Equal([][]byte{{0}, {1}, {2}}, response.Gossip)
+}
+
+func TestCallTimeout(t *testing.T) {
+	require := require.New(t)
+
+	server := newTestServer(t)
+	unblock := make(chan struct{})
+	defer close(unblock)
+	require.NoError(Register(server, unregistered, func(context.Context, ids.NodeID, *sdk.PushGossip) (*sdk.PushGossip, error) {
+		<-unblock
+		return &sdk.PushGossip{}, nil
+	}))
+	client := newTestClient(t, server, time.Millisecond)
+
+	_, err := Call(context.Background(), client, ids.EmptyNodeID, unregistered, &sdk.PushGossip{})
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestRegisterExistingMethod(t *testing.T) {
+	require := require.New(t)
+
+	server := newTestServer(t)
+	err := Register(server, Method[*sdk.PushGossip, *sdk.PushGossip]{ID: echo.ID}, nil)
+	require.ErrorIs(err, ErrExistingMethod)
+}
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	server := newTestServer(t)
+	client := newTestClient(t, server, time.Minute)
+
+	_, err := Call(ctx, client, ids.EmptyNodeID, echo, &sdk.PushGossip{
+		Gossip: [][]byte{{1}},
+	})
+	require.NoError(err)
+	_, err = Call(ctx, client, ids.EmptyNodeID, echo, &sdk.PushGossip{})
+	require.ErrorIs(err, common.ErrUndefined)
+	_, err = Call(ctx, client, ids.EmptyNodeID, count, &sdk.PushGossip{})
+	require.NoError(err)
+
+	for _, m := range []metrics{client.metrics, server.metrics} {
+		require.Equal(float64(2), testutil.ToFloat64(m.count.WithLabelValues(echo.Name)))
+		require.Equal(float64(1), testutil.ToFloat64(m.failedCount.WithLabelValues(echo.Name)))
+		require.Equal(float64(1), testutil.ToFloat64(m.count.WithLabelValues(count.Name)))
+		require.Zero(testutil.ToFloat64(m.failedCount.WithLabelValues(count.Name)))
+	}
+}
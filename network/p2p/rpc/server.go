@@ -0,0 +1,162 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+var (
+	ErrExistingMethod = errors.New("existing method")
+
+	_ p2p.Handler = (*Server)(nil)
+)
+
+// HandlerFunc handles a request of a method from [nodeID].
+//
+// If the returned error is a *common.AppError, it is returned to the peer.
+// Otherwise, common.ErrUndefined is returned to the peer.
+type HandlerFunc[Req, Resp proto.Message] func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	request Req,
+) (Resp, error)
+
+type serverMethod struct {
+	name string
+	// handle returns the response bytes to [requestBytes].
+	handle func(ctx context.Context, nodeID ids.NodeID, requestBytes []byte) ([]byte, error)
+}
+
+// NewServer returns a Server without any methods. Methods are added with
+// Register.
+func NewServer(
+	log logging.Logger,
+	registerer prometheus.Registerer,
+	namespace string,
+) (*Server, error) {
+	metrics, err := newMetrics(registerer, namespace, "handled")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		Handler: p2p.NoOpHandler{},
+		log:     log,
+		metrics: metrics,
+		methods: make(map[uint64]*serverMethod),
+	}, nil
+}
+
+// Server serves the methods registered with Register. It should be added to a
+// p2p.Network with p2p.Network.AddHandler.
+type Server struct {
+	p2p.Handler
+	log     logging.Logger
+	metrics metrics
+
+	lock    sync.RWMutex
+	methods map[uint64]*serverMethod
+}
+
+// Register serves [method] with [handler].
+func Register[Req, Resp proto.Message](
+	s *Server,
+	method Method[Req, Resp],
+	handler HandlerFunc[Req, Resp],
+) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if existing, ok := s.methods[method.ID]; ok {
+		return fmt.Errorf("failed to register method %s with id %d used by %s: %w",
+			method.Name,
+			method.ID,
+			existing.name,
+			ErrExistingMethod,
+		)
+	}
+
+	s.methods[method.ID] = &serverMethod{
+		name: method.Name,
+		handle: func(ctx context.Context, nodeID ids.NodeID, requestBytes []byte) ([]byte, error) {
+			request := newMessage[Req]()
+			if err := proto.Unmarshal(requestBytes, request); err != nil {
+				return nil, ErrInvalidRequest
+			}
+
+			response, err := handler(ctx, nodeID, request)
+			if err != nil {
+				return nil, err
+			}
+			return proto.Marshal(response)
+		},
+	}
+	return nil
+}
+
+func (s *Server) AppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	start := time.Now()
+	request := &sdk.RPCRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return marshalError(ErrInvalidRequest)
+	}
+
+	s.lock.RLock()
+	method, ok := s.methods[request.Method]
+	s.lock.RUnlock()
+	if !ok {
+		return marshalError(ErrUnknownMethod)
+	}
+
+	responseBytes, err := method.handle(ctx, nodeID, request.Request)
+	if err := s.metrics.observe(method.name, start, err != nil); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		s.log.Debug("failed to handle rpc request",
+			zap.Stringer("nodeID", nodeID),
+			zap.String("method", method.name),
+			zap.Error(err),
+		)
+
+		var appErr *common.AppError
+		if !errors.As(err, &appErr) {
+			appErr = common.ErrUndefined
+		}
+		return marshalError(appErr)
+	}
+
+	return proto.Marshal(&sdk.RPCResponse{
+		Response: responseBytes,
+	})
+}
+
+func marshalError(appErr *common.AppError) ([]byte, error) {
+	return proto.Marshal(&sdk.RPCResponse{
+		Error: &sdk.RPCError{
+			Code:    appErr.Code,
+			Message: appErr.Message,
+		},
+	})
+}
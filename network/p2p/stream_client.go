@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/utils/buffer"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+const (
+	DefaultStreamWindow = 16
+	// The number of times that a failed read of a stream is retried before
+	// the stream fails.
+	maxStreamReadRetries = 3
+	// The delay before reading a stream again after the server responded
+	// without any frames, because the server doesn't wait for frames to be
+	// sent.
+	streamPollInterval = 50 * time.Millisecond
+)
+
+var (
+	ErrStreamFailed = errors.New("stream failed")
+	ErrStreamClosed = errors.New("stream closed")
+
+	errInvalidStreamResponse = errors.New("invalid stream response")
+)
+
+// AppRequestStream opens a stream of response frames to [requestBytes] from
+// [nodeID], which must be served by a StreamingHandler. Up to [window] frames
+// are requested at a time, which is capped by the server.
+//
+// The returned stream must be closed once it is no longer read.
+func (c *Client) AppRequestStream(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestBytes []byte,
+	window int,
+) (*Stream, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidStreamWindow, window)
+	}
+
+	c.router.lock.Lock()
+	streamID := c.router.streamID
+	c.router.streamID++
+	c.router.lock.Unlock()
+
+	s := &Stream{
+		client:   c,
+		nodeID:   nodeID,
+		streamID: streamID,
+		window:   window,
+		frames:   buffer.NewUnboundedDeque[[]byte](window),
+		notify:   make(chan struct{}, 1),
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.sendLocked(ctx, &sdk.StreamRequest{
+		StreamId: streamID,
+		Message: &sdk.StreamRequest_Open{
+			Open: &sdk.StreamOpen{
+				Request: requestBytes,
+				Window:  uint32(window),
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.reading = true
+	return s, nil
+}
+
+// Stream reads the response frames of a stream opened by
+// Client.AppRequestStream.
+//
+// Frames are read ahead of calls to Next, up to the window of the stream.
+type Stream struct {
+	client   *Client
+	nodeID   ids.NodeID
+	streamID uint64
+	window   int
+
+	lock sync.Mutex
+	// The frames that have been received but not returned by Next.
+	frames buffer.Deque[[]byte]
+	// The index of the next frame that will be received.
+	nextFrame uint64
+	// True if a request to the server is outstanding.
+	reading bool
+	// The number of consecutive failed reads.
+	failedReads int
+	// The error that Next returns once [frames] is empty. io.EOF if the
+	// stream finished successfully.
+	err error
+
+	// Signaled when frames are received or [err] is set.
+	notify chan struct{}
+}
+
+// Next returns the next frame of the stream. Returns io.EOF once every frame
+// has been returned.
+func (s *Stream) Next(ctx context.Context) ([]byte, error) {
+	for {
+		s.lock.Lock()
+		if frame, ok := s.frames.PopLeft(); ok {
+			s.readLocked(ctx)
+			s.lock.Unlock()
+			return frame, nil
+		}
+		err := s.err
+		s.lock.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		select {
+		case <-s.notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// ReadAll returns the remaining frames of the stream.
+func (s *Stream) ReadAll(ctx context.Context) ([][]byte, error) {
+	var frames [][]byte
+	for {
+		frame, err := s.Next(ctx)
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return frames, err
+		}
+		frames = append(frames, frame)
+	}
+}
+
+// Close stops reading the stream. If the stream isn't finished, the server is
+// notified to cancel it.
+func (s *Stream) Close(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	finished := s.err != nil
+	s.err = ErrStreamClosed
+	s.frames = buffer.NewUnboundedDeque[[]byte](0)
+	signal(s.notify)
+	if finished {
+		return nil
+	}
+
+	return s.sendLocked(ctx, &sdk.StreamRequest{
+		StreamId: s.streamID,
+		Message: &sdk.StreamRequest_Cancel{
+			Cancel: &sdk.StreamCancel{},
+		},
+	})
+}
+
+// readLocked requests more frames from the server if there isn't already an
+// outstanding request and there is room in the window.
+//
+// Invariant: Assumes [s.lock] is held.
+func (s *Stream) readLocked(ctx context.Context) {
+	if s.reading || s.err != nil || s.frames.Len() >= s.window {
+		return
+	}
+
+	err := s.sendLocked(ctx, &sdk.StreamRequest{
+		StreamId: s.streamID,
+		Message: &sdk.StreamRequest_Read{
+			Read: &sdk.StreamRead{
+				NextFrame: s.nextFrame,
+				MaxFrames: uint32(s.window - s.frames.Len()),
+			},
+		},
+	})
+	if err != nil {
+		s.failLocked(err)
+		return
+	}
+	s.reading = true
+}
+
+// Invariant: Assumes [s.lock] is held.
+func (s *Stream) sendLocked(ctx context.Context, request *sdk.StreamRequest) error {
+	requestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	var onResponse AppResponseCallback = s.onResponse
+	if _, ok := request.Message.(*sdk.StreamRequest_Cancel); ok {
+		onResponse = func(context.Context, ids.NodeID, []byte, error) {}
+	}
+	return s.client.AppRequest(ctx, set.Of(s.nodeID), requestBytes, onResponse)
+}
+
+func (s *Stream) onResponse(
+	ctx context.Context,
+	_ ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.reading = false
+	if s.err != nil {
+		return
+	}
+
+	if err != nil {
+		// Reads are retried from the first unreceived frame, so frames aren't
+		// lost if a response is dropped.
+		s.failedReads++
+		if s.failedReads > maxStreamReadRetries {
+			s.failLocked(err)
+			return
+		}
+		s.readLocked(ctx)
+		return
+	}
+	s.failedReads = 0
+
+	response := &sdk.StreamResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		s.failLocked(fmt.Errorf("%w: %w", errInvalidStreamResponse, err))
+		return
+	}
+	if len(response.Frames) > s.window-s.frames.Len() {
+		s.failLocked(fmt.Errorf("%w: %d frames exceeds the window", errInvalidStreamResponse, len(response.Frames)))
+		return
+	}
+
+	for _, frame := range response.Frames {
+		s.frames.PushRight(frame)
+	}
+	s.nextFrame += uint64(len(response.Frames))
+	signal(s.notify)
+
+	switch {
+	case response.Done && response.Error != "":
+		s.err = fmt.Errorf("%w: %s", ErrStreamFailed, response.Error)
+	case response.Done:
+		s.err = io.EOF
+	case len(response.Frames) == 0:
+		// Wait for the server to send more frames. [s.reading] prevents Next
+		// from reading again in the meantime.
+		s.reading = true
+		time.AfterFunc(streamPollInterval, func() {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+
+			s.reading = false
+			s.readLocked(ctx)
+		})
+	default:
+		s.readLocked(ctx)
+	}
+}
+
+// Invariant: Assumes [s.lock] is held.
+func (s *Stream) failLocked(err error) {
+	s.err = fmt.Errorf("%w: %w", ErrStreamFailed, err)
+	signal(s.notify)
+}
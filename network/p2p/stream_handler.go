@@ -0,0 +1,483 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+const (
+	DefaultMaxStreams            = 16
+	DefaultMaxStreamWindow       = 64
+	DefaultMaxStreamResponseSize = units.MiB
+	DefaultStreamIdleTimeout     = 30 * time.Second
+)
+
+var (
+	ErrTooManyStreams      = errors.New("too many streams")
+	ErrUnknownStream       = errors.New("unknown stream")
+	ErrFrameTooLarge       = errors.New("frame too large")
+	ErrStreamCancelled     = errors.New("stream cancelled")
+	ErrCrossChainStreaming = errors.New("cross chain streaming is not supported")
+
+	errInvalidStreamRequest = errors.New("invalid stream request")
+	errInvalidStreamWindow  = errors.New("invalid stream window")
+	errInvalidNextFrame     = errors.New("invalid next frame")
+
+	_ Handler = (*StreamingHandler)(nil)
+)
+
+// StreamHandler is the server-side logic for application protocols that
+// respond to a request with a stream of frames.
+type StreamHandler interface {
+	// AppRequestStream is called when [nodeID] opens a stream with
+	// [requestBytes]. The frames sent on [stream] are delivered to the peer in
+	// order. The stream is finished once AppRequestStream returns. If a
+	// non-nil error is returned, it is reported to the peer after the frames
+	// that were already sent.
+	//
+	// [ctx] is cancelled if the peer cancels the stream or stops reading it.
+	AppRequestStream(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		requestBytes []byte,
+		stream *ResponseStream,
+	) error
+}
+
+// StreamHandlerOption configures StreamingHandler
+type StreamHandlerOption interface {
+	apply(options *streamHandlerOptions)
+}
+
+type streamHandlerOptionFunc func(options *streamHandlerOptions)
+
+func (o streamHandlerOptionFunc) apply(options *streamHandlerOptions) {
+	o(options)
+}
+
+// WithStreamThrottler configures StreamingHandler to drop the streams opened
+// by nodes that are throttled by [throttler]. Reading from a stream isn't
+// throttled.
+func WithStreamThrottler(throttler Throttler) StreamHandlerOption {
+	return streamHandlerOptionFunc(func(options *streamHandlerOptions) {
+		options.throttler = throttler
+	})
+}
+
+// WithMaxStreams configures the maximum number of unfinished streams that
+// each node can have open.
+func WithMaxStreams(maxStreams int) StreamHandlerOption {
+	return streamHandlerOptionFunc(func(options *streamHandlerOptions) {
+		options.maxStreams = maxStreams
+	})
+}
+
+// WithMaxStreamWindow configures the maximum number of unacknowledged frames
+// that are buffered for each stream, regardless of the window requested by
+// the peer.
+func WithMaxStreamWindow(maxWindow int) StreamHandlerOption {
+	return streamHandlerOptionFunc(func(options *streamHandlerOptions) {
+		options.maxWindow = maxWindow
+	})
+}
+
+// WithMaxStreamResponseSize configures the maximum number of bytes of frames
+// that are sent in a response. Frames that are larger than this can't be sent.
+func WithMaxStreamResponseSize(maxResponseSize int) StreamHandlerOption {
+	return streamHandlerOptionFunc(func(options *streamHandlerOptions) {
+		options.maxResponseSize = maxResponseSize
+	})
+}
+
+// WithStreamIdleTimeout configures how long a stream can go without being read
+// before it is cancelled. Finished streams are also kept for this long, so
+// that their last frames can be read again if the response was lost.
+func WithStreamIdleTimeout(idleTimeout time.Duration) StreamHandlerOption {
+	return streamHandlerOptionFunc(func(options *streamHandlerOptions) {
+		options.idleTimeout = idleTimeout
+	})
+}
+
+// streamHandlerOptions holds StreamingHandler-configurable values
+type streamHandlerOptions struct {
+	// throttler is used to drop the streams opened by throttled nodes. If
+	// nil, streams aren't throttled.
+	throttler       Throttler
+	maxStreams      int
+	maxWindow       int
+	maxResponseSize int
+	idleTimeout     time.Duration
+}
+
+// NewStreamingHandler returns a Handler that serves the streams opened by
+// Client.AppRequestStream with [handler].
+//
+// Reads of a stream respond with the frames that have already been sent
+// without waiting for more, so AppRequest doesn't block.
+func NewStreamingHandler(
+	handler StreamHandler,
+	log logging.Logger,
+	options ...StreamHandlerOption,
+) *StreamingHandler {
+	s := &StreamingHandler{
+		handler: handler,
+		log:     log,
+		options: &streamHandlerOptions{
+			maxStreams:      DefaultMaxStreams,
+			maxWindow:       DefaultMaxStreamWindow,
+			maxResponseSize: DefaultMaxStreamResponseSize,
+			idleTimeout:     DefaultStreamIdleTimeout,
+		},
+		streams: make(map[ids.NodeID]map[uint64]*ResponseStream),
+	}
+
+	for _, option := range options {
+		option.apply(s.options)
+	}
+
+	return s
+}
+
+// StreamingHandler serves streams of response frames over AppRequest
+// messages. It should be registered with Network.AddHandler.
+type StreamingHandler struct {
+	handler StreamHandler
+	log     logging.Logger
+	options *streamHandlerOptions
+
+	lock sync.Mutex
+	// nodeID -> streamID -> stream
+	streams map[ids.NodeID]map[uint64]*ResponseStream
+}
+
+func (*StreamingHandler) AppGossip(context.Context, ids.NodeID, []byte) {}
+
+func (s *StreamingHandler) AppRequest(
+	_ context.Context,
+	nodeID ids.NodeID,
+	_ time.Time,
+	requestBytes []byte,
+) ([]byte, error) {
+	request := &sdk.StreamRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, err
+	}
+
+	var (
+		response *sdk.StreamResponse
+		err      error
+	)
+	switch msg := request.Message.(type) {
+	case *sdk.StreamRequest_Open:
+		response, err = s.open(nodeID, request.StreamId, msg.Open)
+	case *sdk.StreamRequest_Read:
+		response, err = s.read(nodeID, request.StreamId, msg.Read)
+	case *sdk.StreamRequest_Cancel:
+		s.cancel(nodeID, request.StreamId)
+		response = &sdk.StreamResponse{
+			Done:  true,
+			Error: ErrStreamCancelled.Error(),
+		}
+	default:
+		return nil, errInvalidStreamRequest
+	}
+	if err != nil {
+		// Failing to open or read a stream is reported to the peer, so that
+		// it doesn't need to wait for the request to time out.
+		s.log.Debug("failed to handle stream request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("streamID", request.StreamId),
+			zap.Error(err),
+		)
+		response = &sdk.StreamResponse{
+			Done:  true,
+			Error: err.Error(),
+		}
+	}
+	return proto.Marshal(response)
+}
+
+func (*StreamingHandler) CrossChainAppRequest(context.Context, ids.ID, time.Time, []byte) ([]byte, error) {
+	return nil, ErrCrossChainStreaming
+}
+
+func (s *StreamingHandler) open(
+	nodeID ids.NodeID,
+	streamID uint64,
+	msg *sdk.StreamOpen,
+) (*sdk.StreamResponse, error) {
+	if msg.Window == 0 {
+		return nil, errInvalidStreamWindow
+	}
+	if s.options.throttler != nil && !s.options.throttler.Handle(nodeID) {
+		return nil, fmt.Errorf("dropping stream from %s: %w", nodeID, ErrThrottled)
+	}
+
+	stream, err := s.addStream(nodeID, streamID, min(int(msg.Window), s.options.maxWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		err := s.handler.AppRequestStream(stream.ctx, nodeID, msg.Request, stream)
+		stream.finish(err)
+	}()
+
+	return s.readStream(nodeID, streamID, stream, &sdk.StreamRead{
+		MaxFrames: msg.Window,
+	})
+}
+
+// addStream registers a new stream. If the peer already has a stream with
+// [streamID], it is replaced. Streams that have sent their last frames don't
+// count towards the maximum number of streams, and are dropped if there
+// isn't room for the new stream.
+func (s *StreamingHandler) addStream(nodeID ids.NodeID, streamID uint64, window int) (*ResponseStream, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nodeStreams, ok := s.streams[nodeID]
+	if !ok {
+		nodeStreams = make(map[uint64]*ResponseStream)
+		s.streams[nodeID] = nodeStreams
+	}
+	if existing, ok := nodeStreams[streamID]; ok {
+		existing.cancel()
+		delete(nodeStreams, streamID)
+	}
+	if len(nodeStreams) >= s.options.maxStreams {
+		for id, stream := range nodeStreams {
+			if stream.isDone() {
+				stream.idleTimer.Stop()
+				stream.cancel()
+				delete(nodeStreams, id)
+			}
+		}
+	}
+	if len(nodeStreams) >= s.options.maxStreams {
+		return nil, fmt.Errorf("%w: %s has %d open streams", ErrTooManyStreams, nodeID, len(nodeStreams))
+	}
+
+	stream := newResponseStream(window, s.options.maxResponseSize)
+	stream.idleTimer = time.AfterFunc(s.options.idleTimeout, func() {
+		s.log.Debug("cancelling idle stream",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint64("streamID", streamID),
+		)
+		s.removeStream(nodeID, streamID, stream)
+	})
+	nodeStreams[streamID] = stream
+	return stream, nil
+}
+
+func (s *StreamingHandler) read(
+	nodeID ids.NodeID,
+	streamID uint64,
+	msg *sdk.StreamRead,
+) (*sdk.StreamResponse, error) {
+	s.lock.Lock()
+	stream, ok := s.streams[nodeID][streamID]
+	s.lock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownStream, streamID)
+	}
+
+	return s.readStream(nodeID, streamID, stream, msg)
+}
+
+func (s *StreamingHandler) readStream(
+	nodeID ids.NodeID,
+	streamID uint64,
+	stream *ResponseStream,
+	msg *sdk.StreamRead,
+) (*sdk.StreamResponse, error) {
+	stream.idleTimer.Stop()
+	response, err := stream.read(msg.NextFrame, int(msg.MaxFrames))
+	if err != nil {
+		s.removeStream(nodeID, streamID, stream)
+		return nil, err
+	}
+	// Streams are kept after their last frames are sent until they're idle,
+	// so that the peer can read them again if the response is lost.
+	stream.idleTimer.Reset(s.options.idleTimeout)
+	return response, nil
+}
+
+func (s *StreamingHandler) cancel(nodeID ids.NodeID, streamID uint64) {
+	s.lock.Lock()
+	stream, ok := s.streams[nodeID][streamID]
+	s.lock.Unlock()
+	if ok {
+		s.removeStream(nodeID, streamID, stream)
+	}
+}
+
+// removeStream cancels [stream] and removes it, unless it has already been
+// replaced by a different stream.
+func (s *StreamingHandler) removeStream(nodeID ids.NodeID, streamID uint64, stream *ResponseStream) {
+	stream.idleTimer.Stop()
+	stream.cancel()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nodeStreams := s.streams[nodeID]
+	if nodeStreams[streamID] != stream {
+		return
+	}
+	delete(nodeStreams, streamID)
+	if len(nodeStreams) == 0 {
+		delete(s.streams, nodeID)
+	}
+}
+
+func newResponseStream(window int, maxResponseSize int) *ResponseStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ResponseStream{
+		ctx:             ctx,
+		cancel:          cancel,
+		window:          window,
+		maxResponseSize: maxResponseSize,
+		acknowledged:    make(chan struct{}, 1),
+	}
+}
+
+// ResponseStream sends the frames of a stream to the peer that opened it.
+type ResponseStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	// Cancels the stream if it isn't read for too long.
+	idleTimer *time.Timer
+
+	// The maximum number of unacknowledged frames.
+	window          int
+	maxResponseSize int
+
+	lock sync.Mutex
+	// The sent frames that haven't been acknowledged by the peer.
+	frames [][]byte
+	// The index of frames[0].
+	firstFrame uint64
+	finished   bool
+	// The error returned by the handler. Only set if finished is true.
+	err error
+	// True once the last frames have been read.
+	done bool
+
+	// Signaled when frames are acknowledged.
+	acknowledged chan struct{}
+}
+
+// Send queues [frame] to be sent to the peer. Blocks while the peer has
+// [window] unacknowledged frames. Returns an error if the stream is cancelled
+// or [ctx] is done.
+func (r *ResponseStream) Send(ctx context.Context, frame []byte) error {
+	if len(frame) > r.maxResponseSize {
+		return fmt.Errorf("%w: %d > %d", ErrFrameTooLarge, len(frame), r.maxResponseSize)
+	}
+
+	for {
+		r.lock.Lock()
+		if len(r.frames) < r.window {
+			r.frames = append(r.frames, frame)
+			r.lock.Unlock()
+			return nil
+		}
+		r.lock.Unlock()
+
+		select {
+		case <-r.acknowledged:
+		case <-r.ctx.Done():
+			return ErrStreamCancelled
+		case <-ctx.Done():
+			// The handler is usually given the stream's context.
+			if r.ctx.Err() != nil {
+				return ErrStreamCancelled
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *ResponseStream) finish(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.finished = true
+	r.err = err
+}
+
+// isDone returns true if the last frames of the stream have been read.
+func (r *ResponseStream) isDone() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.done
+}
+
+// read acknowledges the frames before [nextFrame] and returns up to
+// [maxFrames] frames after it. Only the frames that have already been sent are
+// returned, which may be none.
+func (r *ResponseStream) read(nextFrame uint64, maxFrames int) (*sdk.StreamResponse, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Frames can't be requested again after they're acknowledged.
+	if nextFrame < r.firstFrame || nextFrame > r.firstFrame+uint64(len(r.frames)) {
+		return nil, fmt.Errorf("%w: %d isn't in [%d, %d]",
+			errInvalidNextFrame,
+			nextFrame,
+			r.firstFrame,
+			r.firstFrame+uint64(len(r.frames)),
+		)
+	}
+	if acknowledged := int(nextFrame - r.firstFrame); acknowledged > 0 {
+		r.frames = r.frames[acknowledged:]
+		r.firstFrame = nextFrame
+		signal(r.acknowledged)
+	}
+
+	if r.ctx.Err() != nil {
+		return nil, ErrStreamCancelled
+	}
+
+	response := &sdk.StreamResponse{}
+	size := 0
+	for _, frame := range r.frames {
+		if len(response.Frames) == maxFrames || size+len(frame) > r.maxResponseSize {
+			break
+		}
+		response.Frames = append(response.Frames, frame)
+		size += len(frame)
+	}
+	if r.finished && len(response.Frames) == len(r.frames) {
+		r.done = true
+		response.Done = true
+		if r.err != nil {
+			response.Error = r.err.Error()
+		}
+	}
+	return response, nil
+}
+
+// signal notifies a goroutine that may be waiting on [c] without blocking.
+func signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
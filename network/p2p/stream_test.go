@@ -0,0 +1,398 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var (
+	_ StreamHandler = (*testStreamHandler)(nil)
+
+	errTestStream = errors.New("test stream error")
+)
+
+type testStreamHandler struct {
+	appRequestStreamF func(ctx context.Context, nodeID ids.NodeID, requestBytes []byte, stream *ResponseStream) error
+}
+
+func (t testStreamHandler) AppRequestStream(ctx context.Context, nodeID ids.NodeID, requestBytes []byte, stream *ResponseStream) error {
+	return t.appRequestStreamF(ctx, nodeID, requestBytes, stream)
+}
+
+// newStreamClient returns a client of a stream handler that is served by
+// another network. Messages are delivered asynchronously.
+func newStreamClient(
+	t *testing.T,
+	handler StreamHandler,
+	options ...StreamHandlerOption,
+) *Client {
+	require := require.New(t)
+
+	var (
+		clientNodeID = ids.GenerateTestNodeID()
+		serverNodeID = ids.GenerateTestNodeID()
+		client       *Network
+		server       *Network
+	)
+
+	clientSender := &common.SenderTest{
+		SendAppRequestF: func(ctx context.Context, _ set.Set[ids.NodeID], requestID uint32, request []byte) error {
+			go func() {
+				require.NoError(server.AppRequest(ctx, clientNodeID, requestID, time.Now().Add(time.Minute), request))
+			}()
+			return nil
+		},
+	}
+	serverSender := &common.SenderTest{
+		SendAppResponseF: func(ctx context.Context, _ ids.NodeID, requestID uint32, response []byte) error {
+			go func() {
+				require.NoError(client.AppResponse(ctx, serverNodeID, requestID, response))
+			}()
+			return nil
+		},
+	}
+
+	var err error
+	client, err = NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	server, err = NewNetwork(logging.NoLog{}, serverSender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	require.NoError(server.AddHandler(handlerID, NewStreamingHandler(handler, logging.NoLog{}, options...)))
+	return client.NewClient(handlerID)
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		numFrames int
+		window    int
+		options   []StreamHandlerOption
+	}{
+		{
+			name:      "no frames",
+			numFrames: 0,
+			window:    DefaultStreamWindow,
+		},
+		{
+			name:      "fewer frames than window",
+			numFrames: 3,
+			window:    DefaultStreamWindow,
+		},
+		{
+			name:      "more frames than window",
+			numFrames: 100,
+			window:    4,
+		},
+		{
+			name:      "window capped by server",
+			numFrames: 100,
+			window:    DefaultStreamWindow,
+			options: []StreamHandlerOption{
+				WithMaxStreamWindow(2),
+			},
+		},
+		{
+			name:      "response size limited",
+			numFrames: 100,
+			window:    DefaultStreamWindow,
+			options: []StreamHandlerOption{
+				WithMaxStreamResponseSize(2),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			var sent atomic.Int64
+			client := newStreamClient(
+				t,
+				testStreamHandler{
+					appRequestStreamF: func(ctx context.Context, _ ids.NodeID, requestBytes []byte, stream *ResponseStream) error {
+						for i := 0; i < tt.numFrames; i++ {
+							if err := stream.Send(ctx, []byte{requestBytes[0], byte(i)}); err != nil {
+								return err
+							}
+							sent.Add(1)
+						}
+						return nil
+					},
+				},
+				tt.options...,
+			)
+
+			stream, err := client.AppRequestStream(ctx, ids.EmptyNodeID, []byte{1}, tt.window)
+			require.NoError(err)
+
+			for i := 0; i < tt.numFrames; i++ {
+				frame, err := stream.Next(ctx)
+				require.NoError(err)
+				require.Equal([]byte{1, byte(i)}, frame)
+
+				// Both the client and the server buffer up to a window of
+				// frames.
+				require.LessOrEqual(sent.Load(), int64(i+1+2*tt.window))
+			}
+
+			_, err = stream.Next(ctx)
+			require.ErrorIs(err, io.EOF)
+			require.NoError(stream.Close(ctx))
+			_, err = stream.Next(ctx)
+			require.ErrorIs(err, ErrStreamClosed)
+		})
+	}
+}
+
+func TestStreamHandlerError(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	client := newStreamClient(t, testStreamHandler{
+		appRequestStreamF: func(ctx context.Context, _ ids.NodeID, _ []byte, stream *ResponseStream) error {
+			for i := 0; i < 2; i++ {
+				if err := stream.Send(ctx, []byte{byte(i)}); err != nil {
+					return err
+				}
+			}
+			return errTestStream
+		},
+	})
+
+	stream, err := client.AppRequestStream(ctx, ids.EmptyNodeID, nil, DefaultStreamWindow)
+	require.NoError(err)
+
+	// The frames sent before the error are received.
+	frames, err := stream.ReadAll(ctx)
+	require.ErrorIs(err, ErrStreamFailed)
+	require.ErrorContains(err, errTestStream.Error())
+	require.Equal([][]byte{{0}, {1}}, frames)
+}
+
+func TestStreamClose(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	handlerErr := make(chan error, 1)
+	client := newStreamClient(t, testStreamHandler{
+		appRequestStreamF: func(ctx context.Context, _ ids.NodeID, _ []byte, stream *ResponseStream) error {
+			for {
+				if err := stream.Send(ctx, []byte{0}); err != nil {
+					handlerErr <- err
+					return err
+				}
+			}
+		},
+	})
+
+	stream, err := client.AppRequestStream(ctx, ids.EmptyNodeID, nil, 1)
+	require.NoError(err)
+
+	_, err = stream.Next(ctx)
+	require.NoError(err)
+	require.NoError(stream.Close(ctx))
+
+	// Closing the stream cancels the handler.
+	require.ErrorIs(<-handlerErr, ErrStreamCancelled)
+	_, err = stream.Next(ctx)
+	require.ErrorIs(err, ErrStreamClosed)
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	handlerErr := make(chan error, 1)
+	client := newStreamClient(
+		t,
+		testStreamHandler{
+			appRequestStreamF: func(ctx context.Context, _ ids.NodeID, _ []byte, stream *ResponseStream) error {
+				for {
+					if err := stream.Send(ctx, []byte{0}); err != nil {
+						handlerErr <- err
+						return err
+					}
+				}
+			},
+		},
+		WithStreamIdleTimeout(10*time.Millisecond),
+	)
+
+	// The stream isn't read once the window is full, so the server cancels
+	// it.
+	_, err := client.AppRequestStream(ctx, ids.EmptyNodeID, nil, 1)
+	require.NoError(err)
+	require.ErrorIs(<-handlerErr, ErrStreamCancelled)
+}
+
+func TestStreamOpenFailed(t *testing.T) {
+	blockingHandler := testStreamHandler{
+		appRequestStreamF: func(ctx context.Context, _ ids.NodeID, _ []byte, stream *ResponseStream) error {
+			if err := stream.Send(ctx, []byte{0}); err != nil {
+				return err
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+
+	tests := []struct {
+		name        string
+		options     []StreamHandlerOption
+		expectedErr string
+	}{
+		{
+			name: "throttled",
+			options: []StreamHandlerOption{
+				WithStreamThrottler(NewSlidingWindowThrottler(time.Minute, 1)),
+			},
+			expectedErr: ErrThrottled.Error(),
+		},
+		{
+			name: "too many streams",
+			options: []StreamHandlerOption{
+				WithMaxStreams(1),
+			},
+			expectedErr: ErrTooManyStreams.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			client := newStreamClient(t, blockingHandler, tt.options...)
+
+			stream, err := client.AppRequestStream(ctx, ids.EmptyNodeID, nil, DefaultStreamWindow)
+			require.NoError(err)
+			defer func() {
+				require.NoError(stream.Close(ctx))
+			}()
+
+			// Wait for the stream to be opened.
+			_, err = stream.Next(ctx)
+			require.NoError(err)
+
+			failedStream, err := client.AppRequestStream(ctx, ids.EmptyNodeID, nil, DefaultStreamWindow)
+			require.NoError(err)
+
+			_, err = failedStream.Next(ctx)
+			require.ErrorIs(err, ErrStreamFailed)
+			require.ErrorContains(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestStreamingHandlerReadAgain(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	finished := make(chan struct{})
+	handler := NewStreamingHandler(
+		testStreamHandler{
+			appRequestStreamF: func(ctx context.Context, _ ids.NodeID, _ []byte, stream *ResponseStream) error {
+				defer close(finished)
+				return stream.Send(ctx, []byte{0})
+			},
+		},
+		logging.NoLog{},
+	)
+	nodeID := ids.GenerateTestNodeID()
+	request := func(request *sdk.StreamRequest) *sdk.StreamResponse {
+		requestBytes, err := proto.Marshal(request)
+		require.NoError(err)
+		responseBytes, err := handler.AppRequest(ctx, nodeID, time.Time{}, requestBytes)
+		require.NoError(err)
+		response := &sdk.StreamResponse{}
+		require.NoError(proto.Unmarshal(responseBytes, response))
+		return response
+	}
+
+	response := request(&sdk.StreamRequest{
+		Message: &sdk.StreamRequest_Open{
+			Open: &sdk.StreamOpen{
+				Window: 1,
+			},
+		},
+	})
+	require.Empty(response.Error)
+	<-finished
+
+	read := &sdk.StreamRequest{
+		Message: &sdk.StreamRequest_Read{
+			Read: &sdk.StreamRead{
+				NextFrame: uint64(len(response.Frames)),
+				MaxFrames: 1,
+			},
+		},
+	}
+	response = request(read)
+	require.True(response.Done)
+	require.Empty(response.Error)
+
+	// The last response can be read again in case it was lost.
+	readAgainResponse := request(read)
+	require.True(readAgainResponse.Done)
+	require.Empty(readAgainResponse.Error)
+	require.Equal(response.Frames, readAgainResponse.Frames)
+}
+
+func TestResponseStreamRead(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	stream := newResponseStream(2, DefaultMaxStreamResponseSize)
+	require.NoError(stream.Send(ctx, []byte{0}))
+	require.NoError(stream.Send(ctx, []byte{1}))
+
+	// The window is full until frames are acknowledged.
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err := stream.Send(cancelledCtx, []byte{2})
+	require.ErrorIs(err, context.Canceled)
+
+	response, err := stream.read(0, 1)
+	require.NoError(err)
+	require.Equal([][]byte{{0}}, response.Frames)
+	require.False(response.Done)
+
+	// Acknowledging a frame makes room in the window.
+	response, err = stream.read(1, 2)
+	require.NoError(err)
+	require.Equal([][]byte{{1}}, response.Frames)
+	require.NoError(stream.Send(ctx, []byte{2}))
+
+	// Frames can't be requested after they're acknowledged.
+	_, err = stream.read(0, 2)
+	require.ErrorIs(err, errInvalidNextFrame)
+	_, err = stream.read(4, 2)
+	require.ErrorIs(err, errInvalidNextFrame)
+
+	stream.finish(errTestStream)
+	response, err = stream.read(1, 2)
+	require.NoError(err)
+	require.Equal([][]byte{{1}, {2}}, response.Frames)
+	require.True(response.Done)
+	require.Equal(errTestStream.Error(), response.Error)
+
+	err = stream.Send(ctx, make([]byte, DefaultMaxStreamResponseSize+1))
+	require.ErrorIs(err, ErrFrameTooLarge)
+}
@@ -7,14 +7,17 @@ import (
 	"cmp"
 	"context"
 	"math"
+	"math/rand"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/heap"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/sampler"
 	"github.com/MetalBlockchain/metalgo/utils/set"
@@ -34,20 +37,56 @@ type ValidatorSubset interface {
 	Top(ctx context.Context, percentage float64) []ids.NodeID // TODO return error
 }
 
+// ValidatorsOption configures Validators
+type ValidatorsOption interface {
+	applyValidators(validators *Validators)
+}
+
+// ReputationOption weights how much peers are relied on by their reputation.
+// It configures both Validators and PeerTracker.
+type ReputationOption struct {
+	reputation reputation.Scorer
+}
+
+func (o ReputationOption) applyValidators(validators *Validators) {
+	validators.reputation = o.reputation
+}
+
+func (o ReputationOption) applyPeerTracker(peerTracker *PeerTracker) {
+	peerTracker.reputation = o.reputation
+}
+
+// WithReputation weights the sampling of validators, or the bandwidth of
+// peers, by their [reputation].
+func WithReputation(reputation reputation.Scorer) ReputationOption {
+	return ReputationOption{
+		reputation: reputation,
+	}
+}
+
 func NewValidators(
 	peers *Peers,
 	log logging.Logger,
 	subnetID ids.ID,
 	validators validators.State,
 	maxValidatorSetStaleness time.Duration,
+	options ...ValidatorsOption,
 ) *Validators {
-	return &Validators{
+	v := &Validators{
 		peers:                    peers,
 		log:                      log,
 		subnetID:                 subnetID,
 		validators:               validators,
+		reputation:               reputation.NewNoTracker(),
 		maxValidatorSetStaleness: maxValidatorSetStaleness,
 	}
+	for _, option := range options {
+		option.applyValidators(v)
+	}
+	if v.reputation == nil {
+		v.reputation = reputation.NewNoTracker()
+	}
+	return v
 }
 
 // Validators contains a set of nodes that are staking.
@@ -56,18 +95,24 @@ type Validators struct {
 	log                      logging.Logger
 	subnetID                 ids.ID
 	validators               validators.State
+	reputation               reputation.Scorer
 	maxValidatorSetStaleness time.Duration
 
 	lock          sync.Mutex
 	validatorList []validator
 	validatorSet  set.Set[ids.NodeID]
 	totalWeight   uint64
-	lastUpdated   time.Time
+	// True if any validator in [validatorList] has a score below 1.
+	penalized   bool
+	lastUpdated time.Time
 }
 
 type validator struct {
 	nodeID ids.NodeID
 	weight uint64
+	// Reputation score of the validator, cached when the validator set is
+	// refreshed.
+	score float64
 }
 
 func (v validator) Compare(other validator) int {
@@ -77,6 +122,11 @@ func (v validator) Compare(other validator) int {
 	return v.nodeID.Compare(other.nodeID)
 }
 
+type sampledValidator struct {
+	nodeID ids.NodeID
+	key    float64
+}
+
 func (v *Validators) refresh(ctx context.Context) {
 	if time.Since(v.lastUpdated) < v.maxValidatorSetStaleness {
 		return
@@ -86,6 +136,7 @@ func (v *Validators) refresh(ctx context.Context) {
 	v.validatorList = v.validatorList[:0]
 	v.validatorSet.Clear()
 	v.totalWeight = 0
+	v.penalized = false
 
 	height, err := v.validators.GetCurrentHeight(ctx)
 	if err != nil {
@@ -99,25 +150,34 @@ func (v *Validators) refresh(ctx context.Context) {
 	}
 
 	for nodeID, vdr := range validatorSet {
+		score := v.reputation.Score(nodeID)
 		v.validatorList = append(v.validatorList, validator{
 			nodeID: nodeID,
 			weight: vdr.Weight,
+			score:  score,
 		})
 		v.validatorSet.Add(nodeID)
 		v.totalWeight += vdr.Weight
+		v.penalized = v.penalized || score < 1
 	}
 	utils.Sort(v.validatorList)
 
 	v.lastUpdated = time.Now()
 }
 
-// Sample returns a random sample of connected validators
+// Sample returns a random sample of connected validators. Validators are
+// sampled with a probability weighted by their reputation, which is cached for
+// [maxValidatorSetStaleness].
 func (v *Validators) Sample(ctx context.Context, limit int) []ids.NodeID {
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
 	v.refresh(ctx)
 
+	if v.penalized {
+		return v.sampleWeighted(limit)
+	}
+
 	var (
 		uniform = sampler.NewUniform()
 		sampled = make([]ids.NodeID, 0, limit)
@@ -141,6 +201,50 @@ func (v *Validators) Sample(ctx context.Context, limit int) []ids.NodeID {
 	return sampled
 }
 
+// sampleWeighted returns a sample of connected validators weighted by their
+// scores.
+//
+// Weighted sampling without replacement is performed by giving each validator
+// a random key of e/w, where e is exponentially distributed and w is the
+// validator's score, and sampling the validators with the smallest keys.
+//
+// Assumes [v.lock] is held.
+func (v *Validators) sampleWeighted(limit int) []ids.NodeID {
+	// The sampled validator with the largest key is at the top of the heap so
+	// that it can be replaced by a validator with a smaller key.
+	sampled := heap.NewQueue[sampledValidator](func(a, b sampledValidator) bool {
+		return a.key > b.key
+	})
+	for _, vdr := range v.validatorList {
+		if !v.peers.has(vdr.nodeID) {
+			continue
+		}
+
+		key := rand.ExpFloat64() / vdr.score // #nosec G404
+		if sampled.Len() < limit {
+			sampled.Push(sampledValidator{
+				nodeID: vdr.nodeID,
+				key:    key,
+			})
+			continue
+		}
+		if largest, ok := sampled.Peek(); ok && key < largest.key {
+			_, _ = sampled.Pop()
+			sampled.Push(sampledValidator{
+				nodeID: vdr.nodeID,
+				key:    key,
+			})
+		}
+	}
+
+	nodeIDs := make([]ids.NodeID, 0, sampled.Len())
+	for sampled.Len() > 0 {
+		vdr, _ := sampled.Pop()
+		nodeIDs = append(nodeIDs, vdr.nodeID)
+	}
+	return nodeIDs
+}
+
 // Top returns the top [percentage] of validators, regardless of if they are
 // connected or not.
 func (v *Validators) Top(ctx context.Context, percentage float64) []ids.NodeID {
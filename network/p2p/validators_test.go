@@ -15,6 +15,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
@@ -213,6 +214,61 @@ func TestValidatorsSample(t *testing.T) {
 	}
 }
 
+func TestValidatorsSampleReputation(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	state := &validators.TestState{
+		GetCurrentHeightF: func(context.Context) (uint64, error) {
+			return 1, nil
+		},
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID1: {
+					NodeID: nodeID1,
+					Weight: 1,
+				},
+				nodeID2: {
+					NodeID: nodeID2,
+					Weight: 1,
+				},
+			}, nil
+		},
+	}
+
+	tracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife: time.Hour,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		tracker.Report(nodeID2, reputation.InvalidBlock)
+	}
+
+	network, err := NewNetwork(logging.NoLog{}, &common.FakeSender{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(network.Connected(ctx, nodeID1, nil))
+	require.NoError(network.Connected(ctx, nodeID2, nil))
+
+	v := NewValidators(network.Peers, network.log, ids.Empty, state, time.Hour, WithReputation(tracker))
+
+	// All connected validators are sampled, regardless of their reputation.
+	require.ElementsMatch([]ids.NodeID{nodeID1, nodeID2}, v.Sample(ctx, 3))
+
+	// The validator with a bad reputation is sampled less often.
+	sampled := make(map[ids.NodeID]int)
+	for i := 0; i < 1000; i++ {
+		nodeIDs := v.Sample(ctx, 1)
+		require.Len(nodeIDs, 1)
+		sampled[nodeIDs[0]]++
+	}
+	require.Greater(sampled[nodeID1], sampled[nodeID2])
+}
+
 func TestValidatorsTop(t *testing.T) {
 	nodeID1 := ids.GenerateTestNodeID()
 	nodeID2 := ids.GenerateTestNodeID()
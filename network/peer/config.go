@@ -41,6 +41,10 @@ type Config struct {
 	PongTimeout          time.Duration
 	MaxClockDifference   time.Duration
 
+	// Throttles the app messages read from peers that were started with an
+	// app stream. Must be set if any peer is started with an app stream.
+	AppInboundMsgThrottler throttling.InboundMsgThrottler
+
 	SupportedACPs []uint32
 	ObjectedACPs  []uint32
 
@@ -115,6 +115,9 @@ type peer struct {
 
 	// the connection object that is used to read/write messages from
 	conn net.Conn
+	// the connection object that is used to read/write app messages from. If
+	// nil, app messages are read/written from [conn].
+	appConn net.Conn
 
 	// [cert] is this peer's certificate, specifically the leaf of the
 	// certificate chain they provided.
@@ -125,6 +128,9 @@ type peer struct {
 
 	// queue of messages to send to this peer.
 	messageQueue MessageQueue
+	// queue of app messages to send to this peer. Only used if [appConn] isn't
+	// nil.
+	appMessageQueue MessageQueue
 
 	// ip is the claimed IP the peer gave us in the Handshake message.
 	ip *SignedIP
@@ -196,14 +202,47 @@ func Start(
 	cert *staking.Certificate,
 	id ids.NodeID,
 	messageQueue MessageQueue,
+) Peer {
+	return start(config, conn, nil, cert, id, messageQueue, nil)
+}
+
+// StartWithAppStream starts a new peer instance that reads and writes app
+// messages from [appConn] rather than from [conn], so that app messages can't
+// block other messages. App messages are sent from [appMessageQueue], and
+// read app messages are throttled by [config.AppInboundMsgThrottler].
+//
+// Invariant: There must only be one peer running at a time with a reference to
+// the same [config.InboundMsgThrottler] or [config.AppInboundMsgThrottler].
+func StartWithAppStream(
+	config *Config,
+	conn net.Conn,
+	appConn net.Conn,
+	cert *staking.Certificate,
+	id ids.NodeID,
+	messageQueue MessageQueue,
+	appMessageQueue MessageQueue,
+) Peer {
+	return start(config, conn, appConn, cert, id, messageQueue, appMessageQueue)
+}
+
+func start(
+	config *Config,
+	conn net.Conn,
+	appConn net.Conn,
+	cert *staking.Certificate,
+	id ids.NodeID,
+	messageQueue MessageQueue,
+	appMessageQueue MessageQueue,
 ) Peer {
 	onClosingCtx, onClosingCtxCancel := context.WithCancel(context.Background())
 	p := &peer{
 		Config:             config,
 		conn:               conn,
+		appConn:            appConn,
 		cert:               cert,
 		id:                 id,
 		messageQueue:       messageQueue,
+		appMessageQueue:    appMessageQueue,
 		onFinishHandshake:  make(chan struct{}),
 		numExecuting:       3,
 		onClosingCtx:       onClosingCtx,
@@ -212,10 +251,23 @@ func Start(
 		observedUptimes:    make(map[ids.ID]uint32),
 		getPeerListChan:    make(chan struct{}, 1),
 	}
+	if appConn != nil {
+		p.numExecuting += 2
+	}
 
-	go p.readMessages()
+	// Track this node with the inbound message throttlers.
+	p.InboundMsgThrottler.AddNode(p.id)
+	if appConn != nil {
+		p.AppInboundMsgThrottler.AddNode(p.id)
+	}
+
+	go p.readMessages(p.conn, false)
 	go p.writeMessages()
 	go p.sendNetworkMessages()
+	if appConn != nil {
+		go p.readMessages(p.appConn, true)
+		go p.writeAppMessages()
+	}
 
 	return p
 }
@@ -314,6 +366,9 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
+	if p.appConn != nil && message.AppOps.Contains(msg.Op()) {
+		return p.appMessageQueue.Push(ctx, msg)
+	}
 	return p.messageQueue.Push(ctx, msg)
 }
 
@@ -332,6 +387,15 @@ func (p *peer) StartClose() {
 				zap.Error(err),
 			)
 		}
+		if p.appConn != nil {
+			if err := p.appConn.Close(); err != nil {
+				p.Log.Debug("failed to close app connection",
+					zap.Stringer("nodeID", p.id),
+					zap.Error(err),
+				)
+			}
+			p.appMessageQueue.Close()
+		}
 
 		p.messageQueue.Close()
 		p.onClosingCtxCancel()
@@ -363,27 +427,51 @@ func (p *peer) close() {
 		return
 	}
 
+	p.InboundMsgThrottler.RemoveNode(p.id)
+	if p.appConn != nil {
+		p.AppInboundMsgThrottler.RemoveNode(p.id)
+	}
 	p.Network.Disconnected(p.id)
 	close(p.onClosed)
 }
 
-// Read and handle messages from this peer.
+// Read and handle messages from this peer over [conn]. If [appOnly], messages
+// that aren't app messages are dropped and messages are throttled by
+// [p.AppInboundMsgThrottler] rather than [p.InboundMsgThrottler], so that
+// waiting to read from one connection doesn't block the other.
 // When this method returns, the connection is closed.
-func (p *peer) readMessages() {
-	// Track this node with the inbound message throttler.
-	p.InboundMsgThrottler.AddNode(p.id)
+func (p *peer) readMessages(conn net.Conn, appOnly bool) {
 	defer func() {
-		p.InboundMsgThrottler.RemoveNode(p.id)
 		p.StartClose()
 		p.close()
 	}()
 
+	throttler := p.InboundMsgThrottler
+	if appOnly {
+		throttler = p.AppInboundMsgThrottler
+
+		// App messages are dropped until the handshake has finished. Because
+		// the handshake is sent over a different connection, we wait for it
+		// to finish before reading any app messages.
+		select {
+		case <-p.onFinishHandshake:
+		case <-p.onClosingCtx.Done():
+			return
+		}
+	}
+
 	// Continuously read and handle messages from this peer.
-	reader := bufio.NewReaderSize(p.conn, p.Config.ReadBufferSize)
+	reader := bufio.NewReaderSize(conn, p.Config.ReadBufferSize)
 	msgLenBytes := make([]byte, wrappers.IntLen)
 	for {
-		// Time out and close connection if we can't read the message length
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		// Time out and close connection if we can't read the message length.
+		// The app connection may be idle for longer than the timeout, as the
+		// liveness of the peer is checked over [p.conn].
+		deadline := time.Time{}
+		if !appOnly {
+			deadline = p.nextTimeout()
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
 			p.Log.Verbo("error setting the connection read timeout",
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
@@ -418,12 +506,13 @@ func (p *peer) readMessages() {
 		// throttler metrics to verify that there is no leak.
 		//
 		// Invariant: There must only be one call to Acquire at any given time
-		// with the same nodeID. In this package, only this goroutine ever
-		// performs Acquire. Additionally, we ensure that this goroutine has
-		// exited before calling [Network.Disconnected] to guarantee that there
-		// can't be multiple instances of this goroutine running over different
-		// peer instances.
-		onFinishedHandling := p.InboundMsgThrottler.Acquire(
+		// with the same nodeID on the same throttler. In this package, only
+		// the reader goroutines perform Acquire, and each reader goroutine uses
+		// its own throttler. Additionally, we ensure that these goroutines
+		// have exited before calling [Network.Disconnected] to guarantee that
+		// there can't be multiple instances of these goroutines running over
+		// different peer instances.
+		onFinishedHandling := throttler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
@@ -436,7 +525,7 @@ func (p *peer) readMessages() {
 		}
 
 		// Time out and close connection if we can't read message
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		if err := conn.SetReadDeadline(p.nextTimeout()); err != nil {
 			p.Log.Verbo("error setting the connection read timeout",
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
@@ -486,6 +575,18 @@ func (p *peer) readMessages() {
 			continue
 		}
 
+		if appOnly && !message.AppOps.Contains(msg.Op()) {
+			p.Log.Verbo("dropping message",
+				zap.String("reason", "not an app message"),
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+			)
+
+			msg.OnFinishedHandling()
+			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+			continue
+		}
+
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
@@ -551,12 +652,27 @@ func (p *peer) writeMessages() {
 		return
 	}
 
-	p.writeMessage(writer, msg)
+	p.writeMessage(p.conn, writer, msg)
+	p.writeQueuedMessages(p.conn, writer, p.messageQueue)
+}
+
+func (p *peer) writeAppMessages() {
+	defer func() {
+		p.StartClose()
+		p.close()
+	}()
+
+	writer := bufio.NewWriterSize(p.appConn, p.Config.WriteBufferSize)
+	p.writeQueuedMessages(p.appConn, writer, p.appMessageQueue)
+}
 
+// writeQueuedMessages writes the messages in [messageQueue] to [conn] until
+// the peer is closing or writing fails.
+func (p *peer) writeQueuedMessages(conn net.Conn, writer *bufio.Writer, messageQueue MessageQueue) {
 	for {
-		msg, ok := p.messageQueue.PopNow()
+		msg, ok := messageQueue.PopNow()
 		if ok {
-			p.writeMessage(writer, msg)
+			p.writeMessage(conn, writer, msg)
 			continue
 		}
 
@@ -570,24 +686,24 @@ func (p *peer) writeMessages() {
 			return
 		}
 
-		msg, ok = p.messageQueue.Pop()
+		msg, ok = messageQueue.Pop()
 		if !ok {
 			// This peer is closing
 			return
 		}
 
-		p.writeMessage(writer, msg)
+		p.writeMessage(conn, writer, msg)
 	}
 }
 
-func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
+func (p *peer) writeMessage(conn net.Conn, writer io.Writer, msg message.OutboundMessage) {
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
 		zap.Stringer("nodeID", p.id),
 		zap.Binary("messageBytes", msgBytes),
 	)
 
-	if err := p.conn.SetWriteDeadline(p.nextTimeout()); err != nil {
+	if err := conn.SetWriteDeadline(p.nextTimeout()); err != nil {
 		p.Log.Verbo("error setting write deadline",
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
@@ -111,6 +111,8 @@ func makeRawTestPeers(t *testing.T, trackedSubnets set.Set[ids.ID]) (*rawTestPee
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+
+		AppInboundMsgThrottler: throttling.NewNoInboundThrottler(),
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
@@ -271,6 +273,74 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendWithAppStream(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t, set.Set[ids.ID]{})
+	appConn0, appConn1 := net.Pipe()
+	peer0 := StartWithAppStream(
+		rawPeer0.config,
+		rawPeer0.conn,
+		appConn0,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+	peer1 := StartWithAppStream(
+		rawPeer1.config,
+		rawPeer1.conn,
+		appConn1,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+		),
+	)
+
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	mc := newMessageCreator(t)
+	outboundAppMsg, err := mc.AppGossip(ids.Empty, []byte{1})
+	require.NoError(err)
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	require.True(peer0.Send(context.Background(), outboundAppMsg))
+	require.True(peer0.Send(context.Background(), outboundGetMsg))
+
+	ops := set.Of(
+		(<-rawPeer1.inboundMsgChan).Op(),
+		(<-rawPeer1.inboundMsgChan).Op(),
+	)
+	require.Equal(set.Of(message.AppGossipOp, message.GetOp), ops)
+
+	// Closing the app stream closes the peer.
+	require.NoError(appConn1.Close())
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
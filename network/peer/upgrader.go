@@ -19,6 +19,9 @@ var (
 
 	_ Upgrader = (*tlsServerUpgrader)(nil)
 	_ Upgrader = (*tlsClientUpgrader)(nil)
+	_ Upgrader = (*tlsStateUpgrader)(nil)
+
+	errNotTLSConn = errors.New("connection doesn't expose a tls connection state")
 )
 
 type Upgrader interface {
@@ -58,23 +61,70 @@ func (t *tlsClientUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staki
 	return connToIDAndCert(tls.Client(conn, t.config), t.invalidCerts)
 }
 
+// TLSConn is a connection that has already finished its TLS handshake, such
+// as a QUIC connection.
+type TLSConn interface {
+	net.Conn
+
+	ConnectionState() tls.ConnectionState
+}
+
+// AppStreamer is a connection that has a separate stream for app messages, so
+// that app messages can't block other messages.
+type AppStreamer interface {
+	AppStream() net.Conn
+}
+
+type tlsStateUpgrader struct {
+	invalidCerts prometheus.Counter
+}
+
+// NewTLSStateUpgrader returns an upgrader of connections that implement
+// [TLSConn]. The peer is identified by the certificate it presented during
+// the TLS handshake of the connection.
+func NewTLSStateUpgrader(invalidCerts prometheus.Counter) Upgrader {
+	return &tlsStateUpgrader{
+		invalidCerts: invalidCerts,
+	}
+}
+
+func (t *tlsStateUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *staking.Certificate, error) {
+	tlsConn, ok := conn.(TLSConn)
+	if !ok {
+		return ids.EmptyNodeID, nil, nil, errNotTLSConn
+	}
+
+	nodeID, peerCert, err := stateToIDAndCert(tlsConn.ConnectionState(), t.invalidCerts)
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+	return nodeID, conn, peerCert, nil
+}
+
 func connToIDAndCert(conn *tls.Conn, invalidCerts prometheus.Counter) (ids.NodeID, net.Conn, *staking.Certificate, error) {
 	if err := conn.Handshake(); err != nil {
 		return ids.EmptyNodeID, nil, nil, err
 	}
 
-	state := conn.ConnectionState()
+	nodeID, peerCert, err := stateToIDAndCert(conn.ConnectionState(), invalidCerts)
+	if err != nil {
+		return ids.EmptyNodeID, nil, nil, err
+	}
+	return nodeID, conn, peerCert, nil
+}
+
+func stateToIDAndCert(state tls.ConnectionState, invalidCerts prometheus.Counter) (ids.NodeID, *staking.Certificate, error) {
 	if len(state.PeerCertificates) == 0 {
-		return ids.EmptyNodeID, nil, nil, errNoCert
+		return ids.EmptyNodeID, nil, errNoCert
 	}
 
 	tlsCert := state.PeerCertificates[0]
 	peerCert, err := staking.ParseCertificate(tlsCert.Raw)
 	if err != nil {
 		invalidCerts.Inc()
-		return ids.EmptyNodeID, nil, nil, err
+		return ids.EmptyNodeID, nil, err
 	}
 
 	nodeID := ids.NodeIDFromCert(peerCert)
-	return nodeID, conn, peerCert, nil
+	return nodeID, peerCert, nil
 }
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"crypto/tls"
+	"net"
+
+	quicgo "github.com/quic-go/quic-go"
+
+	"github.com/MetalBlockchain/metalgo/network/peer"
+)
+
+var (
+	_ peer.TLSConn     = (*Conn)(nil)
+	_ peer.AppStreamer = (*Conn)(nil)
+)
+
+// Conn is a QUIC connection to a peer. Reads and writes are done over the
+// consensus stream of the connection, while app messages are read and written
+// over [Conn.AppStream].
+type Conn struct {
+	*stream
+	app *stream
+}
+
+func newConn(conn quicgo.Connection, consensus quicgo.Stream, app quicgo.Stream) *Conn {
+	return &Conn{
+		stream: &stream{
+			Stream: consensus,
+			conn:   conn,
+		},
+		app: &stream{
+			Stream: app,
+			conn:   conn,
+		},
+	}
+}
+
+func (c *Conn) AppStream() net.Conn {
+	return c.app
+}
+
+func (c *Conn) ConnectionState() tls.ConnectionState {
+	return c.conn.ConnectionState().TLS
+}
+
+// stream exposes a QUIC stream as a [net.Conn]. Closing the stream closes the
+// whole connection.
+type stream struct {
+	quicgo.Stream
+	conn quicgo.Connection
+}
+
+func (s *stream) LocalAddr() net.Addr {
+	return s.conn.LocalAddr()
+}
+
+func (s *stream) RemoteAddr() net.Addr {
+	return s.conn.RemoteAddr()
+}
+
+func (s *stream) Close() error {
+	return s.conn.CloseWithError(closeErrorCode, "")
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/cache"
+	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+)
+
+const (
+	// Peers that couldn't be connected to over QUIC are dialed over TCP,
+	// without trying QUIC first, for this long.
+	quicFailureExpiry = 10 * time.Minute
+	// The maximum number of peers whose QUIC failures are remembered.
+	maxQUICFailures = 4096
+)
+
+var _ dialer.Dialer = (*quicDialer)(nil)
+
+type quicDialer struct {
+	transport *Transport
+	fallback  dialer.Dialer
+	throttler throttling.DialThrottler
+
+	// IP --> The last time that the IP couldn't be connected to over QUIC
+	failures cache.Cacher[string, time.Time]
+}
+
+// Dialer returns a dialer that connects to peers over QUIC, and falls back to
+// [fallback] if the peer can't be connected to over QUIC. Peers that recently
+// couldn't be connected to over QUIC are dialed with [fallback] directly, so
+// that dialing peers that don't support QUIC doesn't wait for the QUIC
+// handshake to time out every time.
+// If [throttleRps] == 0, QUIC connection attempts aren't rate-limited.
+func (t *Transport) Dialer(fallback dialer.Dialer, throttleRps uint32) dialer.Dialer {
+	var throttler throttling.DialThrottler
+	if throttleRps <= 0 {
+		throttler = throttling.NewNoDialThrottler()
+	} else {
+		throttler = throttling.NewDialThrottler(int(throttleRps))
+	}
+	return &quicDialer{
+		transport: t,
+		fallback:  fallback,
+		throttler: throttler,
+		failures:  &cache.LRU[string, time.Time]{Size: maxQUICFailures},
+	}
+}
+
+func (d *quicDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	key := ip.String()
+	if failedAt, ok := d.failures.Get(key); ok && time.Since(failedAt) < quicFailureExpiry {
+		return d.fallback.Dial(ctx, ip)
+	}
+
+	if err := d.throttler.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	d.transport.log.Verbo("dialing",
+		zap.Stringer("ip", ip),
+		zap.String("network", NetworkType),
+	)
+	conn, err := d.transport.Dial(ctx, ip)
+	if err == nil {
+		d.failures.Evict(key)
+		return conn, nil
+	}
+	// Don't blame the peer if the dial was interrupted by [ctx].
+	if ctx.Err() == nil {
+		d.failures.Put(key, time.Now())
+	}
+
+	d.transport.log.Verbo("falling back to dialing over TCP",
+		zap.Stringer("ip", ip),
+		zap.Error(err),
+	)
+	return d.fallback.Dial(ctx, ip)
+}
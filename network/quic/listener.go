@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+
+	quicgo "github.com/quic-go/quic-go"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+var (
+	_ net.Listener = (*listener)(nil)
+
+	errTooManyConns = errors.New("too many inbound connections")
+)
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+type listener struct {
+	transport    *Transport
+	quicListener *quicgo.Listener
+	fallback     net.Listener
+
+	// Has a slot for each connection whose streams are being accepted. Nil if
+	// the number of pending connections isn't limited.
+	pending chan struct{}
+
+	results   chan acceptResult
+	closed    chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Listen returns a listener that accepts QUIC connections as well as the
+// connections accepted by [fallback], so that peers that don't support QUIC
+// can still connect.
+//
+// Closing the returned listener closes [fallback] and the transport.
+func (t *Transport) Listen(fallback net.Listener) (net.Listener, error) {
+	config := t.quicConfig(numStreams)
+	if maxConnsPerSec := t.config.MaxInboundConnsPerSec; maxConnsPerSec > 0 {
+		// Connections are rate-limited when the client's first packet is
+		// received, before any work is done to handshake the connection.
+		limiter := rate.NewLimiter(rate.Limit(maxConnsPerSec), int(maxConnsPerSec)+1)
+		config.GetConfigForClient = func(*quicgo.ClientHelloInfo) (*quicgo.Config, error) {
+			if !limiter.Allow() {
+				return nil, errTooManyConns
+			}
+			return config, nil
+		}
+	}
+
+	quicListener, err := t.transport.Listen(t.tlsConfig, config)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &listener{
+		transport:    t,
+		quicListener: quicListener,
+		fallback:     fallback,
+		results:      make(chan acceptResult),
+		closed:       make(chan struct{}),
+	}
+	if t.config.MaxPendingInboundConns > 0 {
+		l.pending = make(chan struct{}, t.config.MaxPendingInboundConns)
+	}
+	go l.acceptQUIC()
+	go l.acceptFallback()
+	return l, nil
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.results:
+		return result.conn, result.err
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		l.closeErr = errors.Join(
+			l.quicListener.Close(),
+			l.fallback.Close(),
+			l.transport.Close(),
+		)
+	})
+	return l.closeErr
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.fallback.Addr()
+}
+
+func (l *listener) acceptQUIC() {
+	for {
+		conn, err := l.quicListener.Accept(context.Background())
+		if err != nil {
+			l.transport.log.Debug("stopped accepting QUIC connections",
+				zap.Error(err),
+			)
+			return
+		}
+
+		if !l.acquirePending() {
+			l.transport.log.Debug("dropping QUIC connection",
+				zap.String("reason", "too many pending connections"),
+				zap.Stringer("peerAddr", conn.RemoteAddr()),
+			)
+			_ = conn.CloseWithError(closeErrorCode, "")
+			continue
+		}
+
+		go l.acceptStreams(conn)
+	}
+}
+
+// acquirePending returns false if there are too many pending connections.
+func (l *listener) acquirePending() bool {
+	if l.pending == nil {
+		return true
+	}
+	select {
+	case l.pending <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *listener) releasePending() {
+	if l.pending != nil {
+		<-l.pending
+	}
+}
+
+func (l *listener) acceptStreams(conn quicgo.Connection) {
+	defer l.releasePending()
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.transport.config.HandshakeTimeout)
+	defer cancel()
+
+	c, err := acceptStreams(ctx, conn)
+	if err != nil {
+		l.transport.log.Verbo("failed to accept QUIC streams",
+			zap.Stringer("peerAddr", conn.RemoteAddr()),
+			zap.Error(err),
+		)
+		_ = conn.CloseWithError(closeErrorCode, "")
+		return
+	}
+
+	if !l.send(c, nil) {
+		_ = c.Close()
+	}
+}
+
+func (l *listener) acceptFallback() {
+	for {
+		conn, err := l.fallback.Accept()
+		if !l.send(conn, err) {
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+	}
+}
+
+// send returns false if the listener was closed before the result could be
+// returned by Accept.
+func (l *listener) send(conn net.Conn, err error) bool {
+	select {
+	case l.results <- acceptResult{conn: conn, err: err}:
+		return true
+	case <-l.closed:
+		return false
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package quic implements peer connections over QUIC.
+//
+// Each connection has two streams: one for app messages and one for all other
+// messages, so that app messages can't block consensus messages. Peers are
+// identified by the staking certificates they present during the TLS handshake
+// of the connection, just like peers connected over TCP.
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	quicgo "github.com/quic-go/quic-go"
+
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+const (
+	// NetworkType is the network that QUIC connections are made over.
+	NetworkType = "udp"
+
+	nextProto = "p2p"
+
+	// Every stream starts with a byte identifying the type of the stream.
+	consensusStream byte = 0
+	appStream       byte = 1
+	numStreams           = 2
+
+	closeErrorCode quicgo.ApplicationErrorCode = 0
+)
+
+var errUnexpectedStream = errors.New("unexpected stream")
+
+type Config struct {
+	// HandshakeTimeout is the maximum amount of time to wait for a connection
+	// to be established, including the opening of its streams.
+	HandshakeTimeout time.Duration `json:"handshakeTimeout"`
+	// MaxIdleTimeout is the maximum amount of time that a connection may go
+	// without receiving any packets before it is closed.
+	MaxIdleTimeout time.Duration `json:"maxIdleTimeout"`
+	// MaxInboundConnsPerSec is the maximum number of inbound connections that
+	// are handshaken per second. Connections over the limit are refused before
+	// the TLS handshake. If 0, inbound connections aren't rate-limited.
+	MaxInboundConnsPerSec float64 `json:"maxInboundConnsPerSec"`
+	// MaxPendingInboundConns is the maximum number of handshaken inbound
+	// connections that may be waiting for their streams to be opened or to be
+	// accepted. Connections over the limit are closed. If 0, the number of
+	// pending inbound connections isn't limited.
+	MaxPendingInboundConns int `json:"maxPendingInboundConns"`
+}
+
+// Transport makes and accepts QUIC connections over a single packet
+// connection.
+type Transport struct {
+	packetConn net.PacketConn
+	transport  *quicgo.Transport
+	tlsConfig  *tls.Config
+	config     Config
+	log        logging.Logger
+}
+
+// NewTransport returns a transport that sends and receives packets over
+// [packetConn]. [tlsConfig] should be the same config that is used to upgrade
+// TCP connections.
+func NewTransport(
+	packetConn net.PacketConn,
+	tlsConfig *tls.Config,
+	config Config,
+	log logging.Logger,
+) *Transport {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.NextProtos = []string{nextProto}
+	return &Transport{
+		packetConn: packetConn,
+		transport: &quicgo.Transport{
+			Conn: packetConn,
+		},
+		tlsConfig: tlsConfig,
+		config:    config,
+		log:       log,
+	}
+}
+
+// Dial opens a connection, and its streams, to [ip].
+func (t *Transport) Dial(ctx context.Context, ip ips.IPPort) (*Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.config.HandshakeTimeout)
+	defer cancel()
+
+	addr := &net.UDPAddr{
+		IP:   ip.IP,
+		Port: int(ip.Port),
+	}
+	// Peers don't open streams to the dialer.
+	conn, err := t.transport.Dial(ctx, addr, t.tlsConfig, t.quicConfig(-1))
+	if err != nil {
+		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
+	}
+
+	consensus, err := openStream(ctx, conn, consensusStream)
+	if err != nil {
+		_ = conn.CloseWithError(closeErrorCode, "")
+		return nil, err
+	}
+	app, err := openStream(ctx, conn, appStream)
+	if err != nil {
+		_ = conn.CloseWithError(closeErrorCode, "")
+		return nil, err
+	}
+	return newConn(conn, consensus, app), nil
+}
+
+// Close the transport and its packet connection. Any connections made by the
+// transport are closed.
+func (t *Transport) Close() error {
+	return errors.Join(
+		t.transport.Close(),
+		t.packetConn.Close(),
+	)
+}
+
+func (t *Transport) quicConfig(maxIncomingStreams int64) *quicgo.Config {
+	return &quicgo.Config{
+		HandshakeIdleTimeout:  t.config.HandshakeTimeout,
+		MaxIdleTimeout:        t.config.MaxIdleTimeout,
+		MaxIncomingStreams:    maxIncomingStreams,
+		MaxIncomingUniStreams: -1,
+	}
+}
+
+func openStream(ctx context.Context, conn quicgo.Connection, streamType byte) (quicgo.Stream, error) {
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The peer isn't notified of the stream until data is written to it.
+	_, err = stream.Write([]byte{streamType})
+	return stream, err
+}
+
+// acceptStreams accepts the streams opened by the dialer of [conn].
+func acceptStreams(ctx context.Context, conn quicgo.Connection) (*Conn, error) {
+	var streams [numStreams]quicgo.Stream
+	for i := 0; i < numStreams; i++ {
+		stream, err := conn.AcceptStream(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		streamType, err := readStreamType(ctx, stream)
+		if err != nil {
+			return nil, err
+		}
+		if int(streamType) >= numStreams || streams[streamType] != nil {
+			return nil, fmt.Errorf("%w: type %d", errUnexpectedStream, streamType)
+		}
+		streams[streamType] = stream
+	}
+	return newConn(conn, streams[consensusStream], streams[appStream]), nil
+}
+
+func readStreamType(ctx context.Context, stream quicgo.Stream) (byte, error) {
+	deadline, _ := ctx.Deadline()
+	if err := stream.SetReadDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	var streamType [1]byte
+	if _, err := io.ReadFull(stream, streamType[:]); err != nil {
+		return 0, err
+	}
+	return streamType[0], stream.SetReadDeadline(time.Time{})
+}
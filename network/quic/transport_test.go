@@ -0,0 +1,215 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+var _ dialer.Dialer = (*testDialer)(nil)
+
+type testDialer struct {
+	conn net.Conn
+}
+
+func (d *testDialer) Dial(context.Context, ips.IPPort) (net.Conn, error) {
+	return d.conn, nil
+}
+
+func newTestTransport(t *testing.T) (*Transport, ids.NodeID) {
+	return newTestTransportWithConfig(t, Config{
+		HandshakeTimeout: time.Second,
+		MaxIdleTimeout:   time.Minute,
+	})
+}
+
+func newTestTransportWithConfig(t *testing.T, config Config) (*Transport, ids.NodeID) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+
+	packetConn, err := net.ListenPacket(NetworkType, "127.0.0.1:0")
+	require.NoError(err)
+
+	transport := NewTransport(
+		packetConn,
+		peer.TLSConfig(*tlsCert, nil),
+		config,
+		logging.NoLog{},
+	)
+	return transport, ids.NodeIDFromCert(cert)
+}
+
+func newTestListener(t *testing.T) (net.Listener, ids.NodeID, ips.IPPort) {
+	return newTestListenerWithConfig(t, Config{
+		HandshakeTimeout: time.Second,
+		MaxIdleTimeout:   time.Minute,
+	})
+}
+
+func newTestListenerWithConfig(t *testing.T, config Config) (net.Listener, ids.NodeID, ips.IPPort) {
+	require := require.New(t)
+
+	transport, nodeID := newTestTransportWithConfig(t, config)
+	fallback, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	listener, err := transport.Listen(fallback)
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	ip, err := ips.ToIPPort(transport.packetConn.LocalAddr().String())
+	require.NoError(err)
+	return listener, nodeID, ip
+}
+
+func TestDialAndAccept(t *testing.T) {
+	require := require.New(t)
+
+	listener, serverNodeID, ip := newTestListener(t)
+	transport, clientNodeID := newTestTransport(t)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+
+	clientConn, err := transport.Dial(context.Background(), ip)
+	require.NoError(err)
+	serverConn, err := listener.Accept()
+	require.NoError(err)
+	require.IsType(&Conn{}, serverConn)
+
+	// Both peers are identified by their staking certificates.
+	upgrader := peer.NewTLSStateUpgrader(prometheus.NewCounter(prometheus.CounterOpts{}))
+	nodeID, _, _, err := upgrader.Upgrade(clientConn)
+	require.NoError(err)
+	require.Equal(serverNodeID, nodeID)
+	nodeID, _, _, err = upgrader.Upgrade(serverConn)
+	require.NoError(err)
+	require.Equal(clientNodeID, nodeID)
+
+	// Messages are received over the stream that they were sent over.
+	serverAppConn := serverConn.(peer.AppStreamer).AppStream()
+	for _, conns := range [][2]net.Conn{
+		{clientConn, serverConn},
+		{clientConn.AppStream(), serverAppConn},
+		{serverConn, clientConn},
+		{serverAppConn, clientConn.AppStream()},
+	} {
+		sender, receiver := conns[0], conns[1]
+		msg := []byte(sender.LocalAddr().String())
+		_, err := sender.Write(msg)
+		require.NoError(err)
+
+		received := make([]byte, len(msg))
+		_, err = io.ReadFull(receiver, received)
+		require.NoError(err)
+		require.Equal(msg, received)
+	}
+
+	// Closing either stream closes the connection.
+	require.NoError(clientConn.AppStream().Close())
+	_, err = serverConn.Read(make([]byte, 1))
+	require.Error(err) //nolint:forbidigo // the error is from quic-go
+}
+
+func TestListenerAcceptsFallback(t *testing.T) {
+	require := require.New(t)
+
+	listener, _, _ := newTestListener(t)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	acceptedConn, err := listener.Accept()
+	require.NoError(err)
+	defer acceptedConn.Close()
+
+	_, isTLSConn := acceptedConn.(peer.TLSConn)
+	require.False(isTLSConn)
+	require.Equal(conn.LocalAddr().String(), acceptedConn.RemoteAddr().String())
+}
+
+func TestListenerClose(t *testing.T) {
+	require := require.New(t)
+
+	listener, _, _ := newTestListener(t)
+	require.NoError(listener.Close())
+
+	_, err := listener.Accept()
+	require.ErrorIs(err, net.ErrClosed)
+}
+
+func TestListenerRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	listener, _, ip := newTestListenerWithConfig(t, Config{
+		HandshakeTimeout:      time.Second,
+		MaxIdleTimeout:        time.Minute,
+		MaxInboundConnsPerSec: .001,
+	})
+	transport, _ := newTestTransport(t)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+
+	// The first connection is within the limit
+	_, err := transport.Dial(context.Background(), ip)
+	require.NoError(err)
+	conn, err := listener.Accept()
+	require.NoError(err)
+	require.NoError(conn.Close())
+
+	// The second connection is refused before it's handshaken
+	_, err = transport.Dial(context.Background(), ip)
+	require.Error(err) //nolint:forbidigo // the error is from quic-go
+}
+
+func TestDialerFallback(t *testing.T) {
+	require := require.New(t)
+
+	transport, _ := newTestTransport(t)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+
+	// Nothing is listening for QUIC connections on this port.
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer tcpListener.Close()
+	ip, err := ips.ToIPPort(tcpListener.Addr().String())
+	require.NoError(err)
+
+	fallbackConn := &tls.Conn{}
+	d := transport.Dialer(&testDialer{conn: fallbackConn}, 0)
+	conn, err := d.Dial(context.Background(), ip)
+	require.NoError(err)
+	require.Equal(fallbackConn, conn)
+
+	// The peer is dialed over TCP without waiting for QUIC to time out again
+	start := time.Now()
+	conn, err = d.Dial(context.Background(), ip)
+	require.NoError(err)
+	require.Equal(fallbackConn, conn)
+	require.Less(time.Since(start), transport.config.HandshakeTimeout)
+}
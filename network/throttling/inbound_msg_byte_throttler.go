@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/linkedhashmap"
@@ -26,6 +27,7 @@ func newInboundMsgByteThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	vdrs validators.Manager,
+	reputation reputation.Scorer,
 	config MsgByteThrottlerConfig,
 ) (*inboundMsgByteThrottler, error) {
 	t := &inboundMsgByteThrottler{
@@ -39,6 +41,7 @@ func newInboundMsgByteThrottler(
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
 		},
+		reputation:         reputation,
 		waitingToAcquire:   linkedhashmap.New[uint64, *msgMetadata](),
 		nodeToWaitingMsgID: make(map[ids.NodeID]uint64),
 	}
@@ -57,13 +60,19 @@ type msgMetadata struct {
 	closeOnAcquireChan chan struct{}
 }
 
-// It gives more space to validators with more stake.
-// Messages are guaranteed to make progress toward
-// acquiring enough bytes to be read.
+// It gives more space to validators with more stake and
+// less space to nodes with a bad reputation.
+// A bad reputation never shrinks the space a node may take
+// below the size of a max size message, so messages are
+// guaranteed to make progress toward acquiring enough bytes
+// to be read.
 type inboundMsgByteThrottler struct {
 	commonMsgThrottler
-	metrics   inboundMsgByteThrottlerMetrics
-	nextMsgID uint64
+	metrics inboundMsgByteThrottlerMetrics
+	// Scales the bytes a node may take from each allocation.
+	// See scaleByReputation.
+	reputation reputation.Scorer
+	nextMsgID  uint64
 	// Node ID --> Msg ID for a message this node is waiting to acquire
 	nodeToWaitingMsgID map[ids.NodeID]uint64
 	// Msg ID --> *msgMetadata
@@ -110,7 +119,7 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 		// only give as many bytes as needed
 		metadata.bytesNeeded,
 		// don't exceed per-node limit
-		t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[nodeID],
+		t.atLargeBytesAllowed(nodeID),
 		// don't give more bytes than are in the allocation
 		t.remainingAtLargeBytes,
 	)
@@ -138,7 +147,7 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 				zap.Error(err),
 			)
 		} else {
-			vdrAllocationSize = uint64(float64(t.maxVdrBytes) * float64(weight) / float64(totalWeight))
+			vdrAllocationSize = t.scaleByReputation(nodeID, uint64(float64(t.maxVdrBytes)*float64(weight)/float64(totalWeight)))
 		}
 	}
 	vdrBytesAlreadyUsed := t.nodeToVdrBytesUsed[nodeID]
@@ -241,7 +250,7 @@ func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.Node
 				// don't give [msg] too many bytes
 				msg.bytesNeeded,
 				// don't exceed per-node limit
-				t.nodeMaxAtLargeBytes-t.nodeToAtLargeBytesUsed[msg.nodeID],
+				t.atLargeBytesAllowed(msg.nodeID),
 				// don't give more bytes than are in the allocation
 				t.remainingAtLargeBytes,
 			)
@@ -298,6 +307,30 @@ func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.Node
 	}
 }
 
+// Returns [limit] scaled by [nodeID]'s reputation. The result is never less
+// than the size of a max size message, unless [limit] is, so that a node with
+// a bad reputation is slowed down rather than prevented from sending messages.
+//
+// Assumes [t.lock] is held.
+func (t *inboundMsgByteThrottler) scaleByReputation(nodeID ids.NodeID, limit uint64) uint64 {
+	scaledLimit := uint64(float64(limit) * t.reputation.Score(nodeID))
+	return max(scaledLimit, min(limit, constants.DefaultMaxMessageSize))
+}
+
+// Returns the number of bytes [nodeID] may still take from the at-large
+// allocation. The per-node limit is scaled by [nodeID]'s reputation.
+//
+// Assumes [t.lock] is held.
+func (t *inboundMsgByteThrottler) atLargeBytesAllowed(nodeID ids.NodeID) uint64 {
+	nodeMaxAtLargeBytes := t.scaleByReputation(nodeID, t.nodeMaxAtLargeBytes)
+	atLargeBytesUsed := t.nodeToAtLargeBytesUsed[nodeID]
+	if atLargeBytesUsed >= nodeMaxAtLargeBytes {
+		// The node's reputation may have worsened since it took these bytes.
+		return 0
+	}
+	return nodeMaxAtLargeBytes - atLargeBytesUsed
+}
+
 type inboundMsgByteThrottlerMetrics struct {
 	acquireLatency        metric.Averager
 	remainingAtLargeBytes prometheus.Gauge
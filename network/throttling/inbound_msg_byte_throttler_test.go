@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -33,6 +34,7 @@ func TestInboundMsgByteThrottlerCancelContextDeadlock(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		reputation.NewNoTracker(),
 		config,
 	)
 	require.NoError(err)
@@ -63,6 +65,7 @@ func TestInboundMsgByteThrottlerCancelContext(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		reputation.NewNoTracker(),
 		config,
 	)
 	require.NoError(err)
@@ -121,6 +124,7 @@ func TestInboundMsgByteThrottler(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		reputation.NewNoTracker(),
 		config,
 	)
 	require.NoError(err)
@@ -336,6 +340,7 @@ func TestSybilMsgThrottlerMaxNonVdr(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		reputation.NewNoTracker(),
 		config,
 	)
 	require.NoError(err)
@@ -387,6 +392,7 @@ func TestMsgThrottlerNextMsg(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		reputation.NewNoTracker(),
 		config,
 	)
 	require.NoError(err)
@@ -444,3 +450,71 @@ func TestMsgThrottlerNextMsg(t *testing.T) {
 	// next non validator message should finish
 	<-done
 }
+
+// Test that nodes with a bad reputation may take fewer bytes, but never fewer
+// than a max size message
+func TestInboundMsgByteThrottlerReputation(t *testing.T) {
+	require := require.New(t)
+	const maxMsgSize = constants.DefaultMaxMessageSize
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        4 * maxMsgSize,
+		AtLargeAllocSize:    8 * maxMsgSize,
+		NodeMaxAtLargeBytes: 4 * maxMsgSize,
+	}
+	vdrs := validators.NewManager()
+	vdrID := ids.GenerateTestNodeID()
+	nonVdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, vdrID, nil, ids.Empty, 1))
+
+	tracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife: time.Hour,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	tracker.Report(nonVdrID, reputation.InvalidBlock)
+	tracker.Report(vdrID, reputation.InvalidBlock)
+
+	throttler, err := newInboundMsgByteThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		vdrs,
+		tracker,
+		config,
+	)
+	require.NoError(err)
+
+	// The non-validator may only take half of the per-node at-large limit.
+	require.Equal(uint64(2*maxMsgSize), throttler.atLargeBytesAllowed(nonVdrID))
+	release := throttler.Acquire(context.Background(), 2*maxMsgSize, nonVdrID)
+	require.Zero(throttler.atLargeBytesAllowed(nonVdrID))
+
+	// The limit may drop below the bytes the node already took.
+	for i := 0; i < 10; i++ {
+		tracker.Report(nonVdrID, reputation.InvalidBlock)
+	}
+	require.Zero(throttler.atLargeBytesAllowed(nonVdrID))
+
+	// The limit never drops below a max size message.
+	release()
+	require.Equal(uint64(maxMsgSize), throttler.atLargeBytesAllowed(nonVdrID))
+	throttler.Acquire(context.Background(), maxMsgSize, nonVdrID)
+	require.Equal(uint64(maxMsgSize), throttler.nodeToAtLargeBytesUsed[nonVdrID])
+
+	// The validator may only take half of its validator allocation.
+	release = throttler.Acquire(context.Background(), 2*maxMsgSize+2*maxMsgSize, vdrID)
+	require.Equal(uint64(2*maxMsgSize), throttler.nodeToAtLargeBytesUsed[vdrID])
+	require.Equal(uint64(2*maxMsgSize), throttler.nodeToVdrBytesUsed[vdrID])
+	require.Equal(uint64(2*maxMsgSize), throttler.remainingVdrBytes)
+
+	// Its validator allocation never drops below a max size message either.
+	for i := 0; i < 10; i++ {
+		tracker.Report(vdrID, reputation.InvalidBlock)
+	}
+	release()
+	throttler.Acquire(context.Background(), maxMsgSize+maxMsgSize, vdrID)
+	require.Equal(uint64(maxMsgSize), throttler.nodeToAtLargeBytesUsed[vdrID])
+	require.Equal(uint64(maxMsgSize), throttler.nodeToVdrBytesUsed[vdrID])
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/metric"
 )
@@ -49,6 +51,46 @@ type InboundMsgThrottlerConfig struct {
 	CPUThrottlerConfig       SystemThrottlerConfig `json:"cpuThrottlerConfig"`
 	DiskThrottlerConfig      SystemThrottlerConfig `json:"diskThrottlerConfig"`
 	MaxProcessingMsgsPerNode uint64                `json:"maxProcessingMsgsPerNode"`
+
+	// Scores peers based on their reputation. Peers with a worse reputation
+	// may take fewer inbound message bytes. Defaults to treating every peer
+	// the same.
+	Reputation reputation.Scorer `json:"-"`
+}
+
+// Split returns the configs of two throttlers that, together, allow as many
+// bytes, as much bandwidth and as many processing messages as [c].
+//
+// Per-node limits are split too, but never below what's needed to read a max
+// size message, so that each throttler can still read every message.
+func (c InboundMsgThrottlerConfig) Split() (InboundMsgThrottlerConfig, InboundMsgThrottlerConfig) {
+	first, second := c, c
+
+	first.VdrAllocSize, second.VdrAllocSize = splitBudget(c.VdrAllocSize)
+	first.AtLargeAllocSize, second.AtLargeAllocSize = splitBudget(c.AtLargeAllocSize)
+	first.RefillRate, second.RefillRate = splitBudget(c.RefillRate)
+
+	nodeMaxAtLargeBytes := splitLimit(c.NodeMaxAtLargeBytes, constants.DefaultMaxMessageSize)
+	first.NodeMaxAtLargeBytes, second.NodeMaxAtLargeBytes = nodeMaxAtLargeBytes, nodeMaxAtLargeBytes
+
+	maxBurstSize := splitLimit(c.MaxBurstSize, constants.DefaultMaxMessageSize)
+	first.MaxBurstSize, second.MaxBurstSize = maxBurstSize, maxBurstSize
+
+	maxProcessingMsgsPerNode := splitLimit(c.MaxProcessingMsgsPerNode, 1)
+	first.MaxProcessingMsgsPerNode, second.MaxProcessingMsgsPerNode = maxProcessingMsgsPerNode, maxProcessingMsgsPerNode
+	return first, second
+}
+
+// splitBudget splits [budget] into two parts that sum to [budget].
+func splitBudget(budget uint64) (uint64, uint64) {
+	half := budget / 2
+	return budget - half, half
+}
+
+// splitLimit returns half of [limit], but not less than [minLimit] unless
+// [limit] is.
+func splitLimit(limit, minLimit uint64) uint64 {
+	return max(limit/2, min(limit, minLimit))
 }
 
 // Returns a new, sybil-safe inbound message throttler.
@@ -62,11 +104,16 @@ func NewInboundMsgThrottler(
 	cpuTargeter tracker.Targeter,
 	diskTargeter tracker.Targeter,
 ) (InboundMsgThrottler, error) {
+	scorer := throttlerConfig.Reputation
+	if scorer == nil {
+		scorer = reputation.NewNoTracker()
+	}
 	byteThrottler, err := newInboundMsgByteThrottler(
 		log,
 		namespace,
 		registerer,
 		vdrs,
+		scorer,
 		throttlerConfig.MsgByteThrottlerConfig,
 	)
 	if err != nil {
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+)
+
+func TestInboundMsgThrottlerConfigSplit(t *testing.T) {
+	require := require.New(t)
+
+	config := InboundMsgThrottlerConfig{
+		MsgByteThrottlerConfig: MsgByteThrottlerConfig{
+			VdrAllocSize:        1025,
+			AtLargeAllocSize:    2049,
+			NodeMaxAtLargeBytes: 4 * constants.DefaultMaxMessageSize,
+		},
+		BandwidthThrottlerConfig: BandwidthThrottlerConfig{
+			RefillRate:   513,
+			MaxBurstSize: constants.DefaultMaxMessageSize,
+		},
+		CPUThrottlerConfig: SystemThrottlerConfig{
+			MaxRecheckDelay: 1,
+		},
+		MaxProcessingMsgsPerNode: 1,
+	}
+	first, second := config.Split()
+
+	// Budgets are split between the throttlers.
+	require.Equal(config.VdrAllocSize, first.VdrAllocSize+second.VdrAllocSize)
+	require.Equal(config.AtLargeAllocSize, first.AtLargeAllocSize+second.AtLargeAllocSize)
+	require.Equal(config.RefillRate, first.RefillRate+second.RefillRate)
+
+	// Per-node limits are split, but not below a max size message.
+	for _, c := range []InboundMsgThrottlerConfig{first, second} {
+		require.Equal(uint64(2*constants.DefaultMaxMessageSize), c.NodeMaxAtLargeBytes)
+		require.Equal(uint64(constants.DefaultMaxMessageSize), c.MaxBurstSize)
+		require.Equal(uint64(1), c.MaxProcessingMsgsPerNode)
+		require.Equal(config.CPUThrottlerConfig, c.CPUThrottlerConfig)
+	}
+}
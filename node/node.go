@@ -38,6 +38,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/database/meterdb"
 	"github.com/MetalBlockchain/metalgo/database/pebble"
 	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/database/usage"
 	"github.com/MetalBlockchain/metalgo/database/versiondb"
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -47,9 +48,11 @@ import (
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/network/quic"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/timeout"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -205,6 +208,9 @@ func New(
 	}
 	n.initCPUTargeter(&config.CPUTargeterConfig)
 	n.initDiskTargeter(&config.DiskTargeterConfig)
+	if err := n.initReputation(n.MetricsRegisterer); err != nil {
+		return nil, fmt.Errorf("problem initializing reputation tracker: %w", err)
+	}
 	if err := n.initNetworking(); err != nil { // Set up networking layer.
 		return nil, fmt.Errorf("problem initializing networking: %w", err)
 	}
@@ -267,6 +273,8 @@ type Node struct {
 
 	// Storage for this node
 	DB database.Database
+	// Attributes the usage of [DB] to the components of this node
+	DBUsage *usage.Tracker
 
 	router     nat.Router
 	portMapper *nat.Mapper
@@ -301,6 +309,9 @@ type Node struct {
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Tracks the reputation of peers
+	reputationTracker reputation.Tracker
+
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
@@ -409,8 +420,6 @@ func (n *Node) initNetworking() error {
 	if err != nil {
 		return err
 	}
-	// Wrap listener so it will only accept a certain number of incoming connections per second
-	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
 	// Record the bound address to enable inclusion in process context file.
 	n.stakingAddress = listener.Addr().String()
@@ -519,6 +528,7 @@ func (n *Node) initNetworking() error {
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.chainRouter
+	n.Config.BenchlistConfig.Reputation = n.reputationTracker
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
@@ -598,6 +608,36 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.Reputation = n.reputationTracker
+
+	netDialer := dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log)
+	if n.Config.NetworkConfig.QUICEnabled {
+		// QUIC is served on the same port as TCP so that peers can dial either
+		// transport with the same IP.
+		packetConn, err := net.ListenPacket(quic.NetworkType, n.stakingAddress)
+		if err != nil {
+			return err
+		}
+		transport := quic.NewTransport(
+			packetConn,
+			tlsConfig,
+			quic.Config{
+				HandshakeTimeout:       constants.DefaultNetworkQUICHandshakeTimeout,
+				MaxIdleTimeout:         n.Config.NetworkConfig.PingPongTimeout,
+				MaxInboundConnsPerSec:  n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec,
+				MaxPendingInboundConns: constants.DefaultNetworkQUICMaxPendingInboundConns,
+			},
+			n.Log,
+		)
+		listener, err = transport.Listen(listener)
+		if err != nil {
+			_ = transport.Close()
+			return err
+		}
+		netDialer = transport.Dialer(netDialer, n.Config.NetworkConfig.DialerConfig.ThrottleRps)
+	}
+	// Wrap listener so it will only accept a certain number of incoming connections per second
+	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -605,7 +645,7 @@ func (n *Node) initNetworking() error {
 		n.MetricsRegisterer,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		netDialer,
 		consensusRouter,
 	)
 
@@ -759,6 +799,11 @@ func (n *Node) initDatabase() error {
 		return err
 	}
 
+	n.DBUsage, err = usage.NewTracker(n.DB, "db_usage", n.MetricsRegisterer)
+	if err != nil {
+		return err
+	}
+
 	rawExpectedGenesisHash := hashing.ComputeHash256(n.Config.GenesisBytes)
 
 	rawGenesisHash, err := n.DB.Get(genesisHashKey)
@@ -834,6 +879,7 @@ func (n *Node) initEventDispatchers() {
 // initialized
 func (n *Node) initIndexer() error {
 	txIndexerDB := prefixdb.New(indexerDBPrefix, n.DB)
+	n.DBUsage.Register("indexer", txIndexerDB.Prefix())
 	var err error
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:      n.Config.IndexAPIEnabled,
@@ -1066,6 +1112,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			TxAcceptorGroup:                         n.TxAcceptorGroup,
 			VertexAcceptorGroup:                     n.VertexAcceptorGroup,
 			DB:                                      n.DB,
+			DBUsage:                                 n.DBUsage,
 			MsgCreator:                              n.msgCreator,
 			Router:                                  n.chainRouter,
 			Net:                                     n.Net,
@@ -1084,6 +1131,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			Health:                                  n.health,
 			ShutdownNodeFunc:                        n.Shutdown,
 			MeterVMEnabled:                          n.Config.MeterVMEnabled,
+			ProposerVMCachePolicy:                   n.Config.ProposerVMCachePolicy,
 			Metrics:                                 n.MetricsGatherer,
 			SubnetConfigs:                           n.Config.SubnetConfigs,
 			ChainConfigs:                            n.Config.ChainConfigs,
@@ -1095,6 +1143,7 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 			ApricotPhase4MinPChainHeight:            version.ApricotPhase4MinPChainHeight[n.Config.NetworkID],
 			ResourceTracker:                         n.resourceTracker,
+			Reputation:                              n.reputationTracker,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.Enabled,
 			Tracer:                                  n.tracer,
@@ -1201,6 +1250,7 @@ func (n *Node) initVMs() error {
 func (n *Node) initSharedMemory() {
 	n.Log.Info("initializing SharedMemory")
 	sharedMemoryDB := prefixdb.New([]byte("shared memory"), n.DB)
+	n.DBUsage.Register("shared memory", sharedMemoryDB.Prefix())
 	n.sharedMemory = atomic.NewMemory(sharedMemoryDB)
 }
 
@@ -1208,7 +1258,9 @@ func (n *Node) initSharedMemory() {
 // Assumes n.APIServer is already set
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
-	n.keystore = keystore.New(n.Log, prefixdb.New(keystoreDBPrefix, n.DB))
+	keystoreDB := prefixdb.New(keystoreDBPrefix, n.DB)
+	n.DBUsage.Register("keystore", keystoreDB.Prefix())
+	n.keystore = keystore.New(n.Log, keystoreDB)
 	handler, err := n.keystore.CreateHandler()
 	if err != nil {
 		return err
@@ -1269,6 +1321,7 @@ func (n *Node) initAdminAPI() error {
 		admin.Config{
 			Log:          n.Log,
 			DB:           n.DB,
+			DBUsage:      n.DBUsage,
 			ChainManager: n.chainManager,
 			HTTPServer:   n.APIServer,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
@@ -1344,6 +1397,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.reputationTracker,
 	)
 	if err != nil {
 		return err
@@ -1515,6 +1569,16 @@ func (n *Node) initResourceManager(reg prometheus.Registerer) error {
 	return err
 }
 
+// Initialize [n.reputationTracker].
+func (n *Node) initReputation(reg prometheus.Registerer) error {
+	reputationTracker, err := reputation.NewTracker(n.Config.ReputationConfig, reg)
+	if err != nil {
+		return err
+	}
+	n.reputationTracker = reputationTracker
+	return nil
+}
+
 // Initialize [n.cpuTargeter].
 // Assumes [n.resourceTracker] is already initialized.
 func (n *Node) initCPUTargeter(
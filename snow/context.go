@@ -12,6 +12,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/metrics"
 	"github.com/MetalBlockchain/metalgo/chains/atomic"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
@@ -49,6 +50,8 @@ type Context struct {
 	Metrics      metrics.OptionalGatherer
 
 	WarpSigner warp.Signer
+	// Tracks the reputation of peers. Misbehavior of peers should be reported.
+	Reputation reputation.Tracker
 
 	// snowman++ attributes
 	ValidatorState validators.State // interface for P-Chain validators
@@ -22,6 +22,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/ancestor"
 	"github.com/MetalBlockchain/metalgo/snow/event"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 	"github.com/MetalBlockchain/metalgo/utils/bimap"
@@ -214,6 +215,7 @@ func (t *Transitive) Put(ctx context.Context, nodeID ids.NodeID, requestID uint3
 				zap.Error(err),
 			)
 		}
+		t.reportInvalidBlock(nodeID)
 		// because GetFailed doesn't utilize the assumption that we actually
 		// sent a Get message, we can safely call GetFailed here to potentially
 		// abandon the request.
@@ -325,6 +327,7 @@ func (t *Transitive) PushQuery(ctx context.Context, nodeID ids.NodeID, requestID
 				zap.Error(err),
 			)
 		}
+		t.reportInvalidBlock(nodeID)
 		return nil
 	}
 
@@ -1065,6 +1068,16 @@ func (t *Transitive) addToNonVerifieds(blk snowman.Block) {
 	}
 }
 
+// reportInvalidBlock reports that [nodeID] sent a block that couldn't be
+// parsed or verified.
+func (t *Transitive) reportInvalidBlock(nodeID ids.NodeID) {
+	// Locally built blocks are issued as if they were sent by this node.
+	if nodeID == t.Ctx.NodeID {
+		return
+	}
+	t.Ctx.Reputation.Report(nodeID, reputation.InvalidBlock)
+}
+
 // addUnverifiedBlockToConsensus returns whether the block was added and an
 // error if one occurred while adding it to consensus.
 func (t *Transitive) addUnverifiedBlockToConsensus(
@@ -1085,6 +1098,8 @@ func (t *Transitive) addUnverifiedBlockToConsensus(
 			zap.Error(err),
 		)
 
+		t.reportInvalidBlock(nodeID)
+
 		// if verify fails, then all descendants are also invalid
 		t.addToNonVerifieds(blk)
 		return false, nil
@@ -13,6 +13,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/heap"
 	"github.com/MetalBlockchain/metalgo/utils/set"
@@ -65,6 +66,9 @@ type benchlist struct {
 	// Validator set of the network
 	vdrs validators.Manager
 
+	// Reputation of peers
+	reputation reputation.Scorer
+
 	// Validator ID --> Consecutive failure information
 	// [streaklock] must be held when touching [failureStreaks]
 	streaklock     sync.Mutex
@@ -82,6 +86,11 @@ type benchlist struct {
 	threshold              int
 	minimumFailingDuration time.Duration
 
+	// A validator with a reputation score below [minReputation] will be
+	// benched if it has been failing for [minimumFailingDuration], regardless
+	// of [threshold]
+	minReputation float64
+
 	// A benched validator will be benched for between [duration/2] and [duration]
 	duration time.Duration
 
@@ -90,6 +99,26 @@ type benchlist struct {
 	maxPortion float64
 }
 
+// Option configures a Benchlist
+type Option interface {
+	apply(benchlist *benchlist)
+}
+
+type optionFunc func(benchlist *benchlist)
+
+func (o optionFunc) apply(benchlist *benchlist) {
+	o(benchlist)
+}
+
+// WithReputation benches validators with a [reputation] score below
+// [minReputation] sooner.
+func WithReputation(reputation reputation.Scorer, minReputation float64) Option {
+	return optionFunc(func(benchlist *benchlist) {
+		benchlist.reputation = reputation
+		benchlist.minReputation = minReputation
+	})
+}
+
 // NewBenchlist returns a new Benchlist
 func NewBenchlist(
 	ctx *snow.ConsensusContext,
@@ -99,6 +128,7 @@ func NewBenchlist(
 	minimumFailingDuration,
 	duration time.Duration,
 	maxPortion float64,
+	options ...Option,
 ) (Benchlist, error) {
 	if maxPortion < 0 || maxPortion >= 1 {
 		return nil, fmt.Errorf("max portion of benched stake must be in [0,1) but got %f", maxPortion)
@@ -117,6 +147,12 @@ func NewBenchlist(
 		duration:               duration,
 		maxPortion:             maxPortion,
 	}
+	for _, option := range options {
+		option.apply(benchlist)
+	}
+	if benchlist.reputation == nil {
+		benchlist.reputation = reputation.NewNoTracker()
+	}
 	if err := benchlist.metrics.Initialize(ctx.Registerer); err != nil {
 		return nil, err
 	}
@@ -253,7 +289,22 @@ func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
 	b.failureStreaks[nodeID] = failureStreak
 	b.streaklock.Unlock()
 
-	if failureStreak.consecutive >= b.threshold && now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
+	if !now.After(failureStreak.firstFailure.Add(b.minimumFailingDuration)) {
+		return
+	}
+
+	if failureStreak.consecutive >= b.threshold {
+		b.bench(nodeID)
+		return
+	}
+
+	// Validators with a bad reputation aren't given the benefit of the doubt
+	// for as many failures.
+	if score := b.reputation.Score(nodeID); score < b.minReputation {
+		b.ctx.Log.Debug("benching validator with bad reputation",
+			zap.Stringer("nodeID", nodeID),
+			zap.Float64("score", score),
+		)
 		b.bench(nodeID)
 	}
 }
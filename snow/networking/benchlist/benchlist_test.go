@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/snowtest"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 )
@@ -361,3 +363,73 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(3, count)
 }
+
+// Test that validators with a bad reputation are benched without reaching the
+// failure threshold, once they have been failing for long enough
+func TestBenchlistBadReputation(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	vdrID2 := ids.GenerateTestNodeID()
+
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID1, nil, ids.Empty, 50))
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, vdrID2, nil, ids.Empty, 50))
+
+	benchable := &TestBenchable{T: t}
+	benchable.Default(true)
+
+	tracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife: time.Hour,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	tracker.Report(vdrID0, reputation.InvalidBlock)
+
+	benchIntf, err := NewBenchlist(
+		ctx,
+		benchable,
+		vdrs,
+		3,
+		minimumFailingDuration,
+		time.Minute,
+		0.5,
+		WithReputation(tracker, 0.75),
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	b.clock.Set(time.Now())
+
+	benched := false
+	benchable.BenchedF = func(ids.ID, ids.NodeID) {
+		benched = true
+	}
+
+	b.RegisterFailure(vdrID0)
+	b.RegisterFailure(vdrID1)
+
+	// A validator with a bad reputation isn't benched before it has been
+	// failing for [minimumFailingDuration]
+	require.False(b.IsBenched(vdrID0))
+	require.False(benched)
+
+	b.clock.Set(b.clock.Time().Add(minimumFailingDuration + time.Second))
+
+	// A validator with a good reputation isn't benched before reaching the
+	// failure threshold
+	b.RegisterFailure(vdrID1)
+	require.False(b.IsBenched(vdrID1))
+	require.False(benched)
+
+	// A validator with a bad reputation is
+	b.RegisterFailure(vdrID0)
+	require.True(b.IsBenched(vdrID0))
+	require.True(benched)
+	require.NotContains(b.failureStreaks, vdrID0)
+}
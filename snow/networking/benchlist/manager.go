@@ -9,6 +9,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 )
 
@@ -41,14 +42,20 @@ type Manager interface {
 type Config struct {
 	Benchable              Benchable          `json:"-"`
 	Validators             validators.Manager `json:"-"`
+	Reputation             reputation.Tracker `json:"-"`
 	Threshold              int                `json:"threshold"`
 	MinimumFailingDuration time.Duration      `json:"minimumFailingDuration"`
 	Duration               time.Duration      `json:"duration"`
 	MaxPortion             float64            `json:"maxPortion"`
+	// A validator with a reputation score below [MinReputation] is benched
+	// once it has been failing for [MinimumFailingDuration], regardless of
+	// [Threshold]. 0 disables this.
+	MinReputation float64 `json:"minReputation"`
 }
 
 type manager struct {
-	config *Config
+	config     *Config
+	reputation reputation.Tracker
 	// Chain ID --> benchlist for that chain.
 	// Each benchlist is safe for concurrent access.
 	chainBenchlists map[ids.ID]Benchlist
@@ -63,10 +70,15 @@ func NewManager(config *Config) Manager {
 	if config.MaxPortion <= 0 {
 		return NewNoBenchlist()
 	}
-	return &manager{
+	m := &manager{
 		config:          config,
+		reputation:      config.Reputation,
 		chainBenchlists: make(map[ids.ID]Benchlist),
 	}
+	if m.reputation == nil {
+		m.reputation = reputation.NewNoTracker()
+	}
+	return m
 }
 
 // IsBenched returns true if messages to [nodeID] regarding [chainID]
@@ -116,6 +128,7 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		m.config.MinimumFailingDuration,
 		m.config.Duration,
 		m.config.MaxPortion,
+		WithReputation(m.reputation, m.config.MinReputation),
 	)
 	if err != nil {
 		return err
@@ -126,6 +139,8 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 }
 
 func (m *manager) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
+	m.reputation.Report(nodeID, reputation.Response)
+
 	m.lock.RLock()
 	benchlist, exists := m.chainBenchlists[chainID]
 	m.lock.RUnlock()
@@ -137,6 +152,8 @@ func (m *manager) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
 }
 
 func (m *manager) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
+	m.reputation.Report(nodeID, reputation.Timeout)
+
 	m.lock.RLock()
 	benchlist, exists := m.chainBenchlists[chainID]
 	m.lock.RUnlock()
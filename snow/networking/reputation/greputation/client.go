@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package greputation
+
+import (
+	"context"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
+
+	pb "github.com/MetalBlockchain/metalgo/proto/pb/reputation"
+)
+
+var _ reputation.Tracker = (*Client)(nil)
+
+// Client forwards reports and score lookups to a remote reputation tracker.
+type Client struct {
+	client pb.ReputationClient
+}
+
+func NewClient(client pb.ReputationClient) *Client {
+	return &Client{client: client}
+}
+
+func (c *Client) Report(nodeID ids.NodeID, behavior reputation.Behavior) {
+	// Reports are best effort, so a failure to forward a report is dropped.
+	_, _ = c.client.Report(context.Background(), &pb.ReportRequest{
+		NodeId:   nodeID.Bytes(),
+		Behavior: uint32(behavior),
+	})
+}
+
+func (c *Client) Score(nodeID ids.NodeID) float64 {
+	resp, err := c.client.Score(context.Background(), &pb.ScoreRequest{
+		NodeId: nodeID.Bytes(),
+	})
+	if err != nil {
+		// Peers aren't penalized if their reputation can't be looked up.
+		return 1
+	}
+	return resp.Score
+}
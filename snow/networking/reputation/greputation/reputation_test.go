@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package greputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/grpcutils"
+
+	pb "github.com/MetalBlockchain/metalgo/proto/pb/reputation"
+)
+
+func setupClient(t testing.TB, tracker reputation.Tracker) *Client {
+	require := require.New(t)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	serverCloser := grpcutils.ServerCloser{}
+
+	server := grpcutils.NewServer()
+	pb.RegisterReputationServer(server, NewServer(tracker))
+	serverCloser.Add(server)
+
+	go grpcutils.Serve(listener, server)
+
+	conn, err := grpcutils.Dial(listener.Addr().String())
+	require.NoError(err)
+
+	t.Cleanup(func() {
+		serverCloser.Stop()
+		_ = conn.Close()
+		_ = listener.Close()
+	})
+
+	return NewClient(pb.NewReputationClient(conn))
+}
+
+func TestReportAndScore(t *testing.T) {
+	require := require.New(t)
+
+	tracker, err := reputation.NewTracker(
+		reputation.Config{
+			Halflife: time.Hour,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	client := setupClient(t, tracker)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.Equal(1.0, client.Score(nodeID))
+
+	// Reports made through the client are tracked by the server
+	client.Report(nodeID, reputation.InvalidBlock)
+	score := client.Score(nodeID)
+	require.Less(score, 1.0)
+	require.InDelta(tracker.Score(nodeID), score, 0.01)
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package greputation
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
+
+	pb "github.com/MetalBlockchain/metalgo/proto/pb/reputation"
+)
+
+var _ pb.ReputationServer = (*Server)(nil)
+
+type Server struct {
+	pb.UnsafeReputationServer
+	tracker reputation.Tracker
+}
+
+func NewServer(tracker reputation.Tracker) *Server {
+	return &Server{tracker: tracker}
+}
+
+func (s *Server) Report(_ context.Context, req *pb.ReportRequest) (*emptypb.Empty, error) {
+	nodeID, err := ids.ToNodeID(req.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.tracker.Report(nodeID, reputation.Behavior(req.Behavior))
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) Score(_ context.Context, req *pb.ScoreRequest) (*pb.ScoreResponse, error) {
+	nodeID, err := ids.ToNodeID(req.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ScoreResponse{
+		Score: s.tracker.Score(nodeID),
+	}, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package reputation tracks how well peers have behaved.
+//
+// Engines and VMs report the behavior of peers to a Tracker. Bad behavior,
+// such as sending an invalid block, penalizes the peer. Good behavior, such as
+// responding to a request in time, pays back some of the penalty. Penalties
+// decay over time so that a peer is eventually forgiven.
+//
+// A peer's score is in (0, 1], where 1 is a peer without any penalty. The
+// score is used to weight how much the node relies on the peer, for example
+// when sampling peers to send requests to or when allocating inbound message
+// bytes to the peer.
+package reputation
+
+import (
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+// Behavior is a behavior of a peer that can be reported.
+type Behavior int
+
+const (
+	// Response is reported when a peer responded to a request in time.
+	Response Behavior = iota
+	// Timeout is reported when a peer didn't respond to a request in time.
+	Timeout
+	// InvalidGossip is reported when a peer gossiped a message that is
+	// malformed, invalid or unrequested.
+	InvalidGossip
+	// InvalidProof is reported when a peer sent a proof that failed
+	// verification.
+	InvalidProof
+	// InvalidBlock is reported when a peer sent a block that failed
+	// verification.
+	InvalidBlock
+)
+
+// behaviorPenalties are the penalties added to the score of a peer when a
+// behavior is reported. Negative penalties are rewards.
+var behaviorPenalties = map[Behavior]float64{
+	Response:      -0.01,
+	Timeout:       0.05,
+	InvalidGossip: 0.1,
+	InvalidProof:  0.5,
+	InvalidBlock:  1,
+}
+
+func (b Behavior) String() string {
+	switch b {
+	case Response:
+		return "response"
+	case Timeout:
+		return "timeout"
+	case InvalidGossip:
+		return "invalid_gossip"
+	case InvalidProof:
+		return "invalid_proof"
+	case InvalidBlock:
+		return "invalid_block"
+	default:
+		return "unknown"
+	}
+}
+
+// Reporter is notified of the behavior of peers.
+type Reporter interface {
+	// Report that [nodeID] behaved as [behavior].
+	Report(nodeID ids.NodeID, behavior Behavior)
+}
+
+// Scorer scores peers based on their reputation.
+type Scorer interface {
+	// Score returns the score of [nodeID] in (0, 1]. Peers without any
+	// reported misbehavior have a score of 1.
+	Score(nodeID ids.NodeID) float64
+}
+
+// Tracker tracks the reputation of peers.
+type Tracker interface {
+	Reporter
+	Scorer
+}
+
+// Config defines the configuration for a reputation tracker
+type Config struct {
+	// Halflife is the amount of time it takes for a peer's penalty to halve.
+	Halflife time.Duration `json:"halflife"`
+}
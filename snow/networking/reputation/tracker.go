@@ -0,0 +1,159 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+// Penalties below [minPenalty] are forgotten.
+const minPenalty = 1e-4
+
+var (
+	_ Tracker = (*tracker)(nil)
+	_ Tracker = (*noTracker)(nil)
+
+	errNonPositiveHalflife = errors.New("halflife must be positive")
+)
+
+type penalty struct {
+	value       float64
+	lastUpdated time.Time
+}
+
+type tracker struct {
+	halflife time.Duration
+	metrics  metrics
+	// Tells the time. Can be faked for testing.
+	clock mockable.Clock
+
+	lock sync.Mutex
+	// Node ID --> Penalty of the node. Nodes without a penalty aren't
+	// included.
+	penalties map[ids.NodeID]*penalty
+}
+
+type metrics struct {
+	reports           *prometheus.CounterVec
+	numPenalizedPeers prometheus.Gauge
+}
+
+// NewTracker returns a Tracker whose penalties halve every
+// [config.Halflife].
+func NewTracker(config Config, registerer prometheus.Registerer) (Tracker, error) {
+	if config.Halflife <= 0 {
+		return nil, errNonPositiveHalflife
+	}
+
+	t := &tracker{
+		halflife: config.Halflife,
+		metrics: metrics{
+			reports: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: "reputation",
+					Name:      "reports",
+					Help:      "Number of reported peer behaviors",
+				},
+				[]string{"behavior"},
+			),
+			numPenalizedPeers: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: "reputation",
+				Name:      "penalized_peers",
+				Help:      "Number of peers with a penalty",
+			}),
+		},
+		penalties: make(map[ids.NodeID]*penalty),
+	}
+	err := utils.Err(
+		registerer.Register(t.metrics.reports),
+		registerer.Register(t.metrics.numPenalizedPeers),
+	)
+	return t, err
+}
+
+func (t *tracker) Report(nodeID ids.NodeID, behavior Behavior) {
+	t.metrics.reports.WithLabelValues(behavior.String()).Inc()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	p, ok := t.decayedPenalty(nodeID)
+	if !ok {
+		if behaviorPenalties[behavior] <= 0 {
+			// The peer doesn't have a penalty to pay back.
+			return
+		}
+		p = &penalty{
+			lastUpdated: t.clock.Time(),
+		}
+		t.penalties[nodeID] = p
+		t.metrics.numPenalizedPeers.Set(float64(len(t.penalties)))
+	}
+
+	p.value = max(0, p.value+behaviorPenalties[behavior])
+	if p.value < minPenalty {
+		t.forget(nodeID)
+	}
+}
+
+func (t *tracker) Score(nodeID ids.NodeID) float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	p, ok := t.decayedPenalty(nodeID)
+	if !ok {
+		return 1
+	}
+	return 1 / (1 + p.value)
+}
+
+// decayedPenalty returns the penalty of [nodeID] after decaying it to the
+// current time. Returns false if [nodeID] doesn't have a penalty.
+//
+// Assumes [t.lock] is held.
+func (t *tracker) decayedPenalty(nodeID ids.NodeID) (*penalty, bool) {
+	p, ok := t.penalties[nodeID]
+	if !ok {
+		return nil, false
+	}
+
+	now := t.clock.Time()
+	elapsed := now.Sub(p.lastUpdated)
+	p.value *= math.Exp2(-float64(elapsed) / float64(t.halflife))
+	p.lastUpdated = now
+	if p.value < minPenalty {
+		t.forget(nodeID)
+		return nil, false
+	}
+	return p, true
+}
+
+// Assumes [t.lock] is held.
+func (t *tracker) forget(nodeID ids.NodeID) {
+	delete(t.penalties, nodeID)
+	t.metrics.numPenalizedPeers.Set(float64(len(t.penalties)))
+}
+
+type noTracker struct{}
+
+// NewNoTracker returns a Tracker that ignores reports and gives every peer a
+// score of 1.
+func NewNoTracker() Tracker {
+	return noTracker{}
+}
+
+func (noTracker) Report(ids.NodeID, Behavior) {}
+
+func (noTracker) Score(ids.NodeID) float64 {
+	return 1
+}
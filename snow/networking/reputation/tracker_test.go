@@ -0,0 +1,127 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func newTestTracker(t *testing.T, halflife time.Duration) *tracker {
+	require := require.New(t)
+
+	trackerIntf, err := NewTracker(
+		Config{
+			Halflife: halflife,
+		},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	tracker := trackerIntf.(*tracker)
+	tracker.clock.Set(time.Now())
+	return tracker
+}
+
+func TestNewTrackerInvalidHalflife(t *testing.T) {
+	_, err := NewTracker(Config{}, prometheus.NewRegistry())
+	require.ErrorIs(t, err, errNonPositiveHalflife)
+}
+
+func TestTrackerScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		behaviors     []Behavior
+		expectedScore float64
+	}{
+		{
+			name:          "no reports",
+			expectedScore: 1,
+		},
+		{
+			name:          "reward without penalty",
+			behaviors:     []Behavior{Response},
+			expectedScore: 1,
+		},
+		{
+			name:          "invalid block",
+			behaviors:     []Behavior{InvalidBlock},
+			expectedScore: 0.5,
+		},
+		{
+			name:          "penalties accumulate",
+			behaviors:     []Behavior{InvalidBlock, InvalidProof, InvalidProof},
+			expectedScore: 1.0 / 3,
+		},
+		{
+			name:          "rewards pay back penalties",
+			behaviors:     []Behavior{Timeout, Response, Response, Response, Response, Response},
+			expectedScore: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tracker := newTestTracker(t, time.Minute)
+			nodeID := ids.GenerateTestNodeID()
+			for _, behavior := range tt.behaviors {
+				tracker.Report(nodeID, behavior)
+			}
+			require.InDelta(tt.expectedScore, tracker.Score(nodeID), 1e-9)
+
+			// Other peers aren't affected.
+			require.Equal(float64(1), tracker.Score(ids.GenerateTestNodeID()))
+		})
+	}
+}
+
+func TestTrackerPenaltyDecays(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t, time.Minute)
+	nodeID := ids.GenerateTestNodeID()
+
+	tracker.Report(nodeID, InvalidBlock)
+	require.InDelta(0.5, tracker.Score(nodeID), 1e-9)
+	require.Equal(float64(1), testutil.ToFloat64(tracker.metrics.numPenalizedPeers))
+
+	// After a halflife, half of the penalty remains.
+	tracker.clock.Set(tracker.clock.Time().Add(time.Minute))
+	require.InDelta(1/1.5, tracker.Score(nodeID), 1e-9)
+
+	// Eventually the penalty is forgotten.
+	tracker.clock.Set(tracker.clock.Time().Add(time.Hour))
+	require.Equal(float64(1), tracker.Score(nodeID))
+	require.Empty(tracker.penalties)
+	require.Zero(testutil.ToFloat64(tracker.metrics.numPenalizedPeers))
+}
+
+func TestTrackerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t, time.Minute)
+	nodeID := ids.GenerateTestNodeID()
+
+	tracker.Report(nodeID, Timeout)
+	tracker.Report(nodeID, Timeout)
+	tracker.Report(nodeID, InvalidGossip)
+
+	require.Equal(float64(2), testutil.ToFloat64(tracker.metrics.reports.WithLabelValues(Timeout.String())))
+	require.Equal(float64(1), testutil.ToFloat64(tracker.metrics.reports.WithLabelValues(InvalidGossip.String())))
+	require.Zero(testutil.ToFloat64(tracker.metrics.reports.WithLabelValues(InvalidBlock.String())))
+}
+
+func TestNoTracker(t *testing.T) {
+	tracker := NewNoTracker()
+	nodeID := ids.GenerateTestNodeID()
+
+	tracker.Report(nodeID, InvalidBlock)
+	require.Equal(t, float64(1), tracker.Score(nodeID))
+}
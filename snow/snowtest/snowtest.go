@@ -14,6 +14,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/metrics"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
@@ -92,6 +93,8 @@ func Context(tb testing.TB, chainID ids.ID) *snow.Context {
 		BCLookup: aliaser,
 		Metrics:  metrics.NewOptionalGatherer(),
 
+		Reputation: reputation.NewNoTracker(),
+
 		ValidatorState: validatorState,
 		ChainDataDir:   "",
 	}
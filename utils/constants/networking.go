@@ -61,10 +61,22 @@ const (
 	// a timeout of 0 should generally not be provided.
 	DefaultNetworkTCPProxyReadTimeout = 3 * time.Second
 
+	DefaultNetworkQUICEnabled = false
+	// Peers that can't be connected to over QUIC within this timeout are
+	// connected to over TCP.
+	DefaultNetworkQUICHandshakeTimeout = 5 * time.Second
+	// The maximum number of inbound QUIC connections that may be waiting for
+	// their streams to be opened or to be accepted.
+	DefaultNetworkQUICMaxPendingInboundConns = 64
+
 	// Benchlist
 	DefaultBenchlistFailThreshold      = 10
 	DefaultBenchlistDuration           = 15 * time.Minute
 	DefaultBenchlistMinFailingDuration = 2*time.Minute + 30*time.Second
+	DefaultBenchlistMinReputation      = 0
+
+	// Reputation
+	DefaultReputationHalflife = 10 * time.Minute
 
 	// Router
 	DefaultConsensusAppConcurrency  = 2
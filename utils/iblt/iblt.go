@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package iblt implements an invertible bloom lookup table of IDs.
+//
+// Subtracting the table of one set from the table of another set with the same
+// number of cells and salt results in a table of the symmetric difference of
+// the sets, which can be decoded if the table has enough cells. This allows two
+// peers to reconcile their sets with bandwidth proportional to the difference
+// of the sets, rather than to the size of the sets.
+package iblt
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+const (
+	// NumHashes is the number of cells that each ID is added to.
+	NumHashes = 3
+
+	countLen   = 4
+	hashSumLen = 8
+	cellLen    = countLen + ids.IDLen + hashSumLen
+
+	// Decoding a table with fewer cells than ~1.23x the size of the difference
+	// fails with high probability. Small differences require additional cells
+	// to decode reliably.
+	cellsPerDifference = 2
+	extraCells         = 8 * NumHashes
+)
+
+var (
+	errInvalidNumCells = errors.New("number of cells must be a positive multiple of the number of hashes")
+	errInvalidLength   = errors.New("length must be a multiple of the cell length")
+	errMismatchedSize  = errors.New("tables have a different number of cells")
+	errMismatchedSalt  = errors.New("tables have a different salt")
+)
+
+type cell struct {
+	count   int32
+	idSum   ids.ID
+	hashSum uint64
+}
+
+// pure returns true if the cell contains exactly one ID.
+func (c *cell) pure(salt ids.ID) bool {
+	if c.count != 1 && c.count != -1 {
+		return false
+	}
+	_, hashSum := hash(c.idSum, salt)
+	return hashSum == c.hashSum
+}
+
+func (c *cell) empty() bool {
+	return c.count == 0 && c.idSum == ids.Empty && c.hashSum == 0
+}
+
+// Table is an invertible bloom lookup table. The cells are partitioned into
+// [NumHashes] subtables so that every ID is added to [NumHashes] distinct
+// cells.
+type Table struct {
+	salt  ids.ID
+	cells []cell
+}
+
+// NumCells returns the number of cells that a table should have to decode a
+// difference of [difference] IDs with high probability.
+//
+// Decoding can fail regardless of the number of cells, for example if two IDs
+// are added to the same cells, so callers should be prepared to retry with a
+// larger table or a different salt.
+func NumCells(difference int) int {
+	numCells := int(math.Ceil(cellsPerDifference*float64(difference))) + extraCells
+	return roundUp(numCells)
+}
+
+// New returns an empty table with at least [numCells] cells, rounded up to a
+// multiple of [NumHashes].
+func New(numCells int, salt ids.ID) (*Table, error) {
+	if numCells <= 0 {
+		return nil, errInvalidNumCells
+	}
+	return &Table{
+		salt:  salt,
+		cells: make([]cell, roundUp(numCells)),
+	}, nil
+}
+
+// Parse returns the table that was marshalled into [bytes] by [Table.Marshal].
+func Parse(bytes []byte, salt ids.ID) (*Table, error) {
+	if len(bytes)%cellLen != 0 {
+		return nil, errInvalidLength
+	}
+	numCells := len(bytes) / cellLen
+	if numCells == 0 || numCells%NumHashes != 0 {
+		return nil, errInvalidNumCells
+	}
+
+	t := &Table{
+		salt:  salt,
+		cells: make([]cell, numCells),
+	}
+	for i := range t.cells {
+		c := &t.cells[i]
+		c.count = int32(binary.BigEndian.Uint32(bytes))
+		bytes = bytes[countLen:]
+		copy(c.idSum[:], bytes)
+		bytes = bytes[ids.IDLen:]
+		c.hashSum = binary.BigEndian.Uint64(bytes)
+		bytes = bytes[hashSumLen:]
+	}
+	return t, nil
+}
+
+// Len returns the number of cells in the table.
+func (t *Table) Len() int {
+	return len(t.cells)
+}
+
+// Add [id] to the table.
+func (t *Table) Add(id ids.ID) {
+	t.update(id, 1)
+}
+
+// Remove [id] from the table. [id] doesn't need to have been added to the
+// table.
+func (t *Table) Remove(id ids.ID) {
+	t.update(id, -1)
+}
+
+func (t *Table) update(id ids.ID, count int32) {
+	indices, hashSum := hash(id, t.salt)
+	subtableLen := uint64(len(t.cells) / NumHashes)
+	for i, index := range indices {
+		c := &t.cells[uint64(i)*subtableLen+index%subtableLen]
+		c.count += count
+		xor(&c.idSum, &id)
+		c.hashSum ^= hashSum
+	}
+}
+
+// Subtract [other] from the table. After subtracting, the table contains the
+// IDs that were only added to the table and the IDs that were only added to
+// [other] as removed.
+func (t *Table) Subtract(other *Table) error {
+	if len(t.cells) != len(other.cells) {
+		return errMismatchedSize
+	}
+	if t.salt != other.salt {
+		return errMismatchedSalt
+	}
+
+	for i := range t.cells {
+		c := &t.cells[i]
+		o := &other.cells[i]
+		c.count -= o.count
+		xor(&c.idSum, &o.idSum)
+		c.hashSum ^= o.hashSum
+	}
+	return nil
+}
+
+// Decode the IDs that were added to and removed from the table. Returns false
+// if the table has too few cells to decode all of the IDs, in which case the
+// returned IDs are the subset that were decoded.
+//
+// Decode empties the table of the returned IDs.
+func (t *Table) Decode() ([]ids.ID, []ids.ID, bool) {
+	var (
+		added   []ids.ID
+		removed []ids.ID
+		peeled  = make(map[ids.ID]struct{})
+		pure    = make([]int, 0, len(t.cells))
+	)
+	for i := range t.cells {
+		if t.cells[i].pure(t.salt) {
+			pure = append(pure, i)
+		}
+	}
+
+	for len(pure) > 0 {
+		c := &t.cells[pure[len(pure)-1]]
+		pure = pure[:len(pure)-1]
+		// Peeling other cells may have modified this cell since it was
+		// found to be pure.
+		if !c.pure(t.salt) {
+			continue
+		}
+
+		// A well-formed table never contains the same ID twice, so peeling an
+		// ID again means that the table is malformed. Every peel empties at
+		// least one cell, so a table can't have more IDs than cells.
+		id := c.idSum
+		if _, ok := peeled[id]; ok || len(peeled) >= len(t.cells) {
+			return added, removed, false
+		}
+		peeled[id] = struct{}{}
+
+		if c.count > 0 {
+			added = append(added, id)
+			t.Remove(id)
+		} else {
+			removed = append(removed, id)
+			t.Add(id)
+		}
+
+		indices, _ := hash(id, t.salt)
+		subtableLen := uint64(len(t.cells) / NumHashes)
+		for i, index := range indices {
+			cellIndex := int(uint64(i)*subtableLen + index%subtableLen)
+			if t.cells[cellIndex].pure(t.salt) {
+				pure = append(pure, cellIndex)
+			}
+		}
+	}
+
+	for i := range t.cells {
+		if !t.cells[i].empty() {
+			return added, removed, false
+		}
+	}
+	return added, removed, true
+}
+
+// Marshal the cells of the table. The salt isn't included.
+func (t *Table) Marshal() []byte {
+	bytes := make([]byte, 0, len(t.cells)*cellLen)
+	for i := range t.cells {
+		c := &t.cells[i]
+		bytes = binary.BigEndian.AppendUint32(bytes, uint32(c.count))
+		bytes = append(bytes, c.idSum[:]...)
+		bytes = binary.BigEndian.AppendUint64(bytes, c.hashSum)
+	}
+	return bytes
+}
+
+// hash returns the indices of the cells that [id] is added to, before being
+// reduced into their subtables, and the checksum of [id].
+func hash(id ids.ID, salt ids.ID) ([NumHashes]uint64, uint64) {
+	hash := sha256.New()
+	// sha256.Write never returns errors
+	_, _ = hash.Write(id[:])
+	_, _ = hash.Write(salt[:])
+
+	output := make([]byte, 0, sha256.Size)
+	output = hash.Sum(output)
+
+	var indices [NumHashes]uint64
+	for i := range indices {
+		indices[i] = binary.BigEndian.Uint64(output[i*8:])
+	}
+	return indices, binary.BigEndian.Uint64(output[NumHashes*8:])
+}
+
+func xor(dst *ids.ID, src *ids.ID) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+func roundUp(numCells int) int {
+	return (numCells + NumHashes - 1) / NumHashes * NumHashes
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package iblt
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		numCells    int
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name:        "no cells",
+			numCells:    0,
+			expectedErr: errInvalidNumCells,
+		},
+		{
+			name:        "multiple of num hashes",
+			numCells:    2 * NumHashes,
+			expectedLen: 2 * NumHashes,
+		},
+		{
+			name:        "rounded up to multiple of num hashes",
+			numCells:    NumHashes + 1,
+			expectedLen: 2 * NumHashes,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			table, err := New(test.numCells, ids.Empty)
+			require.ErrorIs(err, test.expectedErr)
+			if err != nil {
+				return
+			}
+			require.Equal(test.expectedLen, table.Len())
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		numShared  int
+		numAdded   int
+		numRemoved int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "equal sets",
+			numShared: 1000,
+		},
+		{
+			name:     "only added",
+			numAdded: 10,
+		},
+		{
+			name:       "only removed",
+			numRemoved: 10,
+		},
+		{
+			name:       "mostly shared",
+			numShared:  1000,
+			numAdded:   25,
+			numRemoved: 25,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Decoding can fail with a small probability, so the IDs and salt
+			// are fixed to keep the test deterministic.
+			var (
+				salt   = ids.Empty
+				nextID uint64
+			)
+			newID := func() ids.ID {
+				nextID++
+				return ids.Empty.Prefix(nextID)
+			}
+
+			numCells := NumCells(test.numAdded + test.numRemoved)
+			local, err := New(numCells, salt)
+			require.NoError(err)
+			remote, err := New(numCells, salt)
+			require.NoError(err)
+
+			for i := 0; i < test.numShared; i++ {
+				id := newID()
+				local.Add(id)
+				remote.Add(id)
+			}
+			expectedAdded := make([]ids.ID, test.numAdded)
+			for i := range expectedAdded {
+				expectedAdded[i] = newID()
+				local.Add(expectedAdded[i])
+			}
+			expectedRemoved := make([]ids.ID, test.numRemoved)
+			for i := range expectedRemoved {
+				expectedRemoved[i] = newID()
+				remote.Add(expectedRemoved[i])
+			}
+
+			require.NoError(local.Subtract(remote))
+			added, removed, ok := local.Decode()
+			require.True(ok)
+			require.ElementsMatch(expectedAdded, added)
+			require.ElementsMatch(expectedRemoved, removed)
+		})
+	}
+}
+
+func TestDecodeTooFewCells(t *testing.T) {
+	require := require.New(t)
+
+	table, err := New(NumHashes, ids.GenerateTestID())
+	require.NoError(err)
+	for i := 0; i < 100; i++ {
+		table.Add(ids.GenerateTestID())
+	}
+
+	_, _, ok := table.Decode()
+	require.False(ok)
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	require := require.New(t)
+
+	// The first cell claims to contain [id], but the cells that [id] hashes
+	// into in the other subtables are empty, so peeling [id] makes them pure
+	// with the opposite count.
+	var (
+		salt = ids.Empty
+		id   = ids.Empty.Prefix(1)
+	)
+	_, hashSum := hash(id, salt)
+	bytes := make([]byte, NumHashes*cellLen)
+	binary.BigEndian.PutUint32(bytes, 1)
+	copy(bytes[countLen:], id[:])
+	binary.BigEndian.PutUint64(bytes[countLen+ids.IDLen:], hashSum)
+
+	table, err := Parse(bytes, salt)
+	require.NoError(err)
+
+	_, _, ok := table.Decode()
+	require.False(ok)
+}
+
+func TestSubtractMismatched(t *testing.T) {
+	require := require.New(t)
+
+	salt := ids.GenerateTestID()
+	table, err := New(NumHashes, salt)
+	require.NoError(err)
+
+	differentSize, err := New(2*NumHashes, salt)
+	require.NoError(err)
+	err = table.Subtract(differentSize)
+	require.ErrorIs(err, errMismatchedSize)
+
+	differentSalt, err := New(NumHashes, ids.GenerateTestID())
+	require.NoError(err)
+	err = table.Subtract(differentSalt)
+	require.ErrorIs(err, errMismatchedSalt)
+}
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	salt := ids.GenerateTestID()
+	table, err := New(NumCells(10), salt)
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		table.Add(ids.GenerateTestID())
+	}
+	table.Remove(ids.GenerateTestID())
+
+	parsed, err := Parse(table.Marshal(), salt)
+	require.NoError(err)
+	require.Equal(table, parsed)
+
+	_, err = Parse(nil, salt)
+	require.ErrorIs(err, errInvalidNumCells)
+
+	_, err = Parse(make([]byte, cellLen+1), salt)
+	require.ErrorIs(err, errInvalidLength)
+
+	_, err = Parse(make([]byte, cellLen), salt)
+	require.ErrorIs(err, errInvalidNumCells)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/network/p2p/gossip"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/vms/avm/txs"
@@ -45,6 +46,7 @@ func New(
 	nodeID ids.NodeID,
 	subnetID ids.ID,
 	vdrs validators.State,
+	reputation reputation.Tracker,
 	parser txs.Parser,
 	txVerifier TxVerifier,
 	mempool mempool.Mempool,
@@ -66,6 +68,7 @@ func New(
 		subnetID,
 		vdrs,
 		config.MaxValidatorSetStaleness,
+		p2p.WithReputation(reputation),
 	)
 	txGossipClient := p2pNetwork.NewClient(
 		txGossipHandlerID,
@@ -120,6 +123,7 @@ func New(
 		txGossipClient,
 		txGossipMetrics,
 		config.PullGossipPollSize,
+		gossip.WithReputation(reputation),
 	)
 
 	// Gossip requests are only served if a node is a validator
@@ -135,6 +139,7 @@ func New(
 		gossipMempool,
 		txGossipMetrics,
 		config.TargetGossipSize,
+		gossip.WithReputation(reputation),
 	)
 
 	validatorHandler := p2p.NewValidatorHandler(
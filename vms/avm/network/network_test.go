@@ -15,6 +15,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/vms/avm/block/executor"
@@ -184,6 +185,7 @@ func TestNetworkIssueTxFromRPC(t *testing.T) {
 						return nil, nil
 					},
 				},
+				reputation.NewNoTracker(),
 				parser,
 				txVerifierFunc(ctrl),
 				mempoolFunc(ctrl),
@@ -278,6 +280,7 @@ func TestNetworkIssueTxFromRPCWithoutVerification(t *testing.T) {
 						return nil, nil
 					},
 				},
+				reputation.NewNoTracker(),
 				parser,
 				executor.NewMockManager(ctrl), // Should never verify a tx
 				mempoolFunc(ctrl),
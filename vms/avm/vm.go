@@ -430,6 +430,7 @@ func (vm *VM) Linearize(ctx context.Context, stopVertexID ids.ID, toEngine chan<
 		vm.ctx.NodeID,
 		vm.ctx.SubnetID,
 		vm.ctx.ValidatorState,
+		vm.ctx.Reputation,
 		vm.parser,
 		network.NewLockedTxVerifier(
 			&vm.ctx.Lock,
@@ -201,6 +201,7 @@ func newEnvironment(t *testing.T, f fork) *environment { //nolint:unparam
 		res.backend.Ctx.NodeID,
 		res.backend.Ctx.SubnetID,
 		res.backend.Ctx.ValidatorState,
+		res.backend.Ctx.Reputation,
 		txVerifier,
 		res.mempool,
 		res.backend.Config.PartialSyncPrimaryNetwork,
@@ -13,6 +13,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
 )
 
+//go:generate go run ./gen
+
 const CodecVersion = txs.CodecVersion
 
 var (
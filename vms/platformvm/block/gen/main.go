@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MetalBlockchain/metalgo/codec/codecgen"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/block"
+)
+
+const fileName = "generated_codec.go"
+
+// generatedTypes are the types that generated_codec.go is generated for.
+var generatedTypes = []interface{}{
+	block.CommonBlock{},
+
+	block.ApricotProposalBlock{},
+	block.ApricotAbortBlock{},
+	block.ApricotCommitBlock{},
+	block.ApricotStandardBlock{},
+	block.ApricotAtomicBlock{},
+
+	block.BanffProposalBlock{},
+	block.BanffAbortBlock{},
+	block.BanffCommitBlock{},
+	block.BanffStandardBlock{},
+}
+
+func generate() ([]byte, error) {
+	return codecgen.Generate(
+		[]string{reflectcodec.DefaultTagName},
+		generatedTypes...,
+	)
+}
+
+func main() {
+	src, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't generate code: %s\n", err)
+		os.Exit(1)
+	}
+	if err := perms.WriteFile(fileName, src, perms.ReadWrite); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't write %s: %s\n", fileName, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,472 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package block
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+)
+
+var (
+	_ reflectcodec.Marshaler   = (*CommonBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*CommonBlock)(nil)
+	_ reflectcodec.Marshaler   = (*ApricotProposalBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*ApricotProposalBlock)(nil)
+	_ reflectcodec.Marshaler   = (*ApricotAbortBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*ApricotAbortBlock)(nil)
+	_ reflectcodec.Marshaler   = (*ApricotCommitBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*ApricotCommitBlock)(nil)
+	_ reflectcodec.Marshaler   = (*ApricotStandardBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*ApricotStandardBlock)(nil)
+	_ reflectcodec.Marshaler   = (*ApricotAtomicBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*ApricotAtomicBlock)(nil)
+	_ reflectcodec.Marshaler   = (*BanffProposalBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*BanffProposalBlock)(nil)
+	_ reflectcodec.Marshaler   = (*BanffAbortBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*BanffAbortBlock)(nil)
+	_ reflectcodec.Marshaler   = (*BanffCommitBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*BanffCommitBlock)(nil)
+	_ reflectcodec.Marshaler   = (*BanffStandardBlock)(nil)
+	_ reflectcodec.Unmarshaler = (*BanffStandardBlock)(nil)
+
+	codecTags = []string{"serialize"}
+)
+
+func init() {
+	reflectcodec.RegisterGenerated(
+		codecTags,
+		(*CommonBlock)(nil),
+		(*ApricotProposalBlock)(nil),
+		(*ApricotAbortBlock)(nil),
+		(*ApricotCommitBlock)(nil),
+		(*ApricotStandardBlock)(nil),
+		(*ApricotAtomicBlock)(nil),
+		(*BanffProposalBlock)(nil),
+		(*BanffAbortBlock)(nil),
+		(*BanffCommitBlock)(nil),
+		(*BanffStandardBlock)(nil),
+	)
+}
+
+func (v *CommonBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.PrntID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.Hght))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *CommonBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	copy(v.PrntID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Hght = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *CommonBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 40
+	return size, nil
+}
+
+func (v *ApricotProposalBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if v.Tx == nil {
+		return codec.ErrMarshalNil
+	}
+	if err := (*v.Tx).MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ApricotProposalBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	ptr1 := new(txs.Tx)
+	if err := (*ptr1).Unmarshal(c, p); err != nil {
+		return err
+	}
+	v.Tx = ptr1
+	return nil
+}
+
+func (v *ApricotProposalBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	if v.Tx == nil {
+		return 0, codec.ErrMarshalNil
+	}
+	size += 40
+	fieldSize, err = (*v.Tx).Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *ApricotAbortBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ApricotAbortBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ApricotAbortBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 40
+	return size, nil
+}
+
+func (v *ApricotCommitBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ApricotCommitBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ApricotCommitBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 40
+	return size, nil
+}
+
+func (v *ApricotStandardBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if len(v.Transactions) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Transactions), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Transactions)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Transactions {
+		start2 := p.Offset
+		if v.Transactions[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		if err := (*v.Transactions[i1]).MarshalInto(c, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return nil
+}
+
+func (v *ApricotStandardBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Transactions = make([]*txs.Tx, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *txs.Tx
+		start4 := p.Offset
+		ptr5 := new(txs.Tx)
+		if err := (*ptr5).Unmarshal(c, p); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Transactions = append(v.Transactions, elem3)
+	}
+	return nil
+}
+
+func (v *ApricotStandardBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 44
+	start2 := size
+	for i1 := range v.Transactions {
+		if v.Transactions[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		fieldSize, err = (*v.Transactions[i1]).Size(c)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return size, nil
+}
+
+func (v *ApricotAtomicBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if v.Tx == nil {
+		return codec.ErrMarshalNil
+	}
+	if err := (*v.Tx).MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *ApricotAtomicBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.CommonBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	ptr1 := new(txs.Tx)
+	if err := (*ptr1).Unmarshal(c, p); err != nil {
+		return err
+	}
+	v.Tx = ptr1
+	return nil
+}
+
+func (v *ApricotAtomicBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	if v.Tx == nil {
+		return 0, codec.ErrMarshalNil
+	}
+	size += 40
+	fieldSize, err = (*v.Tx).Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *BanffProposalBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Time))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Transactions) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Transactions), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Transactions)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Transactions {
+		start2 := p.Offset
+		if v.Transactions[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		if err := (*v.Transactions[i1]).MarshalInto(c, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if err := v.ApricotProposalBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffProposalBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Time = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Transactions = make([]*txs.Tx, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *txs.Tx
+		start4 := p.Offset
+		ptr5 := new(txs.Tx)
+		if err := (*ptr5).Unmarshal(c, p); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Transactions = append(v.Transactions, elem3)
+	}
+	if err := v.ApricotProposalBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffProposalBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 12
+	start2 := size
+	for i1 := range v.Transactions {
+		if v.Transactions[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		fieldSize, err = (*v.Transactions[i1]).Size(c)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	fieldSize, err = v.ApricotProposalBlock.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *BanffAbortBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Time))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := v.ApricotAbortBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffAbortBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Time = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	if err := v.ApricotAbortBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffAbortBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 48
+	return size, nil
+}
+
+func (v *BanffCommitBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Time))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := v.ApricotCommitBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffCommitBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Time = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	if err := v.ApricotCommitBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffCommitBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 48
+	return size, nil
+}
+
+func (v *BanffStandardBlock) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Time))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := v.ApricotStandardBlock.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffStandardBlock) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Time = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	if err := v.ApricotStandardBlock.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *BanffStandardBlock) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 8
+	fieldSize, err = v.ApricotStandardBlock.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+)
+
+// newReflectiveCodec returns a codec that (un)marshals blocks like Codec, but
+// with reflection instead of the generated code.
+func newReflectiveCodec(t testing.TB) codec.Manager {
+	require := require.New(t)
+
+	c := linearcodec.NewReflective([]string{reflectcodec.DefaultTagName})
+	require.NoError(RegisterApricotBlockTypes(c))
+	require.NoError(txs.RegisterUnsignedTxsTypes(c))
+	require.NoError(RegisterBanffBlockTypes(c))
+	require.NoError(txs.RegisterDUnsignedTxsTypes(c))
+
+	m := codec.NewDefaultManager()
+	require.NoError(m.RegisterCodec(CodecVersion, c))
+	return m
+}
+
+func newGeneratedCodecTestBlocks(t testing.TB) []Block {
+	require := require.New(t)
+
+	var (
+		timestamp = time.Unix(1_000_000, 0)
+		parentID  = ids.GenerateTestID()
+		height    = uint64(2022)
+	)
+	decisionTxs, err := testDecisionTxs()
+	require.NoError(err)
+	proposalTx, err := testProposalTx()
+	require.NoError(err)
+	atomicTx, err := testAtomicTx()
+	require.NoError(err)
+
+	apricotProposalBlk, err := NewApricotProposalBlock(parentID, height, proposalTx)
+	require.NoError(err)
+	apricotAbortBlk, err := NewApricotAbortBlock(parentID, height)
+	require.NoError(err)
+	apricotCommitBlk, err := NewApricotCommitBlock(parentID, height)
+	require.NoError(err)
+	apricotStandardBlk, err := NewApricotStandardBlock(parentID, height, decisionTxs)
+	require.NoError(err)
+	apricotAtomicBlk, err := NewApricotAtomicBlock(parentID, height, atomicTx)
+	require.NoError(err)
+	banffProposalBlk, err := NewBanffProposalBlock(timestamp, parentID, height, proposalTx, decisionTxs)
+	require.NoError(err)
+	banffAbortBlk, err := NewBanffAbortBlock(timestamp, parentID, height)
+	require.NoError(err)
+	banffCommitBlk, err := NewBanffCommitBlock(timestamp, parentID, height)
+	require.NoError(err)
+	banffStandardBlk, err := NewBanffStandardBlock(timestamp, parentID, height, decisionTxs)
+	require.NoError(err)
+
+	return []Block{
+		apricotProposalBlk,
+		apricotAbortBlk,
+		apricotCommitBlk,
+		apricotStandardBlk,
+		apricotAtomicBlk,
+		banffProposalBlk,
+		banffAbortBlk,
+		banffCommitBlk,
+		banffStandardBlk,
+	}
+}
+
+// Test that the generated code marshals and parses blocks identically to
+// reflection.
+func TestGeneratedCodec(t *testing.T) {
+	reflective := newReflectiveCodec(t)
+	for _, blk := range newGeneratedCodecTestBlocks(t) {
+		t.Run(fmt.Sprintf("%T", blk), func(t *testing.T) {
+			require := require.New(t)
+
+			expectedBytes, err := reflective.Marshal(CodecVersion, &blk)
+			require.NoError(err)
+			require.Equal(expectedBytes, blk.Bytes())
+
+			size, err := Codec.Size(CodecVersion, &blk)
+			require.NoError(err)
+			require.Len(expectedBytes, size)
+
+			expectedBlk, err := Parse(reflective, expectedBytes)
+			require.NoError(err)
+			parsedBlk, err := Parse(Codec, expectedBytes)
+			require.NoError(err)
+			require.Equal(expectedBlk, parsedBlk)
+		})
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		codec func(testing.TB) codec.Manager
+	}{
+		{
+			name:  "reflective",
+			codec: newReflectiveCodec,
+		},
+		{
+			name: "generated",
+			codec: func(testing.TB) codec.Manager {
+				return Codec
+			},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			var (
+				c    = benchmark.codec(b)
+				blks = newGeneratedCodecTestBlocks(b)
+			)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, blk := range blks {
+					_, err := c.Marshal(CodecVersion, &blk)
+					require.NoError(b, err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		codec func(testing.TB) codec.Manager
+	}{
+		{
+			name:  "reflective",
+			codec: newReflectiveCodec,
+		},
+		{
+			name: "generated",
+			codec: func(testing.TB) codec.Manager {
+				return Codec
+			},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			var (
+				c    = benchmark.codec(b)
+				blks = newGeneratedCodecTestBlocks(b)
+			)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, blk := range blks {
+					_, err := Parse(c, blk.Bytes())
+					require.NoError(b, err)
+				}
+			}
+		})
+	}
+}
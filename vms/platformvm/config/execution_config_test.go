@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/network"
 )
 
@@ -69,6 +70,7 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			"chain-db-cache-size": 7,
 			"block-id-cache-size": 8,
 			"fx-owner-cache-size": 9,
+			"cache-policy": "tinylfu",
 			"checksums-enabled": true,
 			"mempool-prune-frequency": 60000000000
 		}`)
@@ -102,6 +104,7 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			ChainDBCacheSize:             7,
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
+			CachePolicy:                  cache.TinyLFUPolicy,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        time.Minute,
 		}
@@ -160,6 +163,7 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 			ChainDBCacheSize:             7,
 			BlockIDCacheSize:             8,
 			FxOwnerCacheSize:             9,
+			CachePolicy:                  DefaultExecutionConfig.CachePolicy,
 			ChecksumsEnabled:             true,
 			MempoolPruneFrequency:        30 * time.Minute,
 		}
@@ -167,6 +167,7 @@ func TestNetworkIssueTxFromRPC(t *testing.T) {
 				snowCtx.NodeID,
 				snowCtx.SubnetID,
 				snowCtx.ValidatorState,
+				snowCtx.Reputation,
 				tt.txVerifier,
 				tt.mempoolFunc(ctrl),
 				tt.partialSyncPrimaryNetwork,
@@ -449,10 +449,14 @@ func newState(
 		return nil, err
 	}
 
+	baseBlockCache, err := cache.NewSized[ids.ID, block.Block](execCfg.CachePolicy, execCfg.BlockCacheSize, blockSize)
+	if err != nil {
+		return nil, err
+	}
 	blockCache, err := metercacher.New[ids.ID, block.Block](
 		"block_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, block.Block](execCfg.BlockCacheSize, blockSize),
+		baseBlockCache,
 	)
 	if err != nil {
 		return nil, err
@@ -477,10 +481,14 @@ func newState(
 	validatorWeightDiffsDB := prefixdb.New(ValidatorWeightDiffsPrefix, validatorsDB)
 	validatorPublicKeyDiffsDB := prefixdb.New(ValidatorPublicKeyDiffsPrefix, validatorsDB)
 
+	baseTxCache, err := cache.NewSized[ids.ID, *txAndStatus](execCfg.CachePolicy, execCfg.TxCacheSize, txAndStatusSize)
+	if err != nil {
+		return nil, err
+	}
 	txCache, err := metercacher.New(
 		"tx_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, *txAndStatus](execCfg.TxCacheSize, txAndStatusSize),
+		baseTxCache,
 	)
 	if err != nil {
 		return nil, err
@@ -505,21 +513,29 @@ func newState(
 	subnetBaseDB := prefixdb.New(SubnetPrefix, baseDB)
 
 	subnetOwnerDB := prefixdb.New(SubnetOwnerPrefix, baseDB)
+	baseSubnetOwnerCache, err := cache.NewSized[ids.ID, fxOwnerAndSize](execCfg.CachePolicy, execCfg.FxOwnerCacheSize, func(_ ids.ID, f fxOwnerAndSize) int {
+		return ids.IDLen + f.size
+	})
+	if err != nil {
+		return nil, err
+	}
 	subnetOwnerCache, err := metercacher.New[ids.ID, fxOwnerAndSize](
 		"subnet_owner_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, fxOwnerAndSize](execCfg.FxOwnerCacheSize, func(_ ids.ID, f fxOwnerAndSize) int {
-			return ids.IDLen + f.size
-		}),
+		baseSubnetOwnerCache,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	baseTransformedSubnetCache, err := cache.NewSized[ids.ID, *txs.Tx](execCfg.CachePolicy, execCfg.TransformedSubnetTxCacheSize, txSize)
+	if err != nil {
+		return nil, err
+	}
 	transformedSubnetCache, err := metercacher.New(
 		"transformed_subnet_cache",
 		metricsReg,
-		cache.NewSizedLRU[ids.ID, *txs.Tx](execCfg.TransformedSubnetTxCacheSize, txSize),
+		baseTransformedSubnetCache,
 	)
 	if err != nil {
 		return nil, err
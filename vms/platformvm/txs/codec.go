@@ -15,6 +15,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
 )
 
+//go:generate go run ./gen
+
 const CodecVersion = 0
 
 var (
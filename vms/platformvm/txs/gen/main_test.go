@@ -0,0 +1,25 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test that the generated code is the same as the code that is generated by
+// the current generator.
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	require := require.New(t)
+
+	expected, err := generate()
+	require.NoError(err)
+
+	generated, err := os.ReadFile(filepath.Join("..", fileName))
+	require.NoError(err)
+	require.Equal(string(expected), string(generated), "run go generate")
+}
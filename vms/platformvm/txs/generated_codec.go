@@ -0,0 +1,1655 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package txs
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/components/verify"
+)
+
+var (
+	_ reflectcodec.Marshaler   = (*Tx)(nil)
+	_ reflectcodec.Unmarshaler = (*Tx)(nil)
+	_ reflectcodec.Marshaler   = (*BaseTx)(nil)
+	_ reflectcodec.Unmarshaler = (*BaseTx)(nil)
+	_ reflectcodec.Marshaler   = (*Validator)(nil)
+	_ reflectcodec.Unmarshaler = (*Validator)(nil)
+	_ reflectcodec.Marshaler   = (*SubnetValidator)(nil)
+	_ reflectcodec.Unmarshaler = (*SubnetValidator)(nil)
+	_ reflectcodec.Marshaler   = (*AddValidatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*AddValidatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*AddSubnetValidatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*AddSubnetValidatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*AddDelegatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*AddDelegatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*CreateChainTx)(nil)
+	_ reflectcodec.Unmarshaler = (*CreateChainTx)(nil)
+	_ reflectcodec.Marshaler   = (*CreateSubnetTx)(nil)
+	_ reflectcodec.Unmarshaler = (*CreateSubnetTx)(nil)
+	_ reflectcodec.Marshaler   = (*ImportTx)(nil)
+	_ reflectcodec.Unmarshaler = (*ImportTx)(nil)
+	_ reflectcodec.Marshaler   = (*ExportTx)(nil)
+	_ reflectcodec.Unmarshaler = (*ExportTx)(nil)
+	_ reflectcodec.Marshaler   = (*AdvanceTimeTx)(nil)
+	_ reflectcodec.Unmarshaler = (*AdvanceTimeTx)(nil)
+	_ reflectcodec.Marshaler   = (*RewardValidatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*RewardValidatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*RemoveSubnetValidatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*RemoveSubnetValidatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*TransformSubnetTx)(nil)
+	_ reflectcodec.Unmarshaler = (*TransformSubnetTx)(nil)
+	_ reflectcodec.Marshaler   = (*AddPermissionlessValidatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*AddPermissionlessValidatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*AddPermissionlessDelegatorTx)(nil)
+	_ reflectcodec.Unmarshaler = (*AddPermissionlessDelegatorTx)(nil)
+	_ reflectcodec.Marshaler   = (*TransferSubnetOwnershipTx)(nil)
+	_ reflectcodec.Unmarshaler = (*TransferSubnetOwnershipTx)(nil)
+
+	codecTags = []string{"serialize"}
+)
+
+func init() {
+	reflectcodec.RegisterGenerated(
+		codecTags,
+		(*Tx)(nil),
+		(*BaseTx)(nil),
+		(*Validator)(nil),
+		(*SubnetValidator)(nil),
+		(*AddValidatorTx)(nil),
+		(*AddSubnetValidatorTx)(nil),
+		(*AddDelegatorTx)(nil),
+		(*CreateChainTx)(nil),
+		(*CreateSubnetTx)(nil),
+		(*ImportTx)(nil),
+		(*ExportTx)(nil),
+		(*AdvanceTimeTx)(nil),
+		(*RewardValidatorTx)(nil),
+		(*RemoveSubnetValidatorTx)(nil),
+		(*TransformSubnetTx)(nil),
+		(*AddPermissionlessValidatorTx)(nil),
+		(*AddPermissionlessDelegatorTx)(nil),
+		(*TransferSubnetOwnershipTx)(nil),
+	)
+}
+
+func (v *Tx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := c.MarshalField(&v.Unsigned, p); err != nil {
+		return err
+	}
+	if len(v.Creds) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Creds), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Creds)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Creds {
+		start2 := p.Offset
+		if err := c.MarshalField(&v.Creds[i1], p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return nil
+}
+
+func (v *Tx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := c.UnmarshalField(p, &v.Unsigned); err != nil {
+		return err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Creds = make([]verify.Verifiable, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 verify.Verifiable
+		start4 := p.Offset
+		if err := c.UnmarshalField(p, &elem3); err != nil {
+			return err
+		}
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.Creds = append(v.Creds, elem3)
+	}
+	return nil
+}
+
+func (v *Tx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = c.FieldSize(&v.Unsigned)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 4
+	start2 := size
+	for i1 := range v.Creds {
+		fieldSize, err = c.FieldSize(&v.Creds[i1])
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return size, nil
+}
+
+func (v *BaseTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackInt(uint32(v.BaseTx.NetworkID))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.BaseTx.BlockchainID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.BaseTx.Outs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.BaseTx.Outs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.BaseTx.Outs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.BaseTx.Outs {
+		start2 := p.Offset
+		if v.BaseTx.Outs[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.BaseTx.Outs[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.BaseTx.Outs[i1]).Out, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if len(v.BaseTx.Ins) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.BaseTx.Ins), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.BaseTx.Ins)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i3 := range v.BaseTx.Ins {
+		start4 := p.Offset
+		if v.BaseTx.Ins[i3] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.BaseTx.Ins[i3]).UTXOID.TxID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		p.PackInt(uint32((*v.BaseTx.Ins[i3]).UTXOID.OutputIndex))
+		if p.Err != nil {
+			return p.Err
+		}
+		p.PackFixedBytes((*v.BaseTx.Ins[i3]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.BaseTx.Ins[i3]).In, p); err != nil {
+			return err
+		}
+		if p.Offset == start4 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if len(v.BaseTx.Memo) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.BaseTx.Memo), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.BaseTx.Memo)))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.BaseTx.Memo)
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *BaseTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.BaseTx.NetworkID = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	copy(v.BaseTx.BlockchainID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.BaseTx.Outs = make([]*avax.TransferableOutput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableOutput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableOutput)
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).Out); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.BaseTx.Outs = append(v.BaseTx.Outs, elem3)
+	}
+	numElts6 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts6 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts6, math.MaxInt32)
+	}
+	v.BaseTx.Ins = make([]*avax.TransferableInput, 0, min(int(numElts6), 16))
+	for i7 := 0; i7 < int(numElts6); i7++ {
+		var elem8 *avax.TransferableInput
+		start9 := p.Offset
+		ptr10 := new(avax.TransferableInput)
+		copy((*ptr10).UTXOID.TxID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		(*ptr10).UTXOID.OutputIndex = uint32(p.UnpackInt())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+		}
+		copy((*ptr10).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr10).In); err != nil {
+			return err
+		}
+		elem8 = ptr10
+		if start9 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.BaseTx.Ins = append(v.BaseTx.Ins, elem8)
+	}
+	numElts11 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts11 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts11, math.MaxInt32)
+	}
+	v.BaseTx.Memo = p.UnpackFixedBytes(int(numElts11))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *BaseTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 40
+	start2 := size
+	for i1 := range v.BaseTx.Outs {
+		if v.BaseTx.Outs[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 32
+		fieldSize, err = c.FieldSize(&(*v.BaseTx.Outs[i1]).Out)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	size += 4
+	start4 := size
+	for i3 := range v.BaseTx.Ins {
+		if v.BaseTx.Ins[i3] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 68
+		fieldSize, err = c.FieldSize(&(*v.BaseTx.Ins[i3]).In)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i3 == 0 && size == start4 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	size += 4
+	size += len(v.BaseTx.Memo)
+	return size, nil
+}
+
+func (v *Validator) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.NodeID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.Start))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.End))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.Wght))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *Validator) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	copy(v.NodeID[:], p.UnpackFixedBytes(20))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.Start = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.End = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.Wght = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *Validator) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 44
+	return size, nil
+}
+
+func (v *SubnetValidator) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.Validator.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *SubnetValidator) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.Validator.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *SubnetValidator) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 76
+	return size, nil
+}
+
+func (v *AddValidatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if len(v.StakeOuts) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.StakeOuts), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.StakeOuts)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.StakeOuts {
+		start2 := p.Offset
+		if v.StakeOuts[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.StakeOuts[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.StakeOuts[i1]).Out, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if err := c.MarshalField(&v.RewardsOwner, p); err != nil {
+		return err
+	}
+	p.PackInt(uint32(v.DelegationShares))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *AddValidatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.Unmarshal(c, p); err != nil {
+		return err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.StakeOuts = make([]*avax.TransferableOutput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableOutput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableOutput)
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).Out); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.StakeOuts = append(v.StakeOuts, elem3)
+	}
+	if err := c.UnmarshalField(p, &v.RewardsOwner); err != nil {
+		return err
+	}
+	v.DelegationShares = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *AddValidatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 48
+	start2 := size
+	for i1 := range v.StakeOuts {
+		if v.StakeOuts[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 32
+		fieldSize, err = c.FieldSize(&(*v.StakeOuts[i1]).Out)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	fieldSize, err = c.FieldSize(&v.RewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 4
+	return size, nil
+}
+
+func (v *AddSubnetValidatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.SubnetValidator.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := c.MarshalField(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *AddSubnetValidatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.SubnetValidator.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := c.UnmarshalField(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *AddSubnetValidatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 76
+	fieldSize, err = c.FieldSize(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *AddDelegatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if len(v.StakeOuts) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.StakeOuts), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.StakeOuts)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.StakeOuts {
+		start2 := p.Offset
+		if v.StakeOuts[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.StakeOuts[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.StakeOuts[i1]).Out, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if err := c.MarshalField(&v.DelegationRewardsOwner, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *AddDelegatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.Unmarshal(c, p); err != nil {
+		return err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.StakeOuts = make([]*avax.TransferableOutput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableOutput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableOutput)
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).Out); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.StakeOuts = append(v.StakeOuts, elem3)
+	}
+	if err := c.UnmarshalField(p, &v.DelegationRewardsOwner); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *AddDelegatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 48
+	start2 := size
+	for i1 := range v.StakeOuts {
+		if v.StakeOuts[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 32
+		fieldSize, err = c.FieldSize(&(*v.StakeOuts[i1]).Out)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	fieldSize, err = c.FieldSize(&v.DelegationRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *CreateChainTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.SubnetID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackStr(string(v.ChainName))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.VMID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.FxIDs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.FxIDs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.FxIDs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.FxIDs {
+		p.PackFixedBytes(v.FxIDs[i1][:])
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	if len(v.GenesisData) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.GenesisData), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.GenesisData)))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.GenesisData)
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.MarshalField(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *CreateChainTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.SubnetID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.ChainName = string(p.UnpackStr())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal string: %w", p.Err)
+	}
+	copy(v.VMID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.FxIDs = make([]ids.ID, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 ids.ID
+		copy(elem3[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.FxIDs = append(v.FxIDs, elem3)
+	}
+	numElts5 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts5 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts5, math.MaxInt32)
+	}
+	v.GenesisData = p.UnpackFixedBytes(int(numElts5))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalField(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *CreateChainTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 32
+	size += wrappers.StringLen(string(v.ChainName))
+	size += 36
+	size += 32 * len(v.FxIDs)
+	size += 4
+	size += len(v.GenesisData)
+	fieldSize, err = c.FieldSize(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *CreateSubnetTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := c.MarshalField(&v.Owner, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *CreateSubnetTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := c.UnmarshalField(p, &v.Owner); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *CreateSubnetTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.FieldSize(&v.Owner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *ImportTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.SourceChain[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.ImportedInputs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.ImportedInputs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.ImportedInputs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.ImportedInputs {
+		start2 := p.Offset
+		if v.ImportedInputs[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.ImportedInputs[i1]).UTXOID.TxID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		p.PackInt(uint32((*v.ImportedInputs[i1]).UTXOID.OutputIndex))
+		if p.Err != nil {
+			return p.Err
+		}
+		p.PackFixedBytes((*v.ImportedInputs[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.ImportedInputs[i1]).In, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return nil
+}
+
+func (v *ImportTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.SourceChain[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.ImportedInputs = make([]*avax.TransferableInput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableInput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableInput)
+		copy((*ptr5).UTXOID.TxID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		(*ptr5).UTXOID.OutputIndex = uint32(p.UnpackInt())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+		}
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).In); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.ImportedInputs = append(v.ImportedInputs, elem3)
+	}
+	return nil
+}
+
+func (v *ImportTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 36
+	start2 := size
+	for i1 := range v.ImportedInputs {
+		if v.ImportedInputs[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 68
+		fieldSize, err = c.FieldSize(&(*v.ImportedInputs[i1]).In)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return size, nil
+}
+
+func (v *ExportTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.DestinationChain[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.ExportedOutputs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.ExportedOutputs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.ExportedOutputs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.ExportedOutputs {
+		start2 := p.Offset
+		if v.ExportedOutputs[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.ExportedOutputs[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.ExportedOutputs[i1]).Out, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return nil
+}
+
+func (v *ExportTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.DestinationChain[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.ExportedOutputs = make([]*avax.TransferableOutput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableOutput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableOutput)
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).Out); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.ExportedOutputs = append(v.ExportedOutputs, elem3)
+	}
+	return nil
+}
+
+func (v *ExportTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 36
+	start2 := size
+	for i1 := range v.ExportedOutputs {
+		if v.ExportedOutputs[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 32
+		fieldSize, err = c.FieldSize(&(*v.ExportedOutputs[i1]).Out)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	return size, nil
+}
+
+func (v *AdvanceTimeTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Time))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *AdvanceTimeTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Time = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *AdvanceTimeTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 8
+	return size, nil
+}
+
+func (v *RewardValidatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackFixedBytes(v.TxID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *RewardValidatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	copy(v.TxID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *RewardValidatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 32
+	return size, nil
+}
+
+func (v *RemoveSubnetValidatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.NodeID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.MarshalField(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *RemoveSubnetValidatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.NodeID[:], p.UnpackFixedBytes(20))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalField(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *RemoveSubnetValidatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 52
+	fieldSize, err = c.FieldSize(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *TransformSubnetTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackFixedBytes(v.AssetID[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.InitialSupply))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.MaximumSupply))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.MinConsumptionRate))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.MaxConsumptionRate))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.MinValidatorStake))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.MaxValidatorStake))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.MinStakeDuration))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.MaxStakeDuration))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.MinDelegationFee))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackLong(uint64(v.MinDelegatorStake))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackByte(uint8(v.MaxValidatorWeightFactor))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.UptimeRequirement))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.MarshalField(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransformSubnetTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	copy(v.AssetID[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	v.InitialSupply = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MaximumSupply = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MinConsumptionRate = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MaxConsumptionRate = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MinValidatorStake = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MaxValidatorStake = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MinStakeDuration = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	v.MaxStakeDuration = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	v.MinDelegationFee = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	v.MinDelegatorStake = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.MaxValidatorWeightFactor = uint8(p.UnpackByte())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint8: %w", p.Err)
+	}
+	v.UptimeRequirement = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	if err := c.UnmarshalField(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransformSubnetTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 137
+	fieldSize, err = c.FieldSize(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *AddPermissionlessValidatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.MarshalField(&v.Signer, p); err != nil {
+		return err
+	}
+	if len(v.StakeOuts) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.StakeOuts), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.StakeOuts)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.StakeOuts {
+		start2 := p.Offset
+		if v.StakeOuts[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.StakeOuts[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.StakeOuts[i1]).Out, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if err := c.MarshalField(&v.ValidatorRewardsOwner, p); err != nil {
+		return err
+	}
+	if err := c.MarshalField(&v.DelegatorRewardsOwner, p); err != nil {
+		return err
+	}
+	p.PackInt(uint32(v.DelegationShares))
+	if p.Err != nil {
+		return p.Err
+	}
+	return nil
+}
+
+func (v *AddPermissionlessValidatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalField(p, &v.Signer); err != nil {
+		return err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.StakeOuts = make([]*avax.TransferableOutput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableOutput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableOutput)
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).Out); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.StakeOuts = append(v.StakeOuts, elem3)
+	}
+	if err := c.UnmarshalField(p, &v.ValidatorRewardsOwner); err != nil {
+		return err
+	}
+	if err := c.UnmarshalField(p, &v.DelegatorRewardsOwner); err != nil {
+		return err
+	}
+	v.DelegationShares = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	return nil
+}
+
+func (v *AddPermissionlessValidatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 76
+	fieldSize, err = c.FieldSize(&v.Signer)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 4
+	start2 := size
+	for i1 := range v.StakeOuts {
+		if v.StakeOuts[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 32
+		fieldSize, err = c.FieldSize(&(*v.StakeOuts[i1]).Out)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	fieldSize, err = c.FieldSize(&v.ValidatorRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.FieldSize(&v.DelegatorRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 4
+	return size, nil
+}
+
+func (v *AddPermissionlessDelegatorTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.StakeOuts) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.StakeOuts), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.StakeOuts)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.StakeOuts {
+		start2 := p.Offset
+		if v.StakeOuts[i1] == nil {
+			return codec.ErrMarshalNil
+		}
+		p.PackFixedBytes((*v.StakeOuts[i1]).Asset.ID[:])
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.MarshalField(&(*v.StakeOuts[i1]).Out, p); err != nil {
+			return err
+		}
+		if p.Offset == start2 {
+			return fmt.Errorf("couldn't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	if err := c.MarshalField(&v.DelegationRewardsOwner, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *AddPermissionlessDelegatorTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.Validator.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.StakeOuts = make([]*avax.TransferableOutput, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 *avax.TransferableOutput
+		start4 := p.Offset
+		ptr5 := new(avax.TransferableOutput)
+		copy((*ptr5).Asset.ID[:], p.UnpackFixedBytes(32))
+		if p.Err != nil {
+			return p.Err
+		}
+		if err := c.UnmarshalField(p, &(*ptr5).Out); err != nil {
+			return err
+		}
+		elem3 = ptr5
+		if start4 == p.Offset {
+			return fmt.Errorf("couldn't unmarshal slice of zero length values: %w", codec.ErrUnmarshalZeroLength)
+		}
+		v.StakeOuts = append(v.StakeOuts, elem3)
+	}
+	if err := c.UnmarshalField(p, &v.DelegationRewardsOwner); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *AddPermissionlessDelegatorTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 80
+	start2 := size
+	for i1 := range v.StakeOuts {
+		if v.StakeOuts[i1] == nil {
+			return 0, codec.ErrMarshalNil
+		}
+		size += 32
+		fieldSize, err = c.FieldSize(&(*v.StakeOuts[i1]).Out)
+		if err != nil {
+			return 0, err
+		}
+		size += fieldSize
+		if i1 == 0 && size == start2 {
+			return 0, fmt.Errorf("can't marshal slice of zero length values: %w", codec.ErrMarshalZeroLength)
+		}
+	}
+	fieldSize, err = c.FieldSize(&v.DelegationRewardsOwner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *TransferSubnetOwnershipTx) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.MarshalInto(c, p); err != nil {
+		return err
+	}
+	p.PackFixedBytes(v.Subnet[:])
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.MarshalField(&v.SubnetAuth, p); err != nil {
+		return err
+	}
+	if err := c.MarshalField(&v.Owner, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransferSubnetOwnershipTx) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.BaseTx.Unmarshal(c, p); err != nil {
+		return err
+	}
+	copy(v.Subnet[:], p.UnpackFixedBytes(32))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := c.UnmarshalField(p, &v.SubnetAuth); err != nil {
+		return err
+	}
+	if err := c.UnmarshalField(p, &v.Owner); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransferSubnetOwnershipTx) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.BaseTx.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	size += 32
+	fieldSize, err = c.FieldSize(&v.SubnetAuth)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = c.FieldSize(&v.Owner)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/secp256k1"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/signer"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/stakeable"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+)
+
+// newReflectiveCodec returns a codec that (un)marshals txs like Codec, but
+// with reflection instead of the generated code.
+func newReflectiveCodec(t testing.TB) codec.Manager {
+	require := require.New(t)
+
+	c := linearcodec.NewReflective([]string{reflectcodec.DefaultTagName})
+	c.SkipRegistrations(5)
+	require.NoError(RegisterUnsignedTxsTypes(c))
+	c.SkipRegistrations(4)
+	require.NoError(RegisterDUnsignedTxsTypes(c))
+
+	m := codec.NewDefaultManager()
+	require.NoError(m.RegisterCodec(CodecVersion, c))
+	return m
+}
+
+func newGeneratedCodecTestTxs(t testing.TB) []*Tx {
+	require := require.New(t)
+
+	var (
+		assetID = ids.GenerateTestID()
+		owner   = secp256k1fx.OutputOwners{
+			Locktime:  1,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+		}
+		outs = []*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          1234,
+					OutputOwners: owner,
+				},
+			},
+			{
+				Asset: avax.Asset{ID: assetID},
+				Out: &stakeable.LockOut{
+					Locktime: 5,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt:          5678,
+						OutputOwners: owner,
+					},
+				},
+			},
+		}
+		ins = []*avax.TransferableInput{
+			{
+				UTXOID: avax.UTXOID{
+					TxID:        ids.GenerateTestID(),
+					OutputIndex: 2,
+				},
+				Asset: avax.Asset{ID: assetID},
+				In: &secp256k1fx.TransferInput{
+					Amt:   1234,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			},
+			{
+				UTXOID: avax.UTXOID{
+					TxID:        ids.GenerateTestID(),
+					OutputIndex: 3,
+				},
+				Asset: avax.Asset{ID: assetID},
+				In: &stakeable.LockIn{
+					Locktime: 5,
+					TransferableIn: &secp256k1fx.TransferInput{
+						Amt:   5678,
+						Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+					},
+				},
+			},
+		}
+		baseTx = BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Outs:         outs,
+			Ins:          ins,
+			Memo:         []byte("memo"),
+		}}
+		validator = Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			Start:  1,
+			End:    2,
+			Wght:   3,
+		}
+		subnetAuth = &secp256k1fx.Input{SigIndices: []uint32{1}}
+	)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	unsignedTxs := []UnsignedTx{
+		&baseTx,
+		&CreateChainTx{
+			BaseTx:      baseTx,
+			SubnetID:    ids.GenerateTestID(),
+			ChainName:   "chain",
+			VMID:        ids.GenerateTestID(),
+			FxIDs:       []ids.ID{ids.GenerateTestID()},
+			GenesisData: []byte("genesis"),
+			SubnetAuth:  subnetAuth,
+		},
+		&ImportTx{
+			BaseTx:         baseTx,
+			SourceChain:    ids.GenerateTestID(),
+			ImportedInputs: ins,
+		},
+		&ExportTx{
+			BaseTx:           baseTx,
+			DestinationChain: ids.GenerateTestID(),
+			ExportedOutputs:  outs,
+		},
+		&AddSubnetValidatorTx{
+			BaseTx: baseTx,
+			SubnetValidator: SubnetValidator{
+				Validator: validator,
+				Subnet:    ids.GenerateTestID(),
+			},
+			SubnetAuth: subnetAuth,
+		},
+		&AddPermissionlessValidatorTx{
+			BaseTx:                baseTx,
+			Validator:             validator,
+			Subnet:                constants.PrimaryNetworkID,
+			Signer:                signer.NewProofOfPossession(sk),
+			StakeOuts:             outs,
+			ValidatorRewardsOwner: &owner,
+			DelegatorRewardsOwner: &owner,
+			DelegationShares:      20_000,
+		},
+		&RewardValidatorTx{
+			TxID: ids.GenerateTestID(),
+		},
+		&AdvanceTimeTx{
+			Time: 12345,
+		},
+	}
+
+	signers := [][]*secp256k1.PrivateKey{{preFundedKeys[0]}, {preFundedKeys[1]}}
+	signedTxs := make([]*Tx, len(unsignedTxs))
+	for i, utx := range unsignedTxs {
+		tx, err := NewSigned(utx, Codec, signers)
+		require.NoError(err)
+		signedTxs[i] = tx
+	}
+	return signedTxs
+}
+
+// Test that the generated code marshals and parses txs identically to
+// reflection.
+func TestGeneratedCodec(t *testing.T) {
+	reflective := newReflectiveCodec(t)
+	for _, tx := range newGeneratedCodecTestTxs(t) {
+		t.Run(fmt.Sprintf("%T", tx.Unsigned), func(t *testing.T) {
+			require := require.New(t)
+
+			expectedBytes, err := reflective.Marshal(CodecVersion, tx)
+			require.NoError(err)
+			require.Equal(expectedBytes, tx.Bytes())
+
+			size, err := Codec.Size(CodecVersion, tx)
+			require.NoError(err)
+			require.Len(expectedBytes, size)
+
+			expectedTx, err := Parse(reflective, expectedBytes)
+			require.NoError(err)
+			parsedTx, err := Parse(Codec, expectedBytes)
+			require.NoError(err)
+			require.Equal(expectedTx, parsedTx)
+			require.Equal(tx.Unsigned.Bytes(), parsedTx.Unsigned.Bytes())
+		})
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		codec func(testing.TB) codec.Manager
+	}{
+		{
+			name:  "reflective",
+			codec: newReflectiveCodec,
+		},
+		{
+			name: "generated",
+			codec: func(testing.TB) codec.Manager {
+				return Codec
+			},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			var (
+				c         = benchmark.codec(b)
+				signedTxs = newGeneratedCodecTestTxs(b)
+			)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, tx := range signedTxs {
+					_, err := c.Marshal(CodecVersion, tx)
+					require.NoError(b, err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		codec func(testing.TB) codec.Manager
+	}{
+		{
+			name:  "reflective",
+			codec: newReflectiveCodec,
+		},
+		{
+			name: "generated",
+			codec: func(testing.TB) codec.Manager {
+				return Codec
+			},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			var (
+				c         = benchmark.codec(b)
+				signedTxs = newGeneratedCodecTestTxs(b)
+			)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, tx := range signedTxs {
+					_, err := Parse(c, tx.Bytes())
+					require.NoError(b, err)
+				}
+			}
+		})
+	}
+}
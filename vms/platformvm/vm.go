@@ -202,6 +202,7 @@ func (vm *VM) Initialize(
 			&chainCtx.Lock,
 			validatorManager,
 		),
+		chainCtx.Reputation,
 		txVerifier,
 		mempool,
 		txExecutorBackend.Config.PartialSyncPrimaryNetwork,
@@ -7,6 +7,7 @@ import (
 	"crypto"
 	"time"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/staking"
 )
 
@@ -32,6 +33,9 @@ type Config struct {
 
 	// Block certificate
 	StakingCertLeaf *staking.Certificate
+
+	// Eviction policy of the block caches
+	CachePolicy cache.Policy
 }
 
 func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
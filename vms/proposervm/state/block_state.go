@@ -66,14 +66,19 @@ func NewBlockState(db database.Database) BlockState {
 	}
 }
 
-func NewMeteredBlockState(db database.Database, namespace string, metrics prometheus.Registerer) (BlockState, error) {
+func NewMeteredBlockState(db database.Database, namespace string, metrics prometheus.Registerer, cachePolicy cache.Policy) (BlockState, error) {
+	baseBlkCache, err := cache.NewSized[ids.ID, *blockWrapper](
+		cachePolicy,
+		blockCacheSize,
+		cachedBlockSize,
+	)
+	if err != nil {
+		return nil, err
+	}
 	blkCache, err := metercacher.New[ids.ID, *blockWrapper](
 		metric.AppendNamespace(namespace, "block_cache"),
 		metrics,
-		cache.NewSizedLRU[ids.ID, *blockWrapper](
-			blockCacheSize,
-			cachedBlockSize,
-		),
+		baseBlkCache,
 	)
 
 	return &blockState{
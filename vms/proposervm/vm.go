@@ -153,20 +153,25 @@ func (vm *VM) Initialize(
 
 	vm.ctx = chainCtx
 	vm.db = versiondb.New(prefixdb.New(dbPrefix, db))
-	baseState, err := state.NewMetered(vm.db, "state", registerer)
+	baseState, err := state.NewMetered(vm.db, "state", registerer, vm.CachePolicy)
 	if err != nil {
 		return err
 	}
 	vm.State = baseState
 	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
 	vm.Tree = tree.New()
+	baseInnerBlkCache, err := cache.NewSized(
+		vm.CachePolicy,
+		innerBlkCacheSize,
+		cachedBlockSize,
+	)
+	if err != nil {
+		return err
+	}
 	innerBlkCache, err := metercacher.New(
 		"inner_block_cache",
 		registerer,
-		cache.NewSizedLRU(
-			innerBlkCacheSize,
-			cachedBlockSize,
-		),
+		baseInnerBlkCache,
 	)
 	if err != nil {
 		return err
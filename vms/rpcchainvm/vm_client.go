@@ -30,6 +30,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/appsender"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation/greputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators/gvalidators"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
@@ -48,6 +49,7 @@ import (
 	httppb "github.com/MetalBlockchain/metalgo/proto/pb/http"
 	keystorepb "github.com/MetalBlockchain/metalgo/proto/pb/keystore"
 	messengerpb "github.com/MetalBlockchain/metalgo/proto/pb/messenger"
+	reputationpb "github.com/MetalBlockchain/metalgo/proto/pb/reputation"
 	rpcdbpb "github.com/MetalBlockchain/metalgo/proto/pb/rpcdb"
 	sharedmemorypb "github.com/MetalBlockchain/metalgo/proto/pb/sharedmemory"
 	validatorstatepb "github.com/MetalBlockchain/metalgo/proto/pb/validatorstate"
@@ -97,6 +99,7 @@ type VMClient struct {
 	appSender            *appsender.Server
 	validatorStateServer *gvalidators.Server
 	warpSignerServer     *gwarp.Server
+	reputationServer     *greputation.Server
 
 	serverCloser grpcutils.ServerCloser
 	conns        []*grpc.ClientConn
@@ -168,6 +171,7 @@ func (vm *VMClient) Initialize(
 	vm.appSender = appsender.NewServer(appSender)
 	vm.validatorStateServer = gvalidators.NewServer(chainCtx.ValidatorState)
 	vm.warpSignerServer = gwarp.NewServer(chainCtx.WarpSigner)
+	vm.reputationServer = greputation.NewServer(chainCtx.Reputation)
 
 	serverListener, err := grpcutils.NewListener()
 	if err != nil {
@@ -292,6 +296,7 @@ func (vm *VMClient) newInitServer() *grpc.Server {
 	healthpb.RegisterHealthServer(server, grpcHealth)
 	validatorstatepb.RegisterValidatorStateServer(server, vm.validatorStateServer)
 	warppb.RegisterSignerServer(server, vm.warpSignerServer)
+	reputationpb.RegisterReputationServer(server, vm.reputationServer)
 
 	// Ensure metric counters are zeroed on restart
 	grpc_prometheus.Register(server)
@@ -28,6 +28,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/appsender"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation/greputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators/gvalidators"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
@@ -44,6 +45,7 @@ import (
 	httppb "github.com/MetalBlockchain/metalgo/proto/pb/http"
 	keystorepb "github.com/MetalBlockchain/metalgo/proto/pb/keystore"
 	messengerpb "github.com/MetalBlockchain/metalgo/proto/pb/messenger"
+	reputationpb "github.com/MetalBlockchain/metalgo/proto/pb/reputation"
 	rpcdbpb "github.com/MetalBlockchain/metalgo/proto/pb/rpcdb"
 	sharedmemorypb "github.com/MetalBlockchain/metalgo/proto/pb/sharedmemory"
 	validatorstatepb "github.com/MetalBlockchain/metalgo/proto/pb/validatorstate"
@@ -192,6 +194,7 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 	appSenderClient := appsender.NewClient(appsenderpb.NewAppSenderClient(clientConn))
 	validatorStateClient := gvalidators.NewClient(validatorstatepb.NewValidatorStateClient(clientConn))
 	warpSignerClient := gwarp.NewClient(warppb.NewSignerClient(clientConn))
+	reputationClient := greputation.NewClient(reputationpb.NewReputationClient(clientConn))
 
 	toEngine := make(chan common.Message, 1)
 	vm.closed = make(chan struct{})
@@ -229,6 +232,8 @@ func (vm *VMServer) Initialize(ctx context.Context, req *vmpb.InitializeRequest)
 
 		// Signs warp messages
 		WarpSigner: warpSignerClient,
+		// Tracks the reputation of peers
+		Reputation: reputationClient,
 
 		ValidatorState: validatorStateClient,
 		// TODO: support remaining snowman++ fields
@@ -15,6 +15,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/components/verify"
 )
 
+//go:generate go run ./gen
+
 const (
 	defaultCacheSize = 256
 )
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/MetalBlockchain/metalgo/codec/codecgen"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+)
+
+const fileName = "generated_codec.go"
+
+// generatedTypes are the types that generated_codec.go is generated for.
+var generatedTypes = []interface{}{
+	secp256k1fx.Credential{},
+	secp256k1fx.Input{},
+	secp256k1fx.OutputOwners{},
+	secp256k1fx.TransferInput{},
+	secp256k1fx.TransferOutput{},
+	secp256k1fx.MintOutput{},
+	secp256k1fx.MintOperation{},
+}
+
+func generate() ([]byte, error) {
+	return codecgen.Generate(
+		[]string{reflectcodec.DefaultTagName},
+		generatedTypes...,
+	)
+}
+
+func main() {
+	src, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't generate code: %s\n", err)
+		os.Exit(1)
+	}
+	if err := perms.WriteFile(fileName, src, perms.ReadWrite); err != nil {
+		fmt.Fprintf(os.Stderr, "couldn't write %s: %s\n", fileName, err)
+		os.Exit(1)
+	}
+}
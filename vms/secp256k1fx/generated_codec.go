@@ -0,0 +1,345 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package secp256k1fx
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/reflectcodec"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+var (
+	_ reflectcodec.Marshaler   = (*Credential)(nil)
+	_ reflectcodec.Unmarshaler = (*Credential)(nil)
+	_ reflectcodec.Marshaler   = (*Input)(nil)
+	_ reflectcodec.Unmarshaler = (*Input)(nil)
+	_ reflectcodec.Marshaler   = (*OutputOwners)(nil)
+	_ reflectcodec.Unmarshaler = (*OutputOwners)(nil)
+	_ reflectcodec.Marshaler   = (*TransferInput)(nil)
+	_ reflectcodec.Unmarshaler = (*TransferInput)(nil)
+	_ reflectcodec.Marshaler   = (*TransferOutput)(nil)
+	_ reflectcodec.Unmarshaler = (*TransferOutput)(nil)
+	_ reflectcodec.Marshaler   = (*MintOutput)(nil)
+	_ reflectcodec.Unmarshaler = (*MintOutput)(nil)
+	_ reflectcodec.Marshaler   = (*MintOperation)(nil)
+	_ reflectcodec.Unmarshaler = (*MintOperation)(nil)
+
+	codecTags = []string{"serialize"}
+)
+
+func init() {
+	reflectcodec.RegisterGenerated(
+		codecTags,
+		(*Credential)(nil),
+		(*Input)(nil),
+		(*OutputOwners)(nil),
+		(*TransferInput)(nil),
+		(*TransferOutput)(nil),
+		(*MintOutput)(nil),
+		(*MintOperation)(nil),
+	)
+}
+
+func (v *Credential) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if len(v.Sigs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Sigs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Sigs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Sigs {
+		p.PackFixedBytes(v.Sigs[i1][:])
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	return nil
+}
+
+func (v *Credential) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Sigs = make([][65]uint8, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 [65]uint8
+		copy(elem3[:], p.UnpackFixedBytes(65))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Sigs = append(v.Sigs, elem3)
+	}
+	return nil
+}
+
+func (v *Credential) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 4
+	size += 65 * len(v.Sigs)
+	return size, nil
+}
+
+func (v *Input) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if len(v.SigIndices) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.SigIndices), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.SigIndices)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.SigIndices {
+		p.PackInt(uint32(v.SigIndices[i1]))
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	return nil
+}
+
+func (v *Input) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.SigIndices = make([]uint32, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 uint32
+		elem3 = uint32(p.UnpackInt())
+		if p.Err != nil {
+			return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+		}
+		v.SigIndices = append(v.SigIndices, elem3)
+	}
+	return nil
+}
+
+func (v *Input) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 4
+	size += 4 * len(v.SigIndices)
+	return size, nil
+}
+
+func (v *OutputOwners) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Locktime))
+	if p.Err != nil {
+		return p.Err
+	}
+	p.PackInt(uint32(v.Threshold))
+	if p.Err != nil {
+		return p.Err
+	}
+	if len(v.Addrs) > math.MaxInt32 {
+		return fmt.Errorf("%w; slice length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, len(v.Addrs), math.MaxInt32)
+	}
+	p.PackInt(uint32(len(v.Addrs)))
+	if p.Err != nil {
+		return p.Err
+	}
+	for i1 := range v.Addrs {
+		p.PackFixedBytes(v.Addrs[i1][:])
+		if p.Err != nil {
+			return p.Err
+		}
+	}
+	return nil
+}
+
+func (v *OutputOwners) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Locktime = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	v.Threshold = uint32(p.UnpackInt())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint32: %w", p.Err)
+	}
+	numElts1 := p.UnpackInt()
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal slice: %w", p.Err)
+	}
+	if numElts1 > math.MaxInt32 {
+		return fmt.Errorf("%w; array length, %d, exceeds maximum length, %d", codec.ErrMaxSliceLenExceeded, numElts1, math.MaxInt32)
+	}
+	v.Addrs = make([]ids.ShortID, 0, min(int(numElts1), 16))
+	for i2 := 0; i2 < int(numElts1); i2++ {
+		var elem3 ids.ShortID
+		copy(elem3[:], p.UnpackFixedBytes(20))
+		if p.Err != nil {
+			return p.Err
+		}
+		v.Addrs = append(v.Addrs, elem3)
+	}
+	return nil
+}
+
+func (v *OutputOwners) Size(c reflectcodec.FieldCodec) (int, error) {
+	size := 0
+	size += 16
+	size += 20 * len(v.Addrs)
+	return size, nil
+}
+
+func (v *TransferInput) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Amt))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := v.Input.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransferInput) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Amt = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	if err := v.Input.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransferInput) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 8
+	fieldSize, err = v.Input.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *TransferOutput) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	p.PackLong(uint64(v.Amt))
+	if p.Err != nil {
+		return p.Err
+	}
+	if err := v.OutputOwners.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransferOutput) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	v.Amt = uint64(p.UnpackLong())
+	if p.Err != nil {
+		return fmt.Errorf("couldn't unmarshal uint64: %w", p.Err)
+	}
+	if err := v.OutputOwners.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *TransferOutput) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	size += 8
+	fieldSize, err = v.OutputOwners.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *MintOutput) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.OutputOwners.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *MintOutput) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.OutputOwners.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *MintOutput) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.OutputOwners.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
+
+func (v *MintOperation) MarshalInto(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.MintInput.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.MintOutput.MarshalInto(c, p); err != nil {
+		return err
+	}
+	if err := v.TransferOutput.MarshalInto(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *MintOperation) Unmarshal(c reflectcodec.FieldCodec, p *wrappers.Packer) error {
+	if err := v.MintInput.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.MintOutput.Unmarshal(c, p); err != nil {
+		return err
+	}
+	if err := v.TransferOutput.Unmarshal(c, p); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *MintOperation) Size(c reflectcodec.FieldCodec) (int, error) {
+	var (
+		size      int
+		fieldSize int
+		err       error
+	)
+	fieldSize, err = v.MintInput.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = v.MintOutput.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	fieldSize, err = v.TransferOutput.Size(c)
+	if err != nil {
+		return 0, err
+	}
+	size += fieldSize
+	return size, nil
+}
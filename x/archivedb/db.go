@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
 
 	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/database"
@@ -57,8 +58,16 @@ var (
 // foo was deleted at height 1000. When calling `reader.GetHeight(foo)` at
 // height 99 it will return a tuple `("foo's value is bar", 10)` returning the
 // value of `foo` at height 99 (which was set at height 10).
+//
+// History that is no longer needed can be removed with Prune, after which
+// reads below the pruned height return ErrHeightPruned.
 type Database struct {
 	db database.Database
+
+	// pruneLock protects the cached pruned height.
+	pruneLock       sync.Mutex
+	minHeight       uint64
+	minHeightLoaded bool
 }
 
 func New(db database.Database) *Database {
@@ -4,6 +4,8 @@
 package archivedb
 
 import (
+	"context"
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -225,3 +227,228 @@ func TestSkipHeight(t *testing.T) {
 	require.NoError(err)
 	require.Equal(uint64(10), height)
 }
+
+func TestIterator(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("a"), []byte("a@1")))
+	require.NoError(batch.Put([]byte("bb"), []byte("bb@1")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("a"), []byte("a@2")))
+	require.NoError(batch.Delete([]byte("c")))
+	require.NoError(batch.Put([]byte{}, []byte("empty@2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("a"), []byte("a@3")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@3")))
+	require.NoError(batch.Write())
+
+	tests := []struct {
+		height   uint64
+		start    []byte
+		expected map[string]string
+		order    []string
+	}{
+		{
+			height: 0,
+			order:  []string{},
+		},
+		{
+			height: 1,
+			order:  []string{"a", "c", "bb"},
+			expected: map[string]string{
+				"a":  "a@1",
+				"c":  "c@1",
+				"bb": "bb@1",
+			},
+		},
+		{
+			height: 2,
+			order:  []string{"", "a", "bb"},
+			expected: map[string]string{
+				"":   "empty@2",
+				"a":  "a@2",
+				"bb": "bb@1",
+			},
+		},
+		{
+			height: 3,
+			order:  []string{"", "a", "c", "bb"},
+			expected: map[string]string{
+				"":   "empty@2",
+				"a":  "a@3",
+				"c":  "c@3",
+				"bb": "bb@1",
+			},
+		},
+		{
+			height: 3,
+			start:  []byte("b"),
+			order:  []string{"c", "bb"},
+			expected: map[string]string{
+				"c":  "c@3",
+				"bb": "bb@1",
+			},
+		},
+	}
+	for _, test := range tests {
+		it := db.Open(test.height).NewIteratorWithStart(test.start)
+		keys := []string{}
+		for it.Next() {
+			key := string(it.Key())
+			keys = append(keys, key)
+			require.Equal(test.expected[key], string(it.Value()))
+		}
+		require.NoError(it.Error())
+		it.Release()
+
+		require.Equal(test.order, keys)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@2")))
+	require.NoError(batch.Delete([]byte("key2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@3")))
+	require.NoError(batch.Write())
+
+	err := db.Prune(context.Background(), 4)
+	require.ErrorIs(err, ErrInvalidPruneHeight)
+
+	require.NoError(db.Prune(context.Background(), 2))
+
+	prunedHeight, err := db.PrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), prunedHeight)
+
+	_, err = db.Open(1).Get([]byte("key1"))
+	require.ErrorIs(err, ErrHeightPruned)
+
+	it := db.Open(1).NewIterator()
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), ErrHeightPruned)
+	it.Release()
+
+	reader := db.Open(2)
+	value, err := reader.Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1@2"), value)
+
+	_, err = reader.Get([]byte("key2"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	value, err = reader.Get([]byte("key3"))
+	require.NoError(err)
+	require.Equal([]byte("value3@1"), value)
+
+	value, err = db.Open(3).Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1@3"), value)
+
+	// Only the versions needed to serve reads at heights >= 2 should remain,
+	// along with the metadata.
+	expectedDBKeys := [][]byte{
+		heightKey,
+		prunedHeightKey,
+	}
+	for _, entry := range []struct {
+		key    string
+		height uint64
+	}{
+		{key: "key1", height: 3},
+		{key: "key1", height: 2},
+		{key: "key3", height: 1},
+	} {
+		dbKey, _ := newDBKeyFromUser([]byte(entry.key), entry.height)
+		expectedDBKeys = append(expectedDBKeys, dbKey)
+	}
+
+	dbKeys := [][]byte{}
+	dbIt := db.db.NewIterator()
+	for dbIt.Next() {
+		dbKeys = append(dbKeys, dbIt.Key())
+	}
+	require.NoError(dbIt.Error())
+	dbIt.Release()
+	require.ElementsMatch(expectedDBKeys, dbKeys)
+
+	// Pruning to a lower height is a no-op.
+	require.NoError(db.Prune(context.Background(), 1))
+
+	prunedHeight, err = db.PrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), prunedHeight)
+}
+
+func TestPruneResume(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	const numKeys = 3 * pruneBatchSize
+	for height := uint64(1); height <= 3; height++ {
+		batch := db.NewBatch(height)
+		for i := 0; i < numKeys; i++ {
+			require.NoError(batch.Put(binary.AppendUvarint(nil, uint64(i)), []byte{byte(height)}))
+		}
+		require.NoError(batch.Write())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := db.Prune(ctx, 3)
+	require.ErrorIs(err, context.Canceled)
+
+	// The pruned height is updated before any versions are removed.
+	_, err = db.Open(2).Get([]byte{0})
+	require.ErrorIs(err, ErrHeightPruned)
+
+	// Reopening the database should resume pruning at the same height.
+	db = New(baseDB)
+	prunedHeight, err := db.PrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(3), prunedHeight)
+
+	require.NoError(db.Prune(context.Background(), 3))
+
+	has, err := baseDB.Has(pruneProgressKey)
+	require.NoError(err)
+	require.False(has)
+
+	reader := db.Open(3)
+	for i := 0; i < numKeys; i++ {
+		key := binary.AppendUvarint(nil, uint64(i))
+		value, height, exists, err := reader.GetEntry(key)
+		require.NoError(err)
+		require.True(exists)
+		require.Equal(uint64(3), height)
+		require.Equal([]byte{3}, value)
+
+		dbKey, _ := newDBKeyFromUser(key, 2)
+		has, err := baseDB.Has(dbKey)
+		require.NoError(err)
+		require.False(has)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+var _ database.Iterator = (*iterator)(nil)
+
+// iterator returns the most recent version of every user key that was
+// modified at or below [height]. Keys whose most recent version is a deletion
+// are skipped.
+//
+// All versions of a user key are stored contiguously, sorted by decreasing
+// height, so the first version at or below [height] is the one to return and
+// the following versions can be skipped.
+type iterator struct {
+	it     database.Iterator
+	height uint64
+
+	// lastKey is the last user key that a version was selected for.
+	lastKey    []byte
+	hasLastKey bool
+
+	key, value []byte
+	err        error
+}
+
+func (it *iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.it.Next() {
+		dbKey := it.it.Key()
+		if isMetadataKey(dbKey) {
+			continue
+		}
+
+		key, height, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			it.err = err
+			break
+		}
+		if height > it.height {
+			continue
+		}
+		if it.hasLastKey && bytes.Equal(key, it.lastKey) {
+			// An older version of a key that was already handled.
+			continue
+		}
+
+		it.lastKey = slices.Clone(key)
+		it.hasLastKey = true
+
+		value, exists := parseDBValue(it.it.Value())
+		if !exists {
+			continue
+		}
+
+		it.key = it.lastKey
+		it.value = slices.Clone(value)
+		return true
+	}
+
+	it.key = nil
+	it.value = nil
+	return false
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Error()
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.it.Release()
+}
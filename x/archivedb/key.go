@@ -4,6 +4,7 @@
 package archivedb
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 
@@ -14,7 +15,15 @@ var (
 	ErrParsingKeyLength   = errors.New("failed reading key length")
 	ErrIncorrectKeyLength = errors.New("incorrect key length")
 
-	heightKey = newDBKeyFromMetadata([]byte{})
+	heightKey        = newDBKeyFromMetadata([]byte{})
+	prunedHeightKey  = newDBKeyFromMetadata([]byte("pruned height"))
+	pruneProgressKey = newDBKeyFromMetadata([]byte("prune progress"))
+
+	metadataKeys = [][]byte{
+		heightKey,
+		prunedHeightKey,
+		pruneProgressKey,
+	}
 )
 
 // The requirements of a database key are:
@@ -93,3 +102,16 @@ func newDBKeyFromMetadata(key []byte) []byte {
 	offset += copy(dbKey[offset:], key)
 	return dbKey[:offset]
 }
+
+// isMetadataKey returns true if [dbKey] is one of the known metadata keys.
+//
+// Metadata keys are interleaved with user keys on disk, so iterators over the
+// database must skip them.
+func isMetadataKey(dbKey []byte) bool {
+	for _, key := range metadataKeys {
+		if bytes.Equal(dbKey, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/MetalBlockchain/metalgo/database"
+)
+
+// pruneBatchSize is the number of database entries that are inspected before
+// the pruning progress is committed.
+const pruneBatchSize = 1024
+
+var (
+	ErrHeightPruned       = errors.New("height has been pruned")
+	ErrInvalidPruneHeight = errors.New("invalid prune height")
+)
+
+// PrunedHeight returns the lowest height that can still be read. If the
+// database has never been pruned, 0 is returned.
+func (db *Database) PrunedHeight() (uint64, error) {
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
+	return db.prunedHeight()
+}
+
+// prunedHeight assumes [db.pruneLock] is held.
+func (db *Database) prunedHeight() (uint64, error) {
+	if db.minHeightLoaded {
+		return db.minHeight, nil
+	}
+
+	height, err := database.GetUInt64(db.db, prunedHeightKey)
+	switch {
+	case err == database.ErrNotFound:
+		height = 0
+	case err != nil:
+		return 0, err
+	}
+
+	db.minHeight = height
+	db.minHeightLoaded = true
+	return height, nil
+}
+
+// checkHeight returns ErrHeightPruned if [height] can no longer be read.
+func (db *Database) checkHeight(height uint64) error {
+	minHeight, err := db.PrunedHeight()
+	if err != nil {
+		return err
+	}
+	if height < minHeight {
+		return fmt.Errorf("%w: requested %d < minimum %d", ErrHeightPruned, height, minHeight)
+	}
+	return nil
+}
+
+// Prune removes all the versions that are no longer needed to serve reads at
+// heights greater than or equal to [height]. Once Prune has been called, reads
+// at heights below [height] return ErrHeightPruned.
+//
+// For every key, the most recent version at or below [height] is kept, along
+// with every version above [height]. If the most recent version at or below
+// [height] is a deletion, it is removed as well. This means that after
+// pruning, GetEntry may report ErrNotFound rather than a deletion for keys
+// that were deleted at or before [height].
+//
+// Pruning is performed incrementally, committing its progress every
+// pruneBatchSize entries. It is safe to call Prune from a background goroutine
+// while the database is being written to. If [ctx] is cancelled, Prune returns
+// early and a subsequent call with the same [height] resumes where the
+// previous call stopped, including across restarts.
+//
+// Pruning to a height lower than the current pruned height is a no-op.
+// Pruning to a height greater than the last written height is not allowed.
+func (db *Database) Prune(ctx context.Context, height uint64) error {
+	lastHeight, err := db.Height()
+	if err != nil {
+		return err
+	}
+	if height > lastHeight {
+		return fmt.Errorf("%w: %d > last height %d", ErrInvalidPruneHeight, height, lastHeight)
+	}
+
+	start, ok, err := db.startPruning(height)
+	if err != nil || !ok {
+		return err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next, err := db.pruneBatch(start, height)
+		if err != nil {
+			return err
+		}
+		if next == nil {
+			return nil
+		}
+		start = next
+	}
+}
+
+// startPruning persists [height] as the new pruned height and returns the
+// database key that pruning should start from. If there is nothing to prune,
+// false is returned.
+func (db *Database) startPruning(height uint64) ([]byte, bool, error) {
+	db.pruneLock.Lock()
+	defer db.pruneLock.Unlock()
+
+	minHeight, err := db.prunedHeight()
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch {
+	case height < minHeight:
+		return nil, false, nil
+	case height == minHeight:
+		// Resume a previously interrupted pruning run, if any.
+		progress, err := db.db.Get(pruneProgressKey)
+		switch {
+		case err == database.ErrNotFound:
+			return nil, false, nil
+		case err != nil:
+			return nil, false, err
+		default:
+			return progress, true, nil
+		}
+	}
+
+	// The pruned height and the progress marker are written atomically so
+	// that a restart always resumes the latest pruning run from the start.
+	batch := db.db.NewBatch()
+	if err := database.PutUInt64(batch, prunedHeightKey, height); err != nil {
+		return nil, false, err
+	}
+	if err := batch.Put(pruneProgressKey, []byte{}); err != nil {
+		return nil, false, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, false, err
+	}
+
+	db.minHeight = height
+	return []byte{}, true, nil
+}
+
+// pruneBatch removes stale versions starting at [start] until at least
+// pruneBatchSize entries have been inspected. The returned key is where the
+// next batch should start, or nil if the end of the database was reached.
+//
+// Batches are only ever split on user key boundaries, because determining
+// which versions of a key to keep requires inspecting them in order.
+func (db *Database) pruneBatch(start []byte, height uint64) ([]byte, error) {
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	var (
+		batch      = db.db.NewBatch()
+		inspected  int
+		currentKey []byte
+		retained   bool
+	)
+	for it.Next() {
+		dbKey := it.Key()
+		if isMetadataKey(dbKey) {
+			continue
+		}
+
+		key, keyHeight, err := parseDBKeyFromUser(dbKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(key, currentKey) {
+			if inspected >= pruneBatchSize {
+				next := slices.Clone(dbKey)
+				return next, db.commitPruneBatch(batch, next)
+			}
+
+			currentKey = slices.Clone(key)
+			retained = false
+		}
+		inspected++
+
+		switch {
+		case keyHeight > height:
+			// Needed to serve reads above the pruned height.
+		case !retained:
+			// This is the version that reads at [height] observe.
+			retained = true
+			if _, exists := parseDBValue(it.Value()); exists {
+				continue
+			}
+			if err := batch.Delete(dbKey); err != nil {
+				return nil, err
+			}
+		default:
+			if err := batch.Delete(dbKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// Pruning is complete, so the progress marker can be removed.
+	if err := batch.Delete(pruneProgressKey); err != nil {
+		return nil, err
+	}
+	return nil, batch.Write()
+}
+
+func (db *Database) commitPruneBatch(batch database.Batch, next []byte) error {
+	if err := batch.Put(pruneProgressKey, next); err != nil {
+		return err
+	}
+	return batch.Write()
+}
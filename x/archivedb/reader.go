@@ -34,7 +34,13 @@ func (r *Reader) Get(key []byte) ([]byte, error) {
 // GetEntry retrieves the value of the provided key, the height it was last
 // modified at, and a boolean to indicate if the last modification was an
 // insertion. If the key has never been modified, ErrNotFound will be returned.
+//
+// If the requested height has been pruned, ErrHeightPruned will be returned.
 func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
+	if err := r.db.checkHeight(r.height); err != nil {
+		return nil, 0, false, err
+	}
+
 	it := r.db.db.NewIteratorWithStartAndPrefix(newDBKeyFromUser(key, r.height))
 	defer it.Release()
 
@@ -59,3 +65,30 @@ func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
 	}
 	return value, height, true, nil
 }
+
+// NewIterator returns an iterator over all the keys that exist as of the
+// reader's height, along with their values at that height.
+//
+// Keys are not returned in lexicographical order. They are ordered by their
+// length first, and then lexicographically among keys of the same length.
+func (r *Reader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStart(nil)
+}
+
+// NewIteratorWithStart returns an iterator over all the keys that exist as of
+// the reader's height and are ordered at or after [start].
+//
+// See NewIterator for the order keys are returned in.
+func (r *Reader) NewIteratorWithStart(start []byte) database.Iterator {
+	if err := r.db.checkHeight(r.height); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+
+	dbStart, _ := newDBKeyFromUser(start, r.height)
+	return &iterator{
+		it:     r.db.db.NewIteratorWithStart(dbStart),
+		height: r.height,
+	}
+}
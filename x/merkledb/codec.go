@@ -29,6 +29,10 @@ const (
 	minByteSliceLen      = minVarIntLen
 	minDBNodeLen         = minMaybeByteSliceLen + minVarIntLen
 	minChildLen          = minVarIntLen + minKeyLen + ids.IDLen + boolLen
+	minValueChangeLen    = minKeyLen + 2*minMaybeByteSliceLen
+	minProofNodeLen      = minKeyLen + minMaybeByteSliceLen + minVarIntLen
+	minProofChildLen     = minVarIntLen + ids.IDLen
+	minProvenValueLen    = minKeyLen + minMaybeByteSliceLen
 
 	estimatedKeyLen   = 64
 	estimatedValueLen = 64
@@ -66,12 +70,22 @@ type encoder interface {
 	// Assumes [n] is non-nil.
 	encodeHashValues(n *node) []byte
 	encodeKey(key Key) []byte
+
+	// Returns the bytes used to persist the value changes of a commit
+	// resulting in [rootID].
+	encodeValueChanges(rootID ids.ID, values map[Key]*change[maybe.Maybe[[]byte]]) []byte
+
+	// Assumes [proof] is non-nil and has as many keys as values.
+	encodeMultiProof(proof *MultiProof) []byte
 }
 
 type decoder interface {
 	// Assumes [n] is non-nil.
 	decodeDBNode(bytes []byte, n *dbNode) error
 	decodeKey(bytes []byte) (Key, error)
+	decodeValueChanges(bytes []byte) (ids.ID, map[Key]*change[maybe.Maybe[[]byte]], error)
+	// Assumes [proof] is non-nil.
+	decodeMultiProof(bytes []byte, proof *MultiProof) error
 }
 
 func newCodec() encoderDecoder {
@@ -228,6 +242,166 @@ func (c *codecImpl) decodeDBNode(b []byte, n *dbNode) error {
 	return nil
 }
 
+func (c *codecImpl) encodeValueChanges(rootID ids.ID, values map[Key]*change[maybe.Maybe[[]byte]]) []byte {
+	estimatedLen := ids.IDLen + minVarIntLen + len(values)*(estimatedKeyLen+2*estimatedValueLen)
+	buf := bytes.NewBuffer(make([]byte, 0, estimatedLen))
+	_, _ = buf.Write(rootID[:])
+	c.encodeUint(buf, uint64(len(values)))
+	for key, valueChange := range values {
+		c.encodeKeyToBuffer(buf, key)
+		c.encodeMaybeByteSlice(buf, valueChange.before)
+		c.encodeMaybeByteSlice(buf, valueChange.after)
+	}
+	return buf.Bytes()
+}
+
+func (c *codecImpl) decodeValueChanges(b []byte) (ids.ID, map[Key]*change[maybe.Maybe[[]byte]], error) {
+	src := bytes.NewReader(b)
+
+	rootID, err := c.decodeID(src)
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+
+	numValues, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return ids.Empty, nil, err
+	case numValues > uint64(src.Len()/minValueChangeLen):
+		return ids.Empty, nil, io.ErrUnexpectedEOF
+	}
+
+	values := make(map[Key]*change[maybe.Maybe[[]byte]], numValues)
+	for i := uint64(0); i < numValues; i++ {
+		key, err := c.decodeKeyFromReader(src)
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+		before, err := c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+		after, err := c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return ids.Empty, nil, err
+		}
+		values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+	if src.Len() != 0 {
+		return ids.Empty, nil, errExtraSpace
+	}
+	return rootID, values, nil
+}
+
+func (c *codecImpl) encodeMultiProof(proof *MultiProof) []byte {
+	estimatedLen := 2*minVarIntLen +
+		len(proof.Nodes)*(estimatedKeyLen+HashLength+minVarIntLen) +
+		len(proof.Keys)*(estimatedKeyLen+estimatedValueLen)
+	buf := bytes.NewBuffer(make([]byte, 0, estimatedLen))
+
+	c.encodeUint(buf, uint64(len(proof.Nodes)))
+	for _, proofNode := range proof.Nodes {
+		c.encodeKeyToBuffer(buf, proofNode.Key)
+		c.encodeMaybeByteSlice(buf, proofNode.ValueOrHash)
+
+		keys := maps.Keys(proofNode.Children)
+		slices.Sort(keys)
+		c.encodeUint(buf, uint64(len(keys)))
+		for _, index := range keys {
+			childID := proofNode.Children[index]
+			c.encodeUint(buf, uint64(index))
+			_, _ = buf.Write(childID[:])
+		}
+	}
+
+	c.encodeUint(buf, uint64(len(proof.Keys)))
+	for i, key := range proof.Keys {
+		c.encodeKeyToBuffer(buf, key)
+		c.encodeMaybeByteSlice(buf, proof.Values[i])
+	}
+	return buf.Bytes()
+}
+
+func (c *codecImpl) decodeMultiProof(b []byte, proof *MultiProof) error {
+	src := bytes.NewReader(b)
+
+	numNodes, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return err
+	case numNodes > uint64(src.Len()/minProofNodeLen):
+		return io.ErrUnexpectedEOF
+	}
+
+	proof.Nodes = make([]ProofNode, numNodes)
+	for i := range proof.Nodes {
+		proofNode := &proof.Nodes[i]
+		proofNode.Key, err = c.decodeKeyFromReader(src)
+		if err != nil {
+			return err
+		}
+		proofNode.ValueOrHash, err = c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return err
+		}
+
+		numChildren, err := c.decodeUint(src)
+		switch {
+		case err != nil:
+			return err
+		case numChildren > uint64(src.Len()/minProofChildLen):
+			return io.ErrUnexpectedEOF
+		}
+
+		proofNode.Children = make(map[byte]ids.ID, numChildren)
+		var previousChild uint64
+		for j := uint64(0); j < numChildren; j++ {
+			index, err := c.decodeUint(src)
+			if err != nil {
+				return err
+			}
+			if (j != 0 && index <= previousChild) || index > math.MaxUint8 {
+				return errChildIndexTooLarge
+			}
+			previousChild = index
+
+			childID, err := c.decodeID(src)
+			if err != nil {
+				return err
+			}
+			proofNode.Children[byte(index)] = childID
+		}
+	}
+
+	numKeys, err := c.decodeUint(src)
+	switch {
+	case err != nil:
+		return err
+	case numKeys > uint64(src.Len()/minProvenValueLen):
+		return io.ErrUnexpectedEOF
+	}
+
+	proof.Keys = make([]Key, numKeys)
+	proof.Values = make([]maybe.Maybe[[]byte], numKeys)
+	for i := range proof.Keys {
+		proof.Keys[i], err = c.decodeKeyFromReader(src)
+		if err != nil {
+			return err
+		}
+		proof.Values[i], err = c.decodeMaybeByteSlice(src)
+		if err != nil {
+			return err
+		}
+	}
+	if src.Len() != 0 {
+		return errExtraSpace
+	}
+	return nil
+}
+
 func (*codecImpl) encodeBool(dst *bytes.Buffer, value bool) {
 	bytesValue := falseBytes
 	if value {
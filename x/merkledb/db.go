@@ -15,8 +15,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/exp/maps"
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/trace"
@@ -46,9 +48,11 @@ var (
 	metadataPrefix         = []byte{0}
 	valueNodePrefix        = []byte{1}
 	intermediateNodePrefix = []byte{2}
+	historyPrefix          = []byte{3}
 
 	cleanShutdownKey        = []byte(string(metadataPrefix) + "cleanShutdown")
 	rootDBKey               = []byte(string(metadataPrefix) + "root")
+	importingKey            = []byte(string(metadataPrefix) + "importing")
 	hadCleanShutdown        = []byte{1}
 	didNotHaveCleanShutdown = []byte{0}
 
@@ -138,6 +142,17 @@ type Prefetcher interface {
 	PrefetchPaths(keys [][]byte) error
 }
 
+type HistoricalViewer interface {
+	// NewHistoricalView returns a read-only view of the trie as it was when
+	// its root was [rootID].
+	// Returns ErrInsufficientHistory if [rootID] isn't in the history.
+	//
+	// The returned trie is invalidated the next time changes are committed
+	// to the database, after which it returns ErrInvalid. Views created on
+	// top of it can't be committed.
+	NewHistoricalView(ctx context.Context, rootID ids.ID) (Trie, error)
+}
+
 type MerkleDB interface {
 	database.Database
 	Clearer
@@ -147,6 +162,11 @@ type MerkleDB interface {
 	ChangeProofer
 	RangeProofer
 	Prefetcher
+	HistoricalViewer
+
+	// setImporting records whether a snapshot is being imported, so that a
+	// partially imported trie can be cleared if the import is interrupted.
+	setImporting(importing bool) error
 }
 
 type Config struct {
@@ -159,13 +179,30 @@ type Config struct {
 	// If 0 is specified, [runtime.NumCPU] will be used.
 	RootGenConcurrency uint
 
+	// CommitConcurrency is the number of goroutines to use when serializing
+	// nodes with values during a commit.
+	//
+	// If 0 is specified, [runtime.NumCPU] will be used.
+	CommitConcurrency uint
+
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// The number of changes to the database that we store on disk in order to
+	// serve change proofs across restarts and beyond [HistoryLength].
+	// If both [DiskHistoryLength] and [DiskHistorySize] are 0, no changes are
+	// stored on disk.
+	DiskHistoryLength uint
+	// The number of bytes of changes to the database that we store on disk.
+	// If 0, the changes stored on disk are only bounded by [DiskHistoryLength].
+	DiskHistorySize uint
 	// The number of bytes used to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes used to cache nodes without values.
 	IntermediateNodeCacheSize uint
+	// The eviction policy of the node caches.
+	// If empty, nodes are evicted in LRU order.
+	CachePolicy cache.Policy
 	// The number of bytes used to store nodes without values in memory before forcing them onto disk.
 	IntermediateWriteBufferSize uint
 	// The number of bytes to write to disk when intermediate nodes are evicted
@@ -201,6 +238,10 @@ type merkleDB struct {
 	// historical views of the trie.
 	history *trieHistory
 
+	// Stores the value changes of older commits on disk. Used when [history]
+	// is insufficient. Nil if no history is stored on disk.
+	diskHistory *diskHistory
+
 	// True iff the db has been closed.
 	closed bool
 
@@ -244,11 +285,25 @@ func newDatabase(
 		return nil, err
 	}
 
+	intermediateNodeCache, err := cache.NewSized(config.CachePolicy, int(config.IntermediateNodeCacheSize), cacheEntrySize)
+	if err != nil {
+		return nil, err
+	}
+	valueNodeCache, err := cache.NewSized(config.CachePolicy, int(config.ValueNodeCacheSize), cacheEntrySize)
+	if err != nil {
+		return nil, err
+	}
+
 	rootGenConcurrency := uint(runtime.NumCPU())
 	if config.RootGenConcurrency != 0 {
 		rootGenConcurrency = config.RootGenConcurrency
 	}
 
+	commitConcurrency := uint(runtime.NumCPU())
+	if config.CommitConcurrency != 0 {
+		commitConcurrency = config.CommitConcurrency
+	}
+
 	// Share a sync.Pool of []byte between the intermediateNodeDB and valueNodeDB
 	// to reduce memory allocations.
 	bufferPool := &sync.Pool{
@@ -264,14 +319,15 @@ func newDatabase(
 			db,
 			bufferPool,
 			metrics,
-			int(config.IntermediateNodeCacheSize),
+			intermediateNodeCache,
 			int(config.IntermediateWriteBufferSize),
 			int(config.IntermediateWriteBatchSize),
 			BranchFactorToTokenSize[config.BranchFactor]),
 		valueNodeDB: newValueNodeDB(db,
 			bufferPool,
 			metrics,
-			int(config.ValueNodeCacheSize)),
+			valueNodeCache,
+			int(commitConcurrency)),
 		history:              newTrieHistory(int(config.HistoryLength)),
 		debugTracer:          getTracerIfEnabled(config.TraceLevel, DebugTrace, config.Tracer),
 		infoTracer:           getTracerIfEnabled(config.TraceLevel, InfoTrace, config.Tracer),
@@ -309,6 +365,38 @@ func newDatabase(
 		return nil, err
 	}
 
+	// The disk history is only initialized after any rebuild, so that the
+	// changes made while rebuilding aren't recorded.
+	if config.DiskHistoryLength != 0 || config.DiskHistorySize != 0 {
+		diskHistory, err := newDiskHistory(
+			db,
+			uint64(config.DiskHistoryLength),
+			uint64(config.DiskHistorySize),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := diskHistory.initialize(trieDB.rootID); err != nil {
+			return nil, err
+		}
+		trieDB.diskHistory = diskHistory
+	}
+
+	// If a snapshot import was interrupted, only part of the trie was
+	// imported.
+	importing, err := trieDB.baseDB.Has(importingKey)
+	if err != nil {
+		return nil, err
+	}
+	if importing {
+		if err := trieDB.Clear(); err != nil {
+			return nil, err
+		}
+		if err := trieDB.setImporting(false); err != nil {
+			return nil, err
+		}
+	}
+
 	// mark that the db has not yet been cleanly closed
 	err = trieDB.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown)
 	return trieDB, err
@@ -612,6 +700,20 @@ func (db *merkleDB) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return getProof(db, key)
 }
 
+func (db *merkleDB) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.GetMultiProof")
+	defer span.End()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	return getMultiProof(db, keys)
+}
+
 func (db *merkleDB) GetRangeProof(
 	ctx context.Context,
 	start maybe.Maybe[[]byte],
@@ -653,13 +755,50 @@ func (db *merkleDB) GetRangeProofAtRoot(
 		return nil, ErrEmptyProof
 	}
 
-	historicalTrie, err := db.getTrieAtRootForRange(rootID, start, end)
+	historicalTrie, err := db.getTrieAtRootForRange(ctx, rootID, start, end)
 	if err != nil {
 		return nil, err
 	}
 	return getRangeProof(historicalTrie, start, end, maxLength)
 }
 
+func (db *merkleDB) NewHistoricalView(ctx context.Context, rootID ids.ID) (Trie, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.NewHistoricalView")
+	defer span.End()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	var (
+		historicalView *view
+		err            error
+	)
+	if rootID == db.getMerkleRoot() {
+		historicalView, err = newView(db, db, ViewChanges{})
+		if err == nil {
+			err = historicalView.calculateNodeIDs(ctx)
+		}
+	} else {
+		historicalView, err = db.getViewAtRootForRange(ctx, rootID, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]())
+	}
+	if err != nil {
+		return nil, err
+	}
+	historicalView.readOnly = true
+
+	// Track the view so that it's invalidated by the next commit, after which
+	// the changes it was built from no longer describe [rootID].
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.childViews = append(db.childViews, historicalView)
+	return historicalView, nil
+}
+
 func (db *merkleDB) GetChangeProof(
 	ctx context.Context,
 	startRootID ids.ID,
@@ -688,6 +827,9 @@ func (db *merkleDB) GetChangeProof(
 	}
 
 	changes, err := db.history.getValueChanges(startRootID, endRootID, start, end, maxLength)
+	if errors.Is(err, ErrInsufficientHistory) && db.diskHistory != nil {
+		changes, err = db.diskHistory.getValueChanges(startRootID, endRootID, start, end, maxLength)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -719,7 +861,7 @@ func (db *merkleDB) GetChangeProof(
 
 	// Since we hold [db.commitlock] we must still have sufficient
 	// history to recreate the trie at [endRootID].
-	historicalTrie, err := db.getTrieAtRootForRange(endRootID, start, largestKey)
+	historicalTrie, err := db.getTrieAtRootForRange(ctx, endRootID, start, largestKey)
 	if err != nil {
 		return nil, err
 	}
@@ -918,6 +1060,8 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *view) error
 		return ErrInvalid
 	case trieToCommit.committed:
 		return ErrCommitted
+	case trieToCommit.readOnly:
+		return ErrReadOnly
 	case trieToCommit.db != trieToCommit.getParentTrie():
 		return ErrParentNotDatabase
 	}
@@ -939,8 +1083,10 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *view) error
 		return nil
 	}
 
-	currentValueNodeBatch := db.valueNodeDB.NewBatch()
-	_, nodesSpan := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.writeNodes")
+	var (
+		currentValueNodeBatch = db.valueNodeDB.NewBatch()
+		intermediateNodeOps   = make(map[Key]*node, len(changes.nodes))
+	)
 	for key, nodeChange := range changes.nodes {
 		shouldAddIntermediate := nodeChange.after != nil && !nodeChange.after.hasValue()
 		shouldDeleteIntermediate := !shouldAddIntermediate && nodeChange.before != nil && !nodeChange.before.hasValue()
@@ -949,15 +1095,9 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *view) error
 		shouldDeleteValue := !shouldAddValue && nodeChange.before != nil && nodeChange.before.hasValue()
 
 		if shouldAddIntermediate {
-			if err := db.intermediateNodeDB.Put(key, nodeChange.after); err != nil {
-				nodesSpan.End()
-				return err
-			}
+			intermediateNodeOps[key] = nodeChange.after
 		} else if shouldDeleteIntermediate {
-			if err := db.intermediateNodeDB.Delete(key); err != nil {
-				nodesSpan.End()
-				return err
-			}
+			intermediateNodeOps[key] = nil
 		}
 
 		if shouldAddValue {
@@ -966,27 +1106,61 @@ func (db *merkleDB) commitChanges(ctx context.Context, trieToCommit *view) error
 			currentValueNodeBatch.Delete(key)
 		}
 	}
-	nodesSpan.End()
 
-	_, commitSpan := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.valueNodeDBCommit")
-	err := currentValueNodeBatch.Write()
-	commitSpan.End()
-	if err != nil {
+	// Intermediate nodes and value nodes are stored independently, so they
+	// are written concurrently.
+	var eg errgroup.Group
+	eg.Go(func() error {
+		_, span := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.writeIntermediateNodes")
+		defer span.End()
+
+		return db.intermediateNodeDB.Write(intermediateNodeOps)
+	})
+	eg.Go(func() error {
+		_, span := db.infoTracer.Start(ctx, "MerkleDB.commitChanges.valueNodeDBCommit")
+		defer span.End()
+
+		return currentValueNodeBatch.Write()
+	})
+	if err := eg.Wait(); err != nil {
 		return err
 	}
 
 	db.history.record(changes)
 
+	// The disk history is written in the same batch as the root, so that the
+	// most recently recorded change always results in the stored root.
+	var (
+		rootBatch          = db.baseDB.NewBatch()
+		onDiskHistoryWrite = func() {}
+	)
+	if db.diskHistory != nil {
+		var err error
+		onDiskHistoryWrite, err = db.diskHistory.recordToBatch(rootBatch, changes.rootID, changes.values)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Update root in database.
 	db.root = changes.rootChange.after
 	db.rootID = changes.rootID
 
 	if db.root.IsNothing() {
-		return db.baseDB.Delete(rootDBKey)
+		if err := rootBatch.Delete(rootDBKey); err != nil {
+			return err
+		}
+	} else {
+		rootKey := codec.encodeKey(db.root.Value().key)
+		if err := rootBatch.Put(rootDBKey, rootKey); err != nil {
+			return err
+		}
 	}
-
-	rootKey := codec.encodeKey(db.root.Value().key)
-	return db.baseDB.Put(rootDBKey, rootKey)
+	if err := rootBatch.Write(); err != nil {
+		return err
+	}
+	onDiskHistoryWrite()
+	return nil
 }
 
 // moveChildViewsToDB removes any child views from the trieToCommit and moves them to the db
@@ -1207,6 +1381,7 @@ func (db *merkleDB) initializeRoot() error {
 // If [end] is Nothing, there's no upper bound on the range.
 // Assumes [db.commitLock] is read locked.
 func (db *merkleDB) getTrieAtRootForRange(
+	ctx context.Context,
 	rootID ids.ID,
 	start maybe.Maybe[[]byte],
 	end maybe.Maybe[[]byte],
@@ -1215,12 +1390,55 @@ func (db *merkleDB) getTrieAtRootForRange(
 	if rootID == db.getMerkleRoot() {
 		return db, nil
 	}
+	return db.getViewAtRootForRange(ctx, rootID, start, end)
+}
 
+// Returns a view of the trie as it was when it had root [rootID] for keys
+// within range [start, end], using [db.history] or, if it doesn't go back far
+// enough, [db.diskHistory].
+// Assumes [db.commitLock] is read locked.
+func (db *merkleDB) getViewAtRootForRange(
+	ctx context.Context,
+	rootID ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+) (*view, error) {
 	changeHistory, err := db.history.getChangesToGetToRoot(rootID, start, end)
+	switch {
+	case err == nil:
+		// [getChangesToGetToRoot] doesn't populate the root ID.
+		changeHistory.rootID = rootID
+		return newViewWithChanges(db, changeHistory)
+	case errors.Is(err, ErrInsufficientHistory) && db.diskHistory != nil:
+		return db.getViewAtRootFromDisk(ctx, rootID)
+	default:
+		return nil, err
+	}
+}
+
+// Returns a view of the trie as it was when it had root [rootID], built by
+// reverting the values changed since [rootID] according to [db.diskHistory].
+// Assumes [db.commitLock] is read locked.
+func (db *merkleDB) getViewAtRootFromDisk(ctx context.Context, rootID ids.ID) (*view, error) {
+	values, err := db.diskHistory.getValuesToGetToRoot(rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := newView(db, db, ViewChanges{
+		MapOps:       values,
+		ConsumeBytes: true,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return newViewWithChanges(db, changeHistory)
+	if err := view.calculateNodeIDs(ctx); err != nil {
+		return nil, err
+	}
+	if view.changes.rootID != rootID {
+		return nil, fmt.Errorf("%w: reverted to root %s rather than %s", errInvalidHistory, view.changes.rootID, rootID)
+	}
+	return view, nil
 }
 
 // Returns all keys in range [start, end] that aren't in [keySet].
@@ -1311,6 +1529,9 @@ func (db *merkleDB) Clear() error {
 		values: map[Key]*change[maybe.Maybe[[]byte]]{},
 		nodes:  map[Key]*change[*node]{},
 	})
+	if db.diskHistory != nil {
+		return db.diskHistory.reset(db.rootID)
+	}
 	return nil
 }
 
@@ -1318,6 +1539,13 @@ func (db *merkleDB) getTokenSize() int {
 	return db.tokenSize
 }
 
+func (db *merkleDB) setImporting(importing bool) error {
+	if importing {
+		return db.baseDB.Put(importingKey, nil)
+	}
+	return db.baseDB.Delete(importingKey)
+}
+
 // Returns [key] prefixed by [prefix].
 // The returned []byte is taken from [bufferPool] and
 // should be returned to it when the caller is done with it.
@@ -16,6 +16,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -106,6 +107,39 @@ func Test_MerkleDB_DB_Interface(t *testing.T) {
 	}
 }
 
+func Test_MerkleDB_Cache_Policies(t *testing.T) {
+	for _, policy := range []cache.Policy{cache.LRUPolicy, cache.TwoQueuePolicy, cache.TinyLFUPolicy} {
+		for name, test := range database.Tests {
+			t.Run(fmt.Sprintf("%s_%s", name, policy), func(t *testing.T) {
+				config := newDefaultConfig()
+				config.CachePolicy = policy
+				config.ValueNodeCacheSize = units.KiB
+				config.IntermediateNodeCacheSize = units.KiB
+				db, err := newDatabase(
+					context.Background(),
+					memdb.New(),
+					config,
+					&mockMetrics{},
+				)
+				require.NoError(t, err)
+				test(t, db)
+			})
+		}
+	}
+}
+
+func Test_MerkleDB_Unknown_Cache_Policy(t *testing.T) {
+	config := newDefaultConfig()
+	config.CachePolicy = "unknown"
+	_, err := newDatabase(
+		context.Background(),
+		memdb.New(),
+		config,
+		&mockMetrics{},
+	)
+	require.ErrorIs(t, err, cache.ErrUnknownPolicy)
+}
+
 func Benchmark_MerkleDB_DBInterface(b *testing.B) {
 	for _, size := range database.BenchmarkSizes {
 		keys, values := database.SetupBenchmark(b, size[0], size[1], size[2])
@@ -121,6 +155,49 @@ func Benchmark_MerkleDB_DBInterface(b *testing.B) {
 	}
 }
 
+func Benchmark_MerkleDB_Commit(b *testing.B) {
+	for _, bf := range validBranchFactors {
+		for _, numKeys := range []int{1_000, 10_000} {
+			b.Run(fmt.Sprintf("merkledb_%d_keys_%d", bf, numKeys), func(b *testing.B) {
+				require := require.New(b)
+
+				config := newDefaultConfig()
+				config.BranchFactor = bf
+				config.IntermediateWriteBufferSize = units.MiB
+				config.IntermediateWriteBatchSize = 256 * units.KiB
+				db, err := newDB(context.Background(), memdb.New(), config)
+				require.NoError(err)
+
+				r := rand.New(rand.NewSource(0)) // #nosec G404
+				ctx := context.Background()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					// Only measure the commit, not generating the changes or
+					// hashing them.
+					b.StopTimer()
+					ops := make([]database.BatchOp, numKeys)
+					for j := range ops {
+						ops[j].Key = make([]byte, 32)
+						ops[j].Value = make([]byte, 32)
+						_, _ = r.Read(ops[j].Key)
+						_, _ = r.Read(ops[j].Value)
+					}
+					view, err := db.NewView(ctx, ViewChanges{
+						BatchOps:     ops,
+						ConsumeBytes: true,
+					})
+					require.NoError(err)
+					_, err = view.GetMerkleRoot(ctx)
+					require.NoError(err)
+					b.StartTimer()
+
+					require.NoError(view.CommitToDB(ctx))
+				}
+			})
+		}
+	}
+}
+
 func Test_MerkleDB_DB_Load_Root_From_DB(t *testing.T) {
 	require := require.New(t)
 	baseDB := memdb.New()
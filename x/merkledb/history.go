@@ -184,48 +184,64 @@ func (th *trieHistory) getValueChanges(
 		changes, _ := th.history.Index(i)
 
 		// Add the changes from this commit to [combinedChanges].
-		for key, valueChange := range changes.values {
-			// The key is outside the range [start, end].
-			if (startKey.HasValue() && key.Less(startKey.Value())) ||
-				(end.HasValue() && key.Greater(endKey.Value())) {
-				continue
-			}
+		addValueChanges(combinedChanges, changedKeys, changes.values, startKey, endKey)
+	}
 
-			// A change to this key already exists in [combinedChanges]
-			// so update its before value with the earlier before value
-			if existing, ok := combinedChanges.values[key]; ok {
-				existing.after = valueChange.after
-				if existing.before.HasValue() == existing.after.HasValue() &&
-					bytes.Equal(existing.before.Value(), existing.after.Value()) {
-					// The change to this key is a no-op, so remove it from [combinedChanges].
-					delete(combinedChanges.values, key)
-					changedKeys.Remove(key)
-				}
-			} else {
-				combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
-					before: valueChange.before,
-					after:  valueChange.after,
-				}
-				changedKeys.Add(key)
+	limitValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// addValueChanges adds the changes in [values] to keys in [start, end] to
+// [combined]. [values] must have occurred after the changes already in
+// [combined]. Keys with changes in [combined] are tracked in [changedKeys].
+func addValueChanges(
+	combined *changeSummary,
+	changedKeys set.Set[Key],
+	values map[Key]*change[maybe.Maybe[[]byte]],
+	start maybe.Maybe[Key],
+	end maybe.Maybe[Key],
+) {
+	for key, valueChange := range values {
+		// The key is outside the range [start, end].
+		if (start.HasValue() && key.Less(start.Value())) ||
+			(end.HasValue() && key.Greater(end.Value())) {
+			continue
+		}
+
+		// A change to this key already exists in [combined]
+		// so update its before value with the earlier before value
+		if existing, ok := combined.values[key]; ok {
+			existing.after = valueChange.after
+			if existing.before.HasValue() == existing.after.HasValue() &&
+				bytes.Equal(existing.before.Value(), existing.after.Value()) {
+				// The change to this key is a no-op, so remove it from [combined].
+				delete(combined.values, key)
+				changedKeys.Remove(key)
 			}
+		} else {
+			combined.values[key] = &change[maybe.Maybe[[]byte]]{
+				before: valueChange.before,
+				after:  valueChange.after,
+			}
+			changedKeys.Add(key)
 		}
 	}
+}
 
+// limitValueChanges removes the greatest keys from [combined] until it
+// contains at most [maxLength] changes.
+func limitValueChanges(combined *changeSummary, changedKeys set.Set[Key], maxLength int) {
 	// If we have <= [maxLength] elements, we're done.
 	if changedKeys.Len() <= maxLength {
-		return combinedChanges, nil
+		return
 	}
 
-	// Keep only the smallest [maxLength] items in [combinedChanges.values].
+	// Keep only the smallest [maxLength] items in [combined.values].
 	sortedChangedKeys := changedKeys.List()
 	utils.Sort(sortedChangedKeys)
-	for len(sortedChangedKeys) > maxLength {
-		greatestKey := sortedChangedKeys[len(sortedChangedKeys)-1]
-		sortedChangedKeys = sortedChangedKeys[:len(sortedChangedKeys)-1]
-		delete(combinedChanges.values, greatestKey)
+	for _, key := range sortedChangedKeys[maxLength:] {
+		delete(combined.values, key)
 	}
-
-	return combinedChanges, nil
 }
 
 // Returns the changes to go from the current trie state back to the requested [rootID]
@@ -0,0 +1,356 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const diskHistoryMetadataLen = 3 * wrappers.LongLen
+
+var (
+	historyChangePrefix = []byte(string(historyPrefix) + "c")
+	historyRootPrefix   = []byte(string(historyPrefix) + "r")
+	historyMetadataKey  = []byte(string(metadataPrefix) + "history")
+
+	errInvalidHistory = errors.New("invalid history")
+)
+
+// diskHistory stores the value changes of previous commits on disk, so that
+// change proofs can be served across restarts and for roots that are no
+// longer in the in-memory [trieHistory].
+//
+// Only value changes are stored. Historical tries are reconstructed by
+// reverting the values changed since the requested root on top of the
+// current trie.
+//
+// The layout on disk is:
+//   - historyChangePrefix + insertNumber --> rootID + value changes
+//   - historyRootPrefix + rootID + insertNumber --> nil
+//   - historyMetadataKey --> oldest insertNumber + next insertNumber + size
+//
+// Insert numbers are big endian encoded, so that changes are iterated in
+// the order they were recorded.
+type diskHistory struct {
+	db database.Database
+
+	// Maximum number of changes to store. 0 means unbounded.
+	maxLength uint64
+	// Maximum number of bytes of changes to store. 0 means unbounded.
+	maxSize uint64
+
+	// The insert number of the oldest stored change.
+	oldest uint64
+	// The insert number of the next change to be recorded.
+	next uint64
+	// The number of bytes of stored changes.
+	size uint64
+}
+
+func newDiskHistory(db database.Database, maxLength, maxSize uint64) (*diskHistory, error) {
+	h := &diskHistory{
+		db:        db,
+		maxLength: maxLength,
+		maxSize:   maxSize,
+	}
+
+	metadata, err := db.Get(historyMetadataKey)
+	switch {
+	case err == database.ErrNotFound:
+		return h, nil
+	case err != nil:
+		return nil, err
+	case len(metadata) != diskHistoryMetadataLen:
+		return nil, fmt.Errorf("%w: metadata length %d", errInvalidHistory, len(metadata))
+	}
+
+	h.oldest = binary.BigEndian.Uint64(metadata)
+	h.next = binary.BigEndian.Uint64(metadata[wrappers.LongLen:])
+	h.size = binary.BigEndian.Uint64(metadata[2*wrappers.LongLen:])
+	return h, nil
+}
+
+// initialize ensures that the most recent change resulted in [rootID]. If it
+// didn't, the stored history doesn't describe the current trie, so it is
+// cleared.
+func (h *diskHistory) initialize(rootID ids.ID) error {
+	if h.next > h.oldest {
+		lastRootID, _, err := h.getChanges(h.next - 1)
+		if err != nil {
+			return err
+		}
+		if lastRootID == rootID {
+			return nil
+		}
+	}
+
+	return h.reset(rootID)
+}
+
+// reset removes all the stored changes and records [rootID] as the most
+// recent root.
+func (h *diskHistory) reset(rootID ids.ID) error {
+	if err := h.clear(); err != nil {
+		return err
+	}
+	return h.record(rootID, nil)
+}
+
+// clear removes all the stored changes.
+func (h *diskHistory) clear() error {
+	if err := database.ClearPrefix(h.db, historyPrefix, clearBatchSize); err != nil {
+		return err
+	}
+	h.oldest = 0
+	h.next = 0
+	h.size = 0
+	return h.db.Delete(historyMetadataKey)
+}
+
+// record stores the value changes resulting in [rootID] and removes the
+// oldest changes that exceed the retention limits.
+func (h *diskHistory) record(rootID ids.ID, values map[Key]*change[maybe.Maybe[[]byte]]) error {
+	batch := h.db.NewBatch()
+	onWrite, err := h.recordToBatch(batch, rootID, values)
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	onWrite()
+	return nil
+}
+
+// recordToBatch adds the writes of [record] to [batch], so that the changes
+// can be written atomically with other data. The returned function must be
+// called after [batch] is written.
+func (h *diskHistory) recordToBatch(
+	batch database.Batch,
+	rootID ids.ID,
+	values map[Key]*change[maybe.Maybe[[]byte]],
+) (func(), error) {
+	var (
+		changes = codec.encodeValueChanges(rootID, values)
+		oldest  = h.oldest
+		next    = h.next + 1
+		size    = h.size + uint64(len(changes))
+	)
+	if err := batch.Put(historyChangeKey(h.next), changes); err != nil {
+		return nil, err
+	}
+	if err := batch.Put(historyRootKey(rootID, h.next), nil); err != nil {
+		return nil, err
+	}
+
+	// Always keep the most recent change, so that the current root can be
+	// used as the start root of a change proof.
+	for next-oldest > 1 && h.exceedsLimits(next-oldest, size) {
+		oldChanges, err := h.db.Get(historyChangeKey(oldest))
+		if err != nil {
+			return nil, err
+		}
+		oldRootID, _, err := codec.decodeValueChanges(oldChanges)
+		if err != nil {
+			return nil, err
+		}
+		if err := batch.Delete(historyChangeKey(oldest)); err != nil {
+			return nil, err
+		}
+		if err := batch.Delete(historyRootKey(oldRootID, oldest)); err != nil {
+			return nil, err
+		}
+		oldest++
+		size -= uint64(len(oldChanges))
+	}
+
+	metadata := make([]byte, diskHistoryMetadataLen)
+	binary.BigEndian.PutUint64(metadata, oldest)
+	binary.BigEndian.PutUint64(metadata[wrappers.LongLen:], next)
+	binary.BigEndian.PutUint64(metadata[2*wrappers.LongLen:], size)
+	if err := batch.Put(historyMetadataKey, metadata); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		h.oldest = oldest
+		h.next = next
+		h.size = size
+	}, nil
+}
+
+func (h *diskHistory) exceedsLimits(length, size uint64) bool {
+	return (h.maxLength != 0 && length > h.maxLength) ||
+		(h.maxSize != 0 && size > h.maxSize)
+}
+
+// getValueChanges has the same semantics as [trieHistory.getValueChanges].
+func (h *diskHistory) getValueChanges(
+	startRoot ids.ID,
+	endRoot ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*changeSummary, error) {
+	if maxLength <= 0 {
+		return nil, fmt.Errorf("%w but was %d", ErrInvalidMaxLength, maxLength)
+	}
+
+	if startRoot == endRoot {
+		return newChangeSummary(maxLength), nil
+	}
+
+	endInsertNumber, ok, err := h.lastInsertNumber(endRoot, h.next)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoEndRoot, endRoot)
+	}
+
+	startInsertNumber, ok, err := h.lastInsertNumber(startRoot, endInsertNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w: start root %s not found before end root %s",
+			ErrInsufficientHistory, startRoot, endRoot,
+		)
+	}
+
+	var (
+		changedKeys     = set.Set[Key]{}
+		startKey        = maybe.Bind(start, ToKey)
+		endKey          = maybe.Bind(end, ToKey)
+		combinedChanges = newChangeSummary(maxLength)
+	)
+	err = h.iterateChanges(startInsertNumber+1, endInsertNumber, func(values map[Key]*change[maybe.Maybe[[]byte]]) {
+		addValueChanges(combinedChanges, changedKeys, values, startKey, endKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	limitValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// getValuesToGetToRoot returns the values that must be written on top of the
+// current trie to revert it to the most recent trie with [rootID]. Deleted
+// values are Nothing.
+//
+// Returns [ErrInsufficientHistory] if [rootID] isn't in the history.
+func (h *diskHistory) getValuesToGetToRoot(rootID ids.ID) (map[string]maybe.Maybe[[]byte], error) {
+	insertNumber, ok, err := h.lastInsertNumber(rootID, h.next)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInsufficientHistory
+	}
+
+	// The value of a key at [rootID] is the value before the first change to
+	// it after [rootID].
+	values := make(map[string]maybe.Maybe[[]byte])
+	err = h.iterateChanges(insertNumber+1, h.next-1, func(changes map[Key]*change[maybe.Maybe[[]byte]]) {
+		for key, valueChange := range changes {
+			keyBytes := string(key.Bytes())
+			if _, ok := values[keyBytes]; !ok {
+				values[keyBytes] = valueChange.before
+			}
+		}
+	})
+	return values, err
+}
+
+// lastInsertNumber returns the greatest insert number less than [before] of a
+// change resulting in [rootID].
+func (h *diskHistory) lastInsertNumber(rootID ids.ID, before uint64) (uint64, bool, error) {
+	it := h.db.NewIteratorWithPrefix(historyRootKey(rootID, 0)[:len(historyRootPrefix)+ids.IDLen])
+	defer it.Release()
+
+	var (
+		insertNumber uint64
+		found        bool
+	)
+	for it.Next() {
+		key := it.Key()
+		number := binary.BigEndian.Uint64(key[len(key)-wrappers.LongLen:])
+		if number >= before {
+			break
+		}
+		insertNumber = number
+		found = true
+	}
+	return insertNumber, found, it.Error()
+}
+
+// iterateChanges calls [f] with the value changes of every change with an
+// insert number in [first, last], in order.
+func (h *diskHistory) iterateChanges(first, last uint64, f func(map[Key]*change[maybe.Maybe[[]byte]])) error {
+	if first > last {
+		return nil
+	}
+
+	it := h.db.NewIteratorWithStartAndPrefix(historyChangeKey(first), historyChangePrefix)
+	defer it.Release()
+
+	expected := first
+	for it.Next() {
+		key := it.Key()
+		insertNumber := binary.BigEndian.Uint64(key[len(historyChangePrefix):])
+		if insertNumber > last {
+			break
+		}
+		if insertNumber != expected {
+			return fmt.Errorf("%w: expected change %d but found %d", errInvalidHistory, expected, insertNumber)
+		}
+		expected++
+
+		_, values, err := codec.decodeValueChanges(it.Value())
+		if err != nil {
+			return err
+		}
+		f(values)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if expected != last+1 {
+		return fmt.Errorf("%w: missing change %d", errInvalidHistory, expected)
+	}
+	return nil
+}
+
+func (h *diskHistory) getChanges(insertNumber uint64) (ids.ID, map[Key]*change[maybe.Maybe[[]byte]], error) {
+	changes, err := h.db.Get(historyChangeKey(insertNumber))
+	if err != nil {
+		return ids.Empty, nil, err
+	}
+	return codec.decodeValueChanges(changes)
+}
+
+func historyChangeKey(insertNumber uint64) []byte {
+	key := make([]byte, len(historyChangePrefix)+wrappers.LongLen)
+	copy(key, historyChangePrefix)
+	binary.BigEndian.PutUint64(key[len(historyChangePrefix):], insertNumber)
+	return key
+}
+
+func historyRootKey(rootID ids.ID, insertNumber uint64) []byte {
+	key := make([]byte, len(historyRootPrefix)+ids.IDLen+wrappers.LongLen)
+	copy(key, historyRootPrefix)
+	copy(key[len(historyRootPrefix):], rootID[:])
+	binary.BigEndian.PutUint64(key[len(historyRootPrefix)+ids.IDLen:], insertNumber)
+	return key
+}
@@ -5,12 +5,15 @@ package merkledb
 
 import (
 	"context"
+	"maps"
 	"math/rand"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/maybe"
@@ -775,3 +778,242 @@ func TestHistoryGetChangesToRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestDiskHistoryAcrossRestart(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		memConfig  = newDefaultConfig()
+		diskConfig = newDefaultConfig()
+	)
+	memConfig.HistoryLength = 100
+	diskConfig.HistoryLength = 1
+	diskConfig.DiskHistoryLength = 100
+
+	memDB, err := newDB(context.Background(), memdb.New(), memConfig)
+	require.NoError(err)
+
+	baseDB := memdb.New()
+	diskDB, err := newDB(context.Background(), baseDB, diskConfig)
+	require.NoError(err)
+
+	r := rand.New(rand.NewSource(int64(0))) // #nosec G404
+	roots := []ids.ID{memDB.getMerkleRoot()}
+	for i := 0; i < 20; i++ {
+		memBatch := memDB.NewBatch()
+		diskBatch := diskDB.NewBatch()
+		for j := 0; j < 10; j++ {
+			key := []byte{byte(r.Intn(32)), byte(r.Intn(32))}
+			if r.Intn(4) == 0 {
+				require.NoError(memBatch.Delete(key))
+				require.NoError(diskBatch.Delete(key))
+				continue
+			}
+			value := []byte{byte(r.Intn(256))}
+			require.NoError(memBatch.Put(key, value))
+			require.NoError(diskBatch.Put(key, value))
+		}
+		require.NoError(memBatch.Write())
+		require.NoError(diskBatch.Write())
+
+		root := memDB.getMerkleRoot()
+		require.Equal(root, diskDB.getMerkleRoot())
+		roots = append(roots, root)
+	}
+
+	require.NoError(diskDB.Close())
+	diskConfig.Reg = prometheus.NewRegistry()
+	diskDB, err = newDB(context.Background(), baseDB, diskConfig)
+	require.NoError(err)
+
+	for i := 0; i < len(roots)-1; i++ {
+		for j := i + 1; j < len(roots); j++ {
+			if roots[i] == roots[j] {
+				continue
+			}
+
+			expectedProof, err := memDB.GetChangeProof(context.Background(), roots[i], roots[j], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+			require.NoError(err)
+			proof, err := diskDB.GetChangeProof(context.Background(), roots[i], roots[j], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+			require.NoError(err)
+			require.Equal(expectedProof, proof)
+
+			expectedProof, err = memDB.GetChangeProof(context.Background(), roots[i], roots[j], maybe.Some([]byte{8}), maybe.Some([]byte{24}), 5)
+			require.NoError(err)
+			proof, err = diskDB.GetChangeProof(context.Background(), roots[i], roots[j], maybe.Some([]byte{8}), maybe.Some([]byte{24}), 5)
+			require.NoError(err)
+			require.Equal(expectedProof, proof)
+		}
+
+		if roots[i] == ids.Empty {
+			continue
+		}
+		expectedRangeProof, err := memDB.GetRangeProofAtRoot(context.Background(), roots[i], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+		require.NoError(err)
+		rangeProof, err := diskDB.GetRangeProofAtRoot(context.Background(), roots[i], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+		require.NoError(err)
+		require.Equal(expectedRangeProof, rangeProof)
+	}
+}
+
+func TestDiskHistoryRetention(t *testing.T) {
+	tests := []struct {
+		name              string
+		diskHistoryLength uint
+		diskHistorySize   uint
+	}{
+		{
+			name:              "length",
+			diskHistoryLength: 3,
+		},
+		{
+			name:            "size",
+			diskHistorySize: 256,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := newDefaultConfig()
+			config.HistoryLength = 1
+			config.DiskHistoryLength = test.diskHistoryLength
+			config.DiskHistorySize = test.diskHistorySize
+			db, err := newDB(context.Background(), memdb.New(), config)
+			require.NoError(err)
+
+			roots := []ids.ID{db.getMerkleRoot()}
+			for i := 0; i < 10; i++ {
+				// Each change takes less than 100 bytes on disk.
+				require.NoError(db.Put([]byte{byte(i)}, make([]byte, 32)))
+				require.NoError(db.Put([]byte{byte(i)}, make([]byte, 16)))
+				roots = append(roots, db.getMerkleRoot())
+			}
+
+			require.LessOrEqual(db.diskHistory.next-db.diskHistory.oldest, uint64(3))
+			if test.diskHistorySize != 0 {
+				require.LessOrEqual(db.diskHistory.size, uint64(test.diskHistorySize))
+			}
+
+			_, err = db.GetChangeProof(context.Background(), roots[7], roots[8], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+			require.ErrorIs(err, ErrInsufficientHistory)
+
+			_, err = db.GetChangeProof(context.Background(), roots[9], roots[10], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+			require.NoError(err)
+		})
+	}
+}
+
+func TestNewHistoricalView(t *testing.T) {
+	tests := []struct {
+		name              string
+		historyLength     uint
+		diskHistoryLength uint
+	}{
+		{
+			name:          "in-memory history",
+			historyLength: 100,
+		},
+		{
+			name:              "on-disk history",
+			historyLength:     1,
+			diskHistoryLength: 100,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := newDefaultConfig()
+			config.HistoryLength = tt.historyLength
+			config.DiskHistoryLength = tt.diskHistoryLength
+			db, err := newDB(context.Background(), memdb.New(), config)
+			require.NoError(err)
+
+			var (
+				r      = rand.New(rand.NewSource(int64(0))) // #nosec G404
+				state  = map[string][]byte{}
+				roots  []ids.ID
+				states []map[string][]byte
+			)
+			for i := 0; i < 10; i++ {
+				batch := db.NewBatch()
+				for j := 0; j < 10; j++ {
+					key := []byte{byte(r.Intn(32))}
+					if r.Intn(4) == 0 {
+						require.NoError(batch.Delete(key))
+						delete(state, string(key))
+						continue
+					}
+					value := []byte{byte(r.Intn(256))}
+					require.NoError(batch.Put(key, value))
+					state[string(key)] = value
+				}
+				require.NoError(batch.Write())
+
+				roots = append(roots, db.getMerkleRoot())
+				states = append(states, maps.Clone(state))
+			}
+
+			ctx := context.Background()
+			for i, root := range roots {
+				trie, err := db.NewHistoricalView(ctx, root)
+				require.NoError(err)
+
+				gotRoot, err := trie.GetMerkleRoot(ctx)
+				require.NoError(err)
+				require.Equal(root, gotRoot)
+
+				for key := 0; key < 32; key++ {
+					value, err := trie.GetValue(ctx, []byte{byte(key)})
+					expectedValue, ok := states[i][string([]byte{byte(key)})]
+					if !ok {
+						require.ErrorIs(err, database.ErrNotFound)
+						continue
+					}
+					require.NoError(err)
+					require.Equal(expectedValue, value)
+
+					proof, err := trie.GetProof(ctx, []byte{byte(key)})
+					require.NoError(err)
+					require.Equal(maybe.Some(expectedValue), proof.Value)
+					require.NoError(proof.Verify(ctx, root, db.tokenSize))
+				}
+
+				iteratedState := map[string][]byte{}
+				it := trie.NewIterator()
+				for it.Next() {
+					iteratedState[string(it.Key())] = it.Value()
+				}
+				require.NoError(it.Error())
+				it.Release()
+				require.Equal(states[i], iteratedState)
+			}
+
+			_, err = db.NewHistoricalView(ctx, ids.GenerateTestID())
+			require.ErrorIs(err, ErrInsufficientHistory)
+
+			trie, err := db.NewHistoricalView(ctx, roots[0])
+			require.NoError(err)
+
+			// Historical views can't be committed, directly or indirectly.
+			err = trie.(View).CommitToDB(ctx)
+			require.ErrorIs(err, ErrReadOnly)
+
+			childView, err := trie.NewView(ctx, ViewChanges{
+				BatchOps: []database.BatchOp{
+					{Key: []byte{0}, Value: []byte{0}},
+				},
+			})
+			require.NoError(err)
+			err = childView.CommitToDB(ctx)
+			require.ErrorIs(err, ErrParentNotDatabase)
+			require.Equal(roots[len(roots)-1], db.getMerkleRoot())
+
+			// Committing to the database invalidates historical views.
+			require.NoError(db.Put([]byte{0}, []byte{1}))
+			_, err = trie.GetValue(ctx, []byte{0})
+			require.ErrorIs(err, ErrInvalid)
+		})
+	}
+}
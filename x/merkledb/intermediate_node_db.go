@@ -8,9 +8,16 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 )
 
-const defaultBufferLength = 256
+const (
+	defaultBufferLength = 256
+
+	// pendingEvictionBatches is the number of batches of evicted nodes that
+	// can be waiting to be written during [intermediateNodeDB.Write].
+	pendingEvictionBatches = 2
+)
 
 // Holds intermediate nodes. That is, those without values.
 // Changes to this database aren't written to [baseDB] until
@@ -37,13 +44,18 @@ type intermediateNodeDB struct {
 	evictionBatchSize int
 	metrics           merkleMetrics
 	tokenSize         int
+
+	// If non-nil, eviction batches are written by [batchWriter] rather than
+	// by the goroutine evicting the nodes.
+	// Only set during [Write].
+	batchWriter *batchWriter
 }
 
 func newIntermediateNodeDB(
 	db database.Database,
 	bufferPool *sync.Pool,
 	metrics merkleMetrics,
-	cacheSize int,
+	nodeCache cache.Cacher[Key, *node],
 	writeBufferSize int,
 	evictionBatchSize int,
 	tokenSize int,
@@ -54,7 +66,7 @@ func newIntermediateNodeDB(
 		bufferPool:        bufferPool,
 		evictionBatchSize: evictionBatchSize,
 		tokenSize:         tokenSize,
-		nodeCache:         cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		nodeCache:         nodeCache,
 	}
 	result.writeBuffer = newOnEvictCache(
 		writeBufferSize,
@@ -91,6 +103,10 @@ func (db *intermediateNodeDB) onEviction(key Key, n *node) error {
 			return err
 		}
 	}
+	if db.batchWriter != nil {
+		db.batchWriter.write(writeBatch)
+		return nil
+	}
 	if err := writeBatch.Write(); err != nil {
 		_ = db.baseDB.Close()
 		return err
@@ -156,6 +172,40 @@ func (db *intermediateNodeDB) Put(key Key, n *node) error {
 	return db.writeBuffer.Put(key, n)
 }
 
+// Write puts each node in [ops] into the database, or deletes it if it's nil.
+//
+// Batches of nodes evicted from the write buffer are written to [baseDB] by a
+// separate goroutine, so that the next batch can be serialized while the
+// previous one is being written. All of the batches have been written when
+// Write returns.
+//
+// A non-nil error is considered fatal and closes [db.baseDB].
+func (db *intermediateNodeDB) Write(ops map[Key]*node) error {
+	db.batchWriter = newBatchWriter(pendingEvictionBatches)
+	defer func() {
+		db.batchWriter = nil
+	}()
+
+	var errs wrappers.Errs
+	for key, n := range ops {
+		var err error
+		if n == nil {
+			err = db.Delete(key)
+		} else {
+			err = db.Put(key, n)
+		}
+		if err != nil {
+			errs.Add(err)
+			break
+		}
+	}
+	errs.Add(db.batchWriter.wait())
+	if errs.Errored() {
+		_ = db.baseDB.Close()
+	}
+	return errs.Err
+}
+
 func (db *intermediateNodeDB) Flush() error {
 	db.nodeCache.Flush()
 	return db.writeBuffer.Flush()
@@ -178,3 +228,46 @@ func (db *intermediateNodeDB) Clear() error {
 	)
 	return database.AtomicClearPrefix(db.baseDB, db.baseDB, intermediateNodePrefix)
 }
+
+// batchWriter writes batches on a separate goroutine.
+type batchWriter struct {
+	batches chan database.Batch
+	done    chan struct{}
+	// The first error returned when writing a batch.
+	// Only accessed by the writing goroutine until [done] is closed.
+	err error
+}
+
+func newBatchWriter(pendingBatches int) *batchWriter {
+	w := &batchWriter{
+		batches: make(chan database.Batch, pendingBatches),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *batchWriter) run() {
+	defer close(w.done)
+
+	for batch := range w.batches {
+		// Once a write has failed, the remaining batches are dropped.
+		if w.err == nil {
+			w.err = batch.Write()
+		}
+	}
+}
+
+// write queues [batch] to be written. Blocks if there are already too many
+// pending batches.
+func (w *batchWriter) write(batch database.Batch) {
+	w.batches <- batch
+}
+
+// wait blocks until every queued batch has been written and returns the first
+// error that occurred. No batches may be written after wait is called.
+func (w *batchWriter) wait() error {
+	close(w.batches)
+	<-w.done
+	return w.err
+}
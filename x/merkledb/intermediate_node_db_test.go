@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/utils/maybe"
@@ -39,7 +40,7 @@ func Test_IntermediateNodeDB(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -153,7 +154,7 @@ func FuzzIntermediateNodeDBConstructDBKey(f *testing.F) {
 					New: func() interface{} { return make([]byte, 0) },
 				},
 				&mockMetrics{},
-				cacheSize,
+				cache.NewSizedLRU(cacheSize, cacheEntrySize),
 				bufferSize,
 				evictionBatchSize,
 				tokenSize,
@@ -196,7 +197,7 @@ func Test_IntermediateNodeDB_ConstructDBKey_DirtyBuffer(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -233,7 +234,7 @@ func TestIntermediateNodeDBClear(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -269,7 +270,7 @@ func TestIntermediateNodeDBDeleteEmptyKey(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
 		bufferSize,
 		evictionBatchSize,
 		4,
@@ -292,3 +293,79 @@ func TestIntermediateNodeDBDeleteEmptyKey(t *testing.T) {
 	require.NoError(err)
 	require.False(has)
 }
+
+func TestIntermediateNodeDBWrite(t *testing.T) {
+	require := require.New(t)
+
+	// Use a small write buffer so that many batches are evicted during Write.
+	bufferSize := 1_000
+	baseDB := memdb.New()
+	db := newIntermediateNodeDB(
+		baseDB,
+		&sync.Pool{
+			New: func() interface{} { return make([]byte, 0) },
+		},
+		&mockMetrics{},
+		cache.NewSizedLRU(0, cacheEntrySize),
+		bufferSize,
+		bufferSize/4,
+		4,
+	)
+
+	ops := make(map[Key]*node)
+	for i := 0; i < 1_000; i++ {
+		key := ToKey([]byte{byte(i >> 8), byte(i)})
+		ops[key] = newNode(key)
+	}
+	require.NoError(db.Write(ops))
+	require.Nil(db.batchWriter)
+
+	// Every node is either still buffered or has been written.
+	for key := range ops {
+		_, err := db.Get(key)
+		require.NoError(err)
+	}
+	require.NoError(db.Flush())
+	for key := range ops {
+		has, err := baseDB.Has(db.constructDBKey(key))
+		require.NoError(err)
+		require.True(has)
+	}
+
+	for key := range ops {
+		ops[key] = nil
+	}
+	require.NoError(db.Write(ops))
+	require.NoError(db.Flush())
+
+	iter := baseDB.NewIteratorWithPrefix(intermediateNodePrefix)
+	defer iter.Release()
+	require.False(iter.Next())
+}
+
+func TestIntermediateNodeDBWriteError(t *testing.T) {
+	require := require.New(t)
+
+	bufferSize := 1_000
+	baseDB := memdb.New()
+	db := newIntermediateNodeDB(
+		baseDB,
+		&sync.Pool{
+			New: func() interface{} { return make([]byte, 0) },
+		},
+		&mockMetrics{},
+		cache.NewSizedLRU(0, cacheEntrySize),
+		bufferSize,
+		bufferSize/4,
+		4,
+	)
+	require.NoError(baseDB.Close())
+
+	ops := make(map[Key]*node)
+	for i := 0; i < 1_000; i++ {
+		key := ToKey([]byte{byte(i >> 8), byte(i)})
+		ops[key] = newNode(key)
+	}
+	err := db.Write(ops)
+	require.ErrorIs(err, database.ErrClosed)
+}
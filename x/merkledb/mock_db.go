@@ -171,6 +171,21 @@ func (mr *MockMerkleDBMockRecorder) GetMerkleRoot(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerkleRoot", reflect.TypeOf((*MockMerkleDB)(nil).GetMerkleRoot), ctx)
 }
 
+// GetMultiProof mocks base method.
+func (m *MockMerkleDB) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiProof", ctx, keys)
+	ret0, _ := ret[0].(*MultiProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiProof indicates an expected call of GetMultiProof.
+func (mr *MockMerkleDBMockRecorder) GetMultiProof(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiProof", reflect.TypeOf((*MockMerkleDB)(nil).GetMultiProof), ctx, keys)
+}
+
 // GetProof mocks base method.
 func (m *MockMerkleDB) GetProof(ctx context.Context, keyBytes []byte) (*Proof, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +305,21 @@ func (mr *MockMerkleDBMockRecorder) NewBatch() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBatch", reflect.TypeOf((*MockMerkleDB)(nil).NewBatch))
 }
 
+// NewHistoricalView mocks base method.
+func (m *MockMerkleDB) NewHistoricalView(ctx context.Context, rootID ids.ID) (Trie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewHistoricalView", ctx, rootID)
+	ret0, _ := ret[0].(Trie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewHistoricalView indicates an expected call of NewHistoricalView.
+func (mr *MockMerkleDBMockRecorder) NewHistoricalView(ctx, rootID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewHistoricalView", reflect.TypeOf((*MockMerkleDB)(nil).NewHistoricalView), ctx, rootID)
+}
+
 // NewIterator mocks base method.
 func (m *MockMerkleDB) NewIterator() database.Iterator {
 	m.ctrl.T.Helper()
@@ -489,3 +519,17 @@ func (mr *MockMerkleDBMockRecorder) getValue(key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getValue", reflect.TypeOf((*MockMerkleDB)(nil).getValue), key)
 }
+
+// setImporting mocks base method.
+func (m *MockMerkleDB) setImporting(importing bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "setImporting", importing)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// setImporting indicates an expected call of setImporting.
+func (mr *MockMerkleDBMockRecorder) setImporting(importing any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setImporting", reflect.TypeOf((*MockMerkleDB)(nil).setImporting), importing)
+}
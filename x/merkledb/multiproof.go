@@ -0,0 +1,274 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+)
+
+var (
+	ErrNonIncreasingMultiProofNodes = errors.New("multi proof nodes are not in increasing order")
+	ErrUnconnectedProofNode         = errors.New("proof node is not the child of another proof node")
+	ErrMissingProofNode             = errors.New("proof is missing a node on the path to a key")
+	ErrKeysValuesLengthMismatch     = errors.New("number of keys and values don't match")
+)
+
+// MultiProof is a proof of the values of several keys. Nodes shared by the
+// paths of multiple keys are only included once.
+type MultiProof struct {
+	// The nodes on the paths from the root to each of [Keys], including the
+	// nodes that prove the exclusion of keys that aren't in the trie.
+	// Sorted by increasing key. Always contains at least the root.
+	Nodes []ProofNode
+
+	// The keys that this proof proves exist/don't exist.
+	// Sorted by increasing key.
+	Keys []Key
+
+	// Values[i] is Nothing if Keys[i] isn't in the trie.
+	// Otherwise, it's the value corresponding to Keys[i].
+	Values []maybe.Maybe[[]byte]
+}
+
+// Verify returns nil if the trie given in [proof] has root [expectedRootID].
+// That is, this is a valid proof that each of [proof.Keys] has the
+// corresponding value in [proof.Values] in the trie with root
+// [expectedRootID].
+func (proof *MultiProof) Verify(ctx context.Context, expectedRootID ids.ID, tokenSize int) error {
+	switch {
+	case len(proof.Nodes) == 0:
+		return ErrEmptyProof
+	case len(proof.Keys) != len(proof.Values):
+		return fmt.Errorf("%w: %d keys, %d values", ErrKeysValuesLengthMismatch, len(proof.Keys), len(proof.Values))
+	}
+
+	for i := 1; i < len(proof.Keys); i++ {
+		if !proof.Keys[i-1].Less(proof.Keys[i]) {
+			return ErrNonIncreasingValues
+		}
+	}
+
+	if err := verifyMultiProofNodes(proof.Nodes, tokenSize); err != nil {
+		return err
+	}
+
+	for i, key := range proof.Keys {
+		if err := verifyMultiProofKey(proof.Nodes, key, proof.Values[i], tokenSize); err != nil {
+			return err
+		}
+	}
+
+	// Don't bother locking [view] -- nobody else has a reference to it.
+	view, err := getStandaloneView(ctx, nil, tokenSize)
+	if err != nil {
+		return err
+	}
+
+	// Insert the deepest nodes first, so that every child that is in the proof
+	// exists before the children that are only known by their IDs are added.
+	for i := len(proof.Nodes) - 1; i >= 0; i-- {
+		proofNode := proof.Nodes[i]
+
+		// pass nothing because we are going to overwrite the value digest below
+		n, err := view.insert(proofNode.Key, maybe.Nothing[[]byte]())
+		if err != nil {
+			return err
+		}
+		// We overwrite the valueDigest to be the hash provided in the proof
+		// node because we may not know the pre-image of the valueDigest.
+		n.valueDigest = proofNode.ValueOrHash
+
+		for index, childID := range proofNode.Children {
+			if _, ok := n.children[index]; ok {
+				continue
+			}
+			// We only need the ID to be correct so that the calculated hash is
+			// correct.
+			n.setChildEntry(index, &child{
+				id: childID,
+			})
+		}
+	}
+
+	gotRootID, err := view.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if expectedRootID != gotRootID {
+		return fmt.Errorf("%w:[%s], expected:[%s]", ErrInvalidProof, gotRootID, expectedRootID)
+	}
+	return nil
+}
+
+// MarshalBinary returns the canonical binary encoding of [proof].
+func (proof *MultiProof) MarshalBinary() ([]byte, error) {
+	if len(proof.Keys) != len(proof.Values) {
+		return nil, fmt.Errorf("%w: %d keys, %d values", ErrKeysValuesLengthMismatch, len(proof.Keys), len(proof.Values))
+	}
+	return codec.encodeMultiProof(proof), nil
+}
+
+// UnmarshalBinary parses [b], which must have been returned by MarshalBinary,
+// into [proof].
+func (proof *MultiProof) UnmarshalBinary(b []byte) error {
+	return codec.decodeMultiProof(b, proof)
+}
+
+// Returns nil iff all the following hold:
+//   - Nodes with a partial byte length don't have a value.
+//   - Each key in [nodes] is greater than the previous key.
+//   - Each node, except the first, is the child of the closest node whose key
+//     is a prefix of its key. That is, the nodes form a single subtree of the
+//     trie rooted at the first node.
+//
+// Assumes [nodes] is non-empty.
+func verifyMultiProofNodes(nodes []ProofNode, tokenSize int) error {
+	type ancestor struct {
+		key Key
+		// The children of the node.
+		children map[byte]ids.ID
+		// The indices of the children that are in [nodes].
+		provenChildren map[byte]struct{}
+	}
+
+	// Because [nodes] is sorted, every node is visited after its ancestors
+	// and before any node that isn't its descendant.
+	var ancestors []ancestor
+	for i, proofNode := range nodes {
+		key := proofNode.Key
+		if key.hasPartialByte() && proofNode.ValueOrHash.HasValue() {
+			return ErrPartialByteLengthWithValue
+		}
+		if i > 0 && !nodes[i-1].Key.Less(key) {
+			return ErrNonIncreasingMultiProofNodes
+		}
+
+		for len(ancestors) > 0 && !key.HasStrictPrefix(ancestors[len(ancestors)-1].key) {
+			ancestors = ancestors[:len(ancestors)-1]
+		}
+		if i > 0 {
+			if len(ancestors) == 0 {
+				return fmt.Errorf("%w: %x isn't below the root", ErrUnconnectedProofNode, key.Bytes())
+			}
+
+			parent := ancestors[len(ancestors)-1]
+			index := key.Token(parent.key.length, tokenSize)
+			if _, ok := parent.children[index]; !ok {
+				return fmt.Errorf("%w: %x has no child at index %d", ErrUnconnectedProofNode, parent.key.Bytes(), index)
+			}
+			// Two nodes sharing a branch must share an ancestor in the proof
+			// below [parent].
+			if _, ok := parent.provenChildren[index]; ok {
+				return fmt.Errorf("%w: %x has multiple children at index %d", ErrUnconnectedProofNode, parent.key.Bytes(), index)
+			}
+			parent.provenChildren[index] = struct{}{}
+		}
+
+		ancestors = append(ancestors, ancestor{
+			key:            key,
+			children:       proofNode.Children,
+			provenChildren: make(map[byte]struct{}),
+		})
+	}
+	return nil
+}
+
+// verifyMultiProofKey returns nil iff [nodes] prove that [key] has [value].
+//
+// Assumes [nodes] passed verifyMultiProofNodes.
+func verifyMultiProofKey(nodes []ProofNode, key Key, value maybe.Maybe[[]byte], tokenSize int) error {
+	var (
+		i    int
+		node = nodes[0]
+	)
+	for {
+		if node.Key == key {
+			if !valueOrHashMatches(value, node.ValueOrHash) {
+				return ErrProofValueDoesntMatch
+			}
+			return nil
+		}
+		if !key.HasStrictPrefix(node.Key) {
+			// [key] would be between [node] and its parent, so it isn't in
+			// the trie.
+			break
+		}
+
+		// The child on the path to [key], if it exists, is the first node in
+		// its branch.
+		index := key.Token(node.Key.length, tokenSize)
+		branch := node.Key.Extend(ToToken(index, tokenSize))
+		offset, _ := slices.BinarySearchFunc(nodes[i+1:], branch, func(n ProofNode, k Key) int {
+			return n.Key.Compare(k)
+		})
+		next := i + 1 + offset
+		if next < len(nodes) && nodes[next].Key.HasPrefix(branch) {
+			i = next
+			node = nodes[next]
+			continue
+		}
+		if _, ok := node.Children[index]; ok {
+			return fmt.Errorf("%w: %x", ErrMissingProofNode, key.Bytes())
+		}
+		// [node] has no child on the path to [key], so it isn't in the trie.
+		break
+	}
+
+	if value.HasValue() {
+		return ErrProofValueDoesntMatch
+	}
+	return nil
+}
+
+// getMultiProof returns a proof of the values of [keys] in [t].
+// Returns ErrEmptyProof if [t] is empty.
+// Assumes [t] doesn't change while this function is running.
+func getMultiProof(t Trie, keys [][]byte) (*MultiProof, error) {
+	root := t.getRoot()
+	if root.IsNothing() {
+		return nil, ErrEmptyProof
+	}
+
+	proofKeys := make([]Key, len(keys))
+	for i, key := range keys {
+		proofKeys[i] = ToKey(key)
+	}
+	slices.SortFunc(proofKeys, Key.Compare)
+	proofKeys = slices.Compact(proofKeys)
+
+	var (
+		rootNode = root.Value().asProofNode()
+		nodes    = map[Key]ProofNode{
+			rootNode.Key: rootNode,
+		}
+		proof = &MultiProof{
+			Keys:   proofKeys,
+			Values: make([]maybe.Maybe[[]byte], len(proofKeys)),
+		}
+	)
+	for i, key := range proofKeys {
+		keyProof, err := getProof(t, key.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		for _, proofNode := range keyProof.Path {
+			nodes[proofNode.Key] = proofNode
+		}
+		proof.Values[i] = keyProof.Value
+	}
+
+	proof.Nodes = maps.Values(nodes)
+	slices.SortFunc(proof.Nodes, func(a, b ProofNode) int {
+		return a.Key.Compare(b.Key)
+	})
+	return proof, nil
+}
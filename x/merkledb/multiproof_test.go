@@ -0,0 +1,314 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+)
+
+func TestMultiProofEmptyTrie(t *testing.T) {
+	db, err := getBasicDB()
+	require.NoError(t, err)
+
+	_, err = db.GetMultiProof(context.Background(), [][]byte{{1}})
+	require.ErrorIs(t, err, ErrEmptyProof)
+}
+
+func TestMultiProof(t *testing.T) {
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	rand := rand.New(rand.NewSource(now)) // #nosec G404
+
+	for _, bf := range validBranchFactors {
+		require := require.New(t)
+
+		db, err := getBasicDBWithBranchFactor(bf)
+		require.NoError(err)
+
+		// Use short keys so that their paths share nodes.
+		keys := make([][]byte, 0, 256)
+		for i := 0; i < cap(keys); i++ {
+			key := make([]byte, 1+rand.Intn(3))
+			_, _ = rand.Read(key)
+			keys = append(keys, key)
+			require.NoError(db.Put(key, key))
+		}
+
+		// Prove a mix of keys that are and aren't in the trie.
+		proofKeys := make([][]byte, 0, 32)
+		for i := 0; i < cap(proofKeys)/2; i++ {
+			proofKeys = append(proofKeys, keys[rand.Intn(len(keys))])
+
+			key := make([]byte, 1+rand.Intn(4))
+			_, _ = rand.Read(key)
+			proofKeys = append(proofKeys, key)
+		}
+
+		ctx := context.Background()
+		proof, err := db.GetMultiProof(ctx, proofKeys)
+		require.NoError(err)
+
+		root, err := db.GetMerkleRoot(ctx)
+		require.NoError(err)
+		require.NoError(proof.Verify(ctx, root, db.tokenSize))
+
+		var numSingleProofNodes int
+		for i, key := range proof.Keys {
+			value, err := db.Get(key.Bytes())
+			if err == database.ErrNotFound {
+				require.True(proof.Values[i].IsNothing())
+			} else {
+				require.NoError(err)
+				require.Equal(maybe.Some(value), proof.Values[i])
+			}
+
+			singleProof, err := db.GetProof(ctx, key.Bytes())
+			require.NoError(err)
+			numSingleProofNodes += len(singleProof.Path)
+		}
+		require.Less(len(proof.Nodes), numSingleProofNodes)
+
+		proofBytes, err := proof.MarshalBinary()
+		require.NoError(err)
+
+		var parsedProof MultiProof
+		require.NoError(parsedProof.UnmarshalBinary(proofBytes))
+		require.NoError(parsedProof.Verify(ctx, root, db.tokenSize))
+
+		parsedProofBytes, err := parsedProof.MarshalBinary()
+		require.NoError(err)
+		require.Equal(proofBytes, parsedProofBytes)
+
+		// A proof generated at an old root shouldn't verify against the new
+		// root.
+		require.NoError(db.Put(proofKeys[0], []byte("updated")))
+		newRoot, err := db.GetMerkleRoot(ctx)
+		require.NoError(err)
+		err = proof.Verify(ctx, newRoot, db.tokenSize)
+		require.ErrorIs(err, ErrInvalidProof)
+	}
+}
+
+func TestMultiProofView(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	ctx := context.Background()
+	view, err := db.NewView(ctx, ViewChanges{
+		BatchOps: []database.BatchOp{
+			{Key: []byte{1}, Delete: true},
+			{Key: []byte{5}, Value: []byte{5}},
+		},
+	})
+	require.NoError(err)
+
+	proof, err := view.GetMultiProof(ctx, [][]byte{{5}, {1}, {2}, {1}})
+	require.NoError(err)
+	require.Equal([]Key{ToKey([]byte{1}), ToKey([]byte{2}), ToKey([]byte{5})}, proof.Keys)
+	require.Equal(
+		[]maybe.Maybe[[]byte]{
+			maybe.Nothing[[]byte](),
+			maybe.Some([]byte{2}),
+			maybe.Some([]byte{5}),
+		},
+		proof.Values,
+	)
+
+	root, err := view.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.NoError(proof.Verify(ctx, root, db.tokenSize))
+}
+
+func TestMultiProofVerifyBadData(t *testing.T) {
+	tests := []struct {
+		name        string
+		malform     func(proof *MultiProof)
+		expectedErr error
+	}{
+		{
+			name:        "happy path",
+			malform:     func(*MultiProof) {},
+			expectedErr: nil,
+		},
+		{
+			name: "empty",
+			malform: func(proof *MultiProof) {
+				proof.Nodes = nil
+			},
+			expectedErr: ErrEmptyProof,
+		},
+		{
+			name: "missing value",
+			malform: func(proof *MultiProof) {
+				proof.Values = proof.Values[1:]
+			},
+			expectedErr: ErrKeysValuesLengthMismatch,
+		},
+		{
+			name: "unsorted keys",
+			malform: func(proof *MultiProof) {
+				proof.Keys[0], proof.Keys[1] = proof.Keys[1], proof.Keys[0]
+				proof.Values[0], proof.Values[1] = proof.Values[1], proof.Values[0]
+			},
+			expectedErr: ErrNonIncreasingValues,
+		},
+		{
+			name: "duplicate node",
+			malform: func(proof *MultiProof) {
+				proof.Nodes = append(proof.Nodes, proof.Nodes[len(proof.Nodes)-1])
+			},
+			expectedErr: ErrNonIncreasingMultiProofNodes,
+		},
+		{
+			name: "partial byte length key with value",
+			malform: func(proof *MultiProof) {
+				proof.Nodes[0].ValueOrHash = maybe.Some([]byte{1})
+			},
+			expectedErr: ErrPartialByteLengthWithValue,
+		},
+		{
+			name: "node not connected to the root",
+			malform: func(proof *MultiProof) {
+				delete(proof.Nodes[0].Children, proof.Nodes[1].Key.Token(proof.Nodes[0].Key.length, 4))
+			},
+			expectedErr: ErrUnconnectedProofNode,
+		},
+		{
+			name: "missing node",
+			malform: func(proof *MultiProof) {
+				proof.Nodes = proof.Nodes[:len(proof.Nodes)-1]
+			},
+			expectedErr: ErrMissingProofNode,
+		},
+		{
+			name: "mismatched value",
+			malform: func(proof *MultiProof) {
+				proof.Values[0] = maybe.Some([]byte{10})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "value of excluded key",
+			malform: func(proof *MultiProof) {
+				proof.Values[len(proof.Values)-1] = maybe.Some([]byte{10})
+			},
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "modified node value",
+			malform: func(proof *MultiProof) {
+				proof.Nodes[1].ValueOrHash = maybe.Some([]byte{10})
+				proof.Values[0] = maybe.Some([]byte{10})
+			},
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name: "modified child ID",
+			malform: func(proof *MultiProof) {
+				for index := range proof.Nodes[0].Children {
+					proof.Nodes[0].Children[index] = ids.GenerateTestID()
+				}
+			},
+			expectedErr: ErrInvalidProof,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db, err := getBasicDB()
+			require.NoError(err)
+			writeBasicBatch(t, db)
+
+			ctx := context.Background()
+			proof, err := db.GetMultiProof(ctx, [][]byte{{0}, {2}, {4, 0}})
+			require.NoError(err)
+
+			tt.malform(proof)
+
+			root, err := db.GetMerkleRoot(ctx)
+			require.NoError(err)
+			err = proof.Verify(ctx, root, db.tokenSize)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestMultiProofUnmarshalBinaryInvalid(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	proof, err := db.GetMultiProof(context.Background(), [][]byte{{0}, {3}})
+	require.NoError(err)
+
+	proofBytes, err := proof.MarshalBinary()
+	require.NoError(err)
+
+	var parsedProof MultiProof
+	err = parsedProof.UnmarshalBinary(append(proofBytes, 0))
+	require.ErrorIs(err, errExtraSpace)
+
+	for i := 0; i < len(proofBytes); i++ {
+		err := parsedProof.UnmarshalBinary(proofBytes[:i])
+		require.ErrorIs(err, io.ErrUnexpectedEOF)
+	}
+}
+
+func FuzzMultiProofVerification(f *testing.F) {
+	f.Fuzz(func(
+		t *testing.T,
+		randSeed int64,
+		numKeyValues uint,
+		numProofKeys uint,
+	) {
+		rand := rand.New(rand.NewSource(randSeed)) // #nosec G404
+		require := require.New(t)
+
+		db, err := getBasicDB()
+		require.NoError(err)
+
+		insertRandomKeyValues(
+			require,
+			rand,
+			[]database.Database{db},
+			numKeyValues%256,
+			0.25,
+		)
+
+		if db.getMerkleRoot() == ids.Empty {
+			return
+		}
+
+		keys := make([][]byte, numProofKeys%32)
+		for i := range keys {
+			keys[i] = make([]byte, rand.Intn(8))
+			_, _ = rand.Read(keys[i])
+		}
+
+		ctx := context.Background()
+		proof, err := db.GetMultiProof(ctx, keys)
+		require.NoError(err)
+
+		rootID, err := db.GetMerkleRoot(ctx)
+		require.NoError(err)
+		require.NoError(proof.Verify(ctx, rootID, db.tokenSize))
+	})
+}
@@ -0,0 +1,317 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+
+	pb "github.com/MetalBlockchain/metalgo/proto/pb/sync"
+)
+
+const (
+	snapshotVersion = 0
+
+	// DefaultSnapshotChunkLength is the default maximum number of key-value
+	// pairs in each chunk of a snapshot.
+	DefaultSnapshotChunkLength = 2048
+
+	// maxSnapshotChunkSize is the maximum number of bytes in an encoded chunk.
+	// Export writes fewer key-value pairs in chunks that would be larger, and
+	// Import won't read larger chunks, so that a malformed snapshot can't
+	// cause an arbitrarily large allocation.
+	maxSnapshotChunkSize = 64 * units.MiB
+)
+
+var (
+	snapshotMagic = []byte("merkledb-snapshot")
+
+	ErrInvalidSnapshot            = errors.New("invalid snapshot")
+	ErrSnapshotRootMismatch       = errors.New("snapshot doesn't match its root")
+	ErrNonEmptyDatabase           = errors.New("database isn't empty")
+	ErrMismatchedBranchFactor     = errors.New("snapshot has a different branch factor than the database")
+	ErrSnapshotChunkTooLarge      = errors.New("snapshot chunk is too large")
+	errUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+)
+
+// SnapshotManifest describes the contents of a snapshot.
+type SnapshotManifest struct {
+	// The root of the trie contained in the snapshot.
+	RootID       ids.ID
+	BranchFactor BranchFactor
+}
+
+// Export writes the full contents of [db] when its root was [rootID] to [w].
+//
+// The snapshot consists of a manifest followed by a sequence of range proofs
+// of up to [chunkLength] key-value pairs each, covering the entire key space
+// in order. Chunks that would be larger than [maxSnapshotChunkSize] bytes
+// contain fewer key-value pairs. It can be written and read in a single pass,
+// so [w] may be a stream.
+//
+// [rootID] must be the current root of [db] or be in its history.
+// Returns [ErrSnapshotChunkTooLarge] if a single key-value pair can't be
+// written in a chunk.
+func Export(ctx context.Context, db MerkleDB, rootID ids.ID, chunkLength int, w io.Writer) error {
+	return export(ctx, db, rootID, chunkLength, maxSnapshotChunkSize, w)
+}
+
+func export(
+	ctx context.Context,
+	db MerkleDB,
+	rootID ids.ID,
+	chunkLength int,
+	maxChunkSize int,
+	w io.Writer,
+) error {
+	if chunkLength <= 0 {
+		return fmt.Errorf("%w but was %d", ErrInvalidMaxLength, chunkLength)
+	}
+
+	bw := bufio.NewWriter(w)
+	manifest := SnapshotManifest{
+		RootID:       rootID,
+		BranchFactor: tokenSizeToBranchFactor[db.getTokenSize()],
+	}
+	if err := writeSnapshotManifest(bw, manifest); err != nil {
+		return err
+	}
+
+	var (
+		start = maybe.Nothing[[]byte]()
+		// The number of key-value pairs requested for the next chunk.
+		length = chunkLength
+	)
+	for rootID != ids.Empty {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		proof, err := db.GetRangeProofAtRoot(ctx, rootID, start, maybe.Nothing[[]byte](), length)
+		if err != nil {
+			return err
+		}
+		if len(proof.KeyValues) == 0 {
+			// Only possible if the previous chunk ended with the last key.
+			break
+		}
+
+		chunkBytes, err := proto.Marshal(proof.ToProto())
+		if err != nil {
+			return err
+		}
+		if len(chunkBytes) > maxChunkSize {
+			if len(proof.KeyValues) == 1 {
+				return fmt.Errorf("%w: chunk of %d bytes exceeds maximum of %d", ErrSnapshotChunkTooLarge, len(chunkBytes), maxChunkSize)
+			}
+			// Retry with fewer key-value pairs in the chunk.
+			length = len(proof.KeyValues) / 2
+			continue
+		}
+		if err := writeSnapshotChunk(bw, chunkBytes); err != nil {
+			return err
+		}
+
+		if len(proof.KeyValues) < length {
+			break
+		}
+		// The smallest key greater than the last key in this chunk.
+		lastKey := proof.KeyValues[len(proof.KeyValues)-1].Key
+		start = maybe.Some(append(slices.Clone(lastKey), 0))
+		length = chunkLength
+	}
+
+	// A chunk of length 0 marks the end of the snapshot.
+	if err := writeSnapshotChunk(bw, nil); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Import reads a snapshot written by Export from [r] into [db], which must be
+// empty, and returns its manifest.
+//
+// Each chunk is verified against the root in the manifest before it's
+// written. Once the snapshot has been read, the root of [db] is compared to
+// the root in the manifest. If the snapshot is invalid, [db] is cleared and an
+// error is returned, so that [db] never contains a partial or incorrect state
+// after Import returns. If the node stops during the import, [db] is cleared
+// when it's next opened.
+func Import(ctx context.Context, db MerkleDB, r io.Reader) (SnapshotManifest, error) {
+	root, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return SnapshotManifest{}, err
+	}
+	if root != ids.Empty {
+		return SnapshotManifest{}, ErrNonEmptyDatabase
+	}
+
+	br := bufio.NewReader(r)
+	manifest, err := readSnapshotManifest(br)
+	if err != nil {
+		return SnapshotManifest{}, err
+	}
+	tokenSize := db.getTokenSize()
+	if manifest.BranchFactor != tokenSizeToBranchFactor[tokenSize] {
+		return SnapshotManifest{}, fmt.Errorf(
+			"%w: snapshot has %d, database has %d",
+			ErrMismatchedBranchFactor,
+			manifest.BranchFactor,
+			tokenSizeToBranchFactor[tokenSize],
+		)
+	}
+
+	if err := db.setImporting(true); err != nil {
+		return SnapshotManifest{}, err
+	}
+	if err := importSnapshotChunks(ctx, db, manifest.RootID, tokenSize, br); err != nil {
+		// Don't leave a partially imported trie behind.
+		if clearErr := db.Clear(); clearErr != nil {
+			return SnapshotManifest{}, fmt.Errorf("%w: failed to clear database: %w", err, clearErr)
+		}
+		if markerErr := db.setImporting(false); markerErr != nil {
+			return SnapshotManifest{}, fmt.Errorf("%w: failed to mark import as finished: %w", err, markerErr)
+		}
+		return SnapshotManifest{}, err
+	}
+	return manifest, db.setImporting(false)
+}
+
+func importSnapshotChunks(
+	ctx context.Context,
+	db MerkleDB,
+	rootID ids.ID,
+	tokenSize int,
+	r *bufio.Reader,
+) error {
+	start := maybe.Nothing[[]byte]()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		chunkBytes, err := readSnapshotChunk(r)
+		if err != nil {
+			return err
+		}
+		if len(chunkBytes) == 0 {
+			break
+		}
+
+		var proofProto pb.RangeProof
+		if err := proto.Unmarshal(chunkBytes, &proofProto); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		var proof RangeProof
+		if err := proof.UnmarshalProto(&proofProto); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+		if len(proof.KeyValues) == 0 {
+			return fmt.Errorf("%w: empty chunk", ErrInvalidSnapshot)
+		}
+		if err := proof.Verify(ctx, start, maybe.Nothing[[]byte](), rootID, tokenSize); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+		}
+
+		lastKey := proof.KeyValues[len(proof.KeyValues)-1].Key
+		if err := db.CommitRangeProof(ctx, start, maybe.Some(lastKey), &proof); err != nil {
+			return err
+		}
+		start = maybe.Some(append(slices.Clone(lastKey), 0))
+	}
+
+	if _, err := r.ReadByte(); err != io.EOF {
+		return fmt.Errorf("%w: trailing data", ErrInvalidSnapshot)
+	}
+
+	// The chunks only prove the key-value pairs they contain, so the final
+	// root ensures that no key-value pairs were omitted.
+	gotRootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if gotRootID != rootID {
+		return fmt.Errorf("%w: imported root %s, expected %s", ErrSnapshotRootMismatch, gotRootID, rootID)
+	}
+	return nil
+}
+
+func writeSnapshotManifest(w io.Writer, manifest SnapshotManifest) error {
+	b := make([]byte, 0, len(snapshotMagic)+2*binary.MaxVarintLen64+ids.IDLen)
+	b = append(b, snapshotMagic...)
+	b = binary.AppendUvarint(b, snapshotVersion)
+	b = binary.AppendUvarint(b, uint64(manifest.BranchFactor))
+	b = append(b, manifest.RootID[:]...)
+	_, err := w.Write(b)
+	return err
+}
+
+func readSnapshotManifest(r *bufio.Reader) (SnapshotManifest, error) {
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return SnapshotManifest{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if !bytes.Equal(magic, snapshotMagic) {
+		return SnapshotManifest{}, fmt.Errorf("%w: unexpected header", ErrInvalidSnapshot)
+	}
+
+	version, err := binary.ReadUvarint(r)
+	if err != nil {
+		return SnapshotManifest{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if version != snapshotVersion {
+		return SnapshotManifest{}, fmt.Errorf("%w: %d", errUnsupportedSnapshotVersion, version)
+	}
+
+	branchFactor, err := binary.ReadUvarint(r)
+	if err != nil {
+		return SnapshotManifest{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	manifest := SnapshotManifest{
+		BranchFactor: BranchFactor(branchFactor),
+	}
+	if err := manifest.BranchFactor.Valid(); err != nil {
+		return SnapshotManifest{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if _, err := io.ReadFull(r, manifest.RootID[:]); err != nil {
+		return SnapshotManifest{}, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	return manifest, nil
+}
+
+func writeSnapshotChunk(w io.Writer, chunkBytes []byte) error {
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(chunkBytes)))); err != nil {
+		return err
+	}
+	_, err := w.Write(chunkBytes)
+	return err
+}
+
+func readSnapshotChunk(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	if size > maxSnapshotChunkSize {
+		return nil, fmt.Errorf("%w: chunk of %d bytes exceeds maximum of %d", ErrInvalidSnapshot, size, maxSnapshotChunkSize)
+	}
+
+	chunkBytes := make([]byte, size)
+	if _, err := io.ReadFull(r, chunkBytes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+	return chunkBytes, nil
+}
@@ -0,0 +1,349 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	for _, bf := range validBranchFactors {
+		for _, numKeyValues := range []uint{0, 1, 16, 500} {
+			t.Run(fmt.Sprintf("branch_factor_%d_key_values_%d", bf, numKeyValues), func(t *testing.T) {
+				require := require.New(t)
+
+				db, err := getBasicDBWithBranchFactor(bf)
+				require.NoError(err)
+
+				r := rand.New(rand.NewSource(int64(numKeyValues))) // #nosec G404
+				insertRandomKeyValues(require, r, []database.Database{db}, numKeyValues, 0)
+
+				ctx := context.Background()
+				rootID, err := db.GetMerkleRoot(ctx)
+				require.NoError(err)
+
+				var snapshot bytes.Buffer
+				require.NoError(Export(ctx, db, rootID, 16, &snapshot))
+
+				importedDB, err := getBasicDBWithBranchFactor(bf)
+				require.NoError(err)
+
+				manifest, err := Import(ctx, importedDB, &snapshot)
+				require.NoError(err)
+				require.Equal(SnapshotManifest{
+					RootID:       rootID,
+					BranchFactor: bf,
+				}, manifest)
+
+				importedRootID, err := importedDB.GetMerkleRoot(ctx)
+				require.NoError(err)
+				require.Equal(rootID, importedRootID)
+				requireEqualIteration(t, db, importedDB)
+			})
+		}
+	}
+}
+
+func TestSnapshotExportHistoricalRoot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	insertRandomKeyValues(require, r, []database.Database{db}, 100, 0)
+
+	ctx := context.Background()
+	oldRootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	expectedDB, err := getBasicDB()
+	require.NoError(err)
+	it := db.NewIterator()
+	for it.Next() {
+		require.NoError(expectedDB.Put(it.Key(), it.Value()))
+	}
+	require.NoError(it.Error())
+	it.Release()
+
+	insertRandomKeyValues(require, r, []database.Database{db}, 100, 0.5)
+
+	var snapshot bytes.Buffer
+	require.NoError(Export(ctx, db, oldRootID, 7, &snapshot))
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	_, err = Import(ctx, importedDB, &snapshot)
+	require.NoError(err)
+	requireEqualIteration(t, expectedDB, importedDB)
+}
+
+func TestSnapshotExportMaxChunkSize(t *testing.T) {
+	require := require.New(t)
+
+	snapshot, _, chunks := getTestSnapshot(t)
+	var maxChunkSize int
+	for _, chunk := range chunks {
+		maxChunkSize = max(maxChunkSize, len(chunk))
+	}
+
+	importedDB, err := getBasicDB()
+	require.NoError(err)
+	ctx := context.Background()
+	manifest, err := Import(ctx, importedDB, bytes.NewReader(snapshot))
+	require.NoError(err)
+
+	// Chunks that would be too large contain fewer key-value pairs.
+	var smallSnapshot bytes.Buffer
+	require.NoError(export(ctx, importedDB, manifest.RootID, 16, maxChunkSize/2, &smallSnapshot))
+
+	smallSnapshotReader := bufio.NewReader(&smallSnapshot)
+	_, err = readSnapshotManifest(smallSnapshotReader)
+	require.NoError(err)
+	var numChunks int
+	for {
+		chunk, err := readSnapshotChunk(smallSnapshotReader)
+		require.NoError(err)
+		if len(chunk) == 0 {
+			break
+		}
+		require.LessOrEqual(len(chunk), maxChunkSize/2)
+		numChunks++
+	}
+	require.Greater(numChunks, len(chunks))
+
+	// A single key-value pair can't be split.
+	err = export(ctx, importedDB, manifest.RootID, 16, 1, io.Discard)
+	require.ErrorIs(err, ErrSnapshotChunkTooLarge)
+}
+
+func TestSnapshotImportInterrupted(t *testing.T) {
+	require := require.New(t)
+
+	snapshot, manifest, chunks := getTestSnapshot(t)
+
+	baseDB := memdb.New()
+	db, err := newDatabase(context.Background(), baseDB, newDefaultConfig(), &mockMetrics{})
+	require.NoError(err)
+
+	// Import only the first chunk, as if the node stopped during the import.
+	ctx := context.Background()
+	require.NoError(db.setImporting(true))
+	err = importSnapshotChunks(
+		ctx,
+		db,
+		manifest.RootID,
+		db.getTokenSize(),
+		bufio.NewReader(newTestSnapshotChunks(t, chunks[0])),
+	)
+	require.ErrorIs(err, ErrSnapshotRootMismatch)
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.NotEqual(ids.Empty, rootID)
+
+	// The partially imported trie is cleared when the database is reopened.
+	db, err = newDatabase(ctx, baseDB, newDefaultConfig(), &mockMetrics{})
+	require.NoError(err)
+	rootID, err = db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(ids.Empty, rootID)
+
+	_, err = Import(ctx, db, bytes.NewReader(snapshot))
+	require.NoError(err)
+	rootID, err = db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(manifest.RootID, rootID)
+
+	// A finished import isn't cleared.
+	db, err = newDatabase(ctx, baseDB, newDefaultConfig(), &mockMetrics{})
+	require.NoError(err)
+	rootID, err = db.GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(manifest.RootID, rootID)
+}
+
+func TestSnapshotImportInvalid(t *testing.T) {
+	snapshot, manifest, chunks := getTestSnapshot(t)
+	require.Greater(t, len(chunks), 2)
+
+	tamperedChunk := bytes.Clone(chunks[1])
+	tamperedChunk[len(tamperedChunk)-1]++
+
+	wrongRootManifest := manifest
+	wrongRootManifest.RootID = ids.GenerateTestID()
+
+	wrongBranchFactorManifest := manifest
+	wrongBranchFactorManifest.BranchFactor = BranchFactor2
+
+	tests := []struct {
+		name        string
+		snapshot    []byte
+		expectedErr error
+	}{
+		{
+			name:        "truncated",
+			snapshot:    snapshot[:len(snapshot)-1],
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name:        "trailing data",
+			snapshot:    append(bytes.Clone(snapshot), 0),
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name:        "invalid header",
+			snapshot:    []byte("not a snapshot"),
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name:        "missing last chunk",
+			snapshot:    newTestSnapshot(t, manifest, chunks[:len(chunks)-1]...).Bytes(),
+			expectedErr: ErrSnapshotRootMismatch,
+		},
+		{
+			name:        "missing middle chunk",
+			snapshot:    newTestSnapshot(t, manifest, append([][]byte{chunks[0]}, chunks[2:]...)...).Bytes(),
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name:        "tampered chunk",
+			snapshot:    newTestSnapshot(t, manifest, append([][]byte{chunks[0], tamperedChunk}, chunks[2:]...)...).Bytes(),
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name:        "wrong root",
+			snapshot:    newTestSnapshot(t, wrongRootManifest, chunks...).Bytes(),
+			expectedErr: ErrInvalidSnapshot,
+		},
+		{
+			name:        "wrong branch factor",
+			snapshot:    newTestSnapshot(t, wrongBranchFactorManifest, chunks...).Bytes(),
+			expectedErr: ErrMismatchedBranchFactor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			importedDB, err := getBasicDB()
+			require.NoError(err)
+
+			ctx := context.Background()
+			_, err = Import(ctx, importedDB, bytes.NewReader(tt.snapshot))
+			require.ErrorIs(err, tt.expectedErr)
+
+			// Nothing should be left behind by a failed import.
+			importedRootID, err := importedDB.GetMerkleRoot(ctx)
+			require.NoError(err)
+			require.Equal(ids.Empty, importedRootID)
+		})
+	}
+}
+
+func TestSnapshotImportNonEmptyDatabase(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	writeBasicBatch(t, db)
+
+	ctx := context.Background()
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	var snapshot bytes.Buffer
+	require.NoError(Export(ctx, db, rootID, DefaultSnapshotChunkLength, &snapshot))
+
+	_, err = Import(ctx, db, &snapshot)
+	require.ErrorIs(err, ErrNonEmptyDatabase)
+}
+
+// requireEqualIteration requires that [expected] and [actual] contain the
+// same key-value pairs.
+func requireEqualIteration(t *testing.T, expected, actual database.Iteratee) {
+	require := require.New(t)
+
+	expectedIt := expected.NewIterator()
+	defer expectedIt.Release()
+	actualIt := actual.NewIterator()
+	defer actualIt.Release()
+
+	for expectedIt.Next() {
+		require.True(actualIt.Next())
+		require.Equal(expectedIt.Key(), actualIt.Key())
+		require.Equal(expectedIt.Value(), actualIt.Value())
+	}
+	require.False(actualIt.Next())
+	require.NoError(expectedIt.Error())
+	require.NoError(actualIt.Error())
+}
+
+// getTestSnapshot returns a snapshot of a database with random contents, along
+// with its manifest and the chunks it contains.
+func getTestSnapshot(t *testing.T) ([]byte, SnapshotManifest, [][]byte) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	insertRandomKeyValues(require, r, []database.Database{db}, 100, 0)
+
+	ctx := context.Background()
+	rootID, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	var snapshot bytes.Buffer
+	require.NoError(Export(ctx, db, rootID, 16, &snapshot))
+
+	snapshotReader := bufio.NewReader(bytes.NewReader(snapshot.Bytes()))
+	manifest, err := readSnapshotManifest(snapshotReader)
+	require.NoError(err)
+
+	var chunks [][]byte
+	for {
+		chunk, err := readSnapshotChunk(snapshotReader)
+		require.NoError(err)
+		if len(chunk) == 0 {
+			break
+		}
+		chunks = append(chunks, chunk)
+	}
+	return snapshot.Bytes(), manifest, chunks
+}
+
+// newTestSnapshot returns a snapshot made of [manifest] and [chunks].
+func newTestSnapshot(t *testing.T, manifest SnapshotManifest, chunks ...[]byte) *bytes.Buffer {
+	require := require.New(t)
+
+	var snapshot bytes.Buffer
+	require.NoError(writeSnapshotManifest(&snapshot, manifest))
+	_, err := newTestSnapshotChunks(t, chunks...).WriteTo(&snapshot)
+	require.NoError(err)
+	return &snapshot
+}
+
+// newTestSnapshotChunks returns [chunks] followed by the end of a snapshot.
+func newTestSnapshotChunks(t *testing.T, chunks ...[]byte) *bytes.Buffer {
+	require := require.New(t)
+
+	var snapshot bytes.Buffer
+	for _, chunk := range chunks {
+		require.NoError(writeSnapshotChunk(&snapshot, chunk))
+	}
+	require.NoError(writeSnapshotChunk(&snapshot, nil))
+	return &snapshot
+}
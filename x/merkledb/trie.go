@@ -35,6 +35,14 @@ type ProofGetter interface {
 	GetProof(ctx context.Context, keyBytes []byte) (*Proof, error)
 }
 
+type MultiProofGetter interface {
+	// GetMultiProof generates a single proof of the values associated with
+	// each of [keys], or of their absence from the trie. Nodes shared by the
+	// paths of multiple keys are only included once.
+	// Returns ErrEmptyProof if the trie is empty.
+	GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error)
+}
+
 type trieInternals interface {
 	// get the value associated with the key in path form
 	// database.ErrNotFound if the key is not present
@@ -59,6 +67,7 @@ type Trie interface {
 	trieInternals
 	MerkleRootGetter
 	ProofGetter
+	MultiProofGetter
 	database.Iteratee
 
 	// GetValue gets the value associated with the specified key
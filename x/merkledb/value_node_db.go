@@ -6,11 +6,18 @@ package merkledb
 import (
 	"sync"
 
+	"golang.org/x/exp/maps"
+
 	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/utils"
 )
 
+// minNodesPerSerializer is the minimum number of nodes serialized by each
+// goroutine in [valueNodeBatch.Write], so that small batches aren't slowed
+// down by the overhead of spawning goroutines.
+const minNodesPerSerializer = 64
+
 var _ database.Iterator = (*iterator)(nil)
 
 type valueNodeDB struct {
@@ -26,6 +33,10 @@ type valueNodeDB struct {
 	nodeCache cache.Cacher[Key, *node]
 	metrics   merkleMetrics
 
+	// The maximum number of goroutines used to serialize nodes when writing
+	// a batch.
+	serializeConcurrency int
+
 	closed utils.Atomic[bool]
 }
 
@@ -33,13 +44,15 @@ func newValueNodeDB(
 	db database.Database,
 	bufferPool *sync.Pool,
 	metrics merkleMetrics,
-	cacheSize int,
+	nodeCache cache.Cacher[Key, *node],
+	serializeConcurrency int,
 ) *valueNodeDB {
 	return &valueNodeDB{
-		metrics:    metrics,
-		baseDB:     db,
-		bufferPool: bufferPool,
-		nodeCache:  cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		metrics:              metrics,
+		baseDB:               db,
+		bufferPool:           bufferPool,
+		nodeCache:            nodeCache,
+		serializeConcurrency: serializeConcurrency,
 	}
 }
 
@@ -107,25 +120,77 @@ func (b *valueNodeBatch) Delete(key Key) {
 	b.ops[key] = nil
 }
 
+// A node serialized by [valueNodeBatch.serialize].
+type serializedNode struct {
+	key Key
+	// Nil if [key] is being deleted.
+	node  *node
+	dbKey []byte
+	bytes []byte
+}
+
 // Write flushes any accumulated data to the underlying database.
+//
+// Nodes are serialized by up to [b.db.serializeConcurrency] goroutines while
+// the already serialized nodes are added to the underlying batch.
 func (b *valueNodeBatch) Write() error {
-	dbBatch := b.db.baseDB.NewBatch()
-	for key, n := range b.ops {
-		b.db.metrics.DatabaseNodeWrite()
-		b.db.nodeCache.Put(key, n)
-		prefixedKey := addPrefixToKey(b.db.bufferPool, valueNodePrefix, key.Bytes())
-		if n == nil {
-			if err := dbBatch.Delete(prefixedKey); err != nil {
-				return err
+	var (
+		keys           = maps.Keys(b.ops)
+		numSerializers = max(min(b.db.serializeConcurrency, len(keys)/minNodesPerSerializer), 1)
+		chunkSize      = (len(keys) + numSerializers - 1) / numSerializers
+		numChunks      int
+		serialized     = make(chan []serializedNode, numSerializers)
+	)
+	for start := 0; start < len(keys); start += chunkSize {
+		chunk := keys[start:min(start+chunkSize, len(keys))]
+		numChunks++
+		go func() {
+			serialized <- b.serialize(chunk)
+		}()
+	}
+
+	var (
+		dbBatch = b.db.baseDB.NewBatch()
+		err     error
+	)
+	for ; numChunks > 0; numChunks-- {
+		for _, n := range <-serialized {
+			if err == nil {
+				err = b.addToBatch(dbBatch, n)
 			}
-		} else if err := dbBatch.Put(prefixedKey, n.bytes()); err != nil {
-			return err
+			b.db.bufferPool.Put(n.dbKey)
 		}
+	}
+	if err != nil {
+		return err
+	}
+	return dbBatch.Write()
+}
 
-		b.db.bufferPool.Put(prefixedKey)
+// serialize returns the database keys and values of the nodes in [keys].
+func (b *valueNodeBatch) serialize(keys []Key) []serializedNode {
+	serialized := make([]serializedNode, len(keys))
+	for i, key := range keys {
+		n := b.ops[key]
+		serialized[i] = serializedNode{
+			key:   key,
+			node:  n,
+			dbKey: addPrefixToKey(b.db.bufferPool, valueNodePrefix, key.Bytes()),
+		}
+		if n != nil {
+			serialized[i].bytes = n.bytes()
+		}
 	}
+	return serialized
+}
 
-	return dbBatch.Write()
+func (b *valueNodeBatch) addToBatch(dbBatch database.Batch, n serializedNode) error {
+	b.db.metrics.DatabaseNodeWrite()
+	b.db.nodeCache.Put(n.key, n.node)
+	if n.node == nil {
+		return dbBatch.Delete(n.dbKey)
+	}
+	return dbBatch.Put(n.dbKey, n.bytes)
 }
 
 type iterator struct {
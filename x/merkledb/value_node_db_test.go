@@ -9,9 +9,11 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/utils/units"
 )
 
 // Test putting, modifying, deleting, and getting key-node pairs.
@@ -27,7 +29,8 @@ func TestValueNodeDB(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		1,
 	)
 
 	// Getting a key that doesn't exist should return an error.
@@ -122,7 +125,8 @@ func TestValueNodeDBIterator(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		1,
 	)
 
 	// Put key-node pairs.
@@ -229,7 +233,8 @@ func TestValueNodeDBClear(t *testing.T) {
 			New: func() interface{} { return make([]byte, 0) },
 		},
 		&mockMetrics{},
-		cacheSize,
+		cache.NewSizedLRU(cacheSize, cacheEntrySize),
+		1,
 	)
 
 	batch := db.NewBatch()
@@ -249,3 +254,50 @@ func TestValueNodeDBClear(t *testing.T) {
 	defer iter.Release()
 	require.False(iter.Next())
 }
+
+func TestValueNodeBatchConcurrentWrite(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newValueNodeDB(
+		baseDB,
+		&sync.Pool{
+			New: func() interface{} { return make([]byte, 0) },
+		},
+		&mockMetrics{},
+		cache.NewSizedLRU(units.MiB, cacheEntrySize),
+		4,
+	)
+
+	// Write enough nodes that they're serialized by multiple goroutines.
+	numNodes := 10 * minNodesPerSerializer
+	batch := db.NewBatch()
+	for i := 0; i < numNodes; i++ {
+		key := ToKey([]byte{byte(i >> 8), byte(i)})
+		n := newNode(key)
+		n.setValue(maybe.Some([]byte{byte(i)}))
+		batch.Put(key, n)
+	}
+	require.NoError(batch.Write())
+
+	for i := 0; i < numNodes; i++ {
+		key := ToKey([]byte{byte(i >> 8), byte(i)})
+		nodeBytes, err := baseDB.Get(addPrefixToKey(db.bufferPool, valueNodePrefix, key.Bytes()))
+		require.NoError(err)
+
+		n, err := parseNode(key, nodeBytes)
+		require.NoError(err)
+		require.Equal(maybe.Some([]byte{byte(i)}), n.value)
+	}
+
+	// Deletions are serialized concurrently as well.
+	batch = db.NewBatch()
+	for i := 0; i < numNodes; i++ {
+		batch.Delete(ToKey([]byte{byte(i >> 8), byte(i)}))
+	}
+	require.NoError(batch.Write())
+
+	iter := baseDB.NewIteratorWithPrefix(valueNodePrefix)
+	defer iter.Release()
+	require.False(iter.Next())
+}
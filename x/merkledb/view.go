@@ -28,6 +28,7 @@ var (
 	_ View = (*view)(nil)
 
 	ErrCommitted                  = errors.New("view has been committed")
+	ErrReadOnly                   = errors.New("view is read-only")
 	ErrInvalid                    = errors.New("the trie this view was based on has changed, rendering this view invalid")
 	ErrPartialByteLengthWithValue = errors.New(
 		"the underlying db only supports whole number of byte keys, so cannot record changes with partial byte lengths",
@@ -45,6 +46,9 @@ type view struct {
 	committed  bool
 	commitLock sync.RWMutex
 
+	// If true, this view is a historical view that can't be committed.
+	readOnly bool
+
 	// tracking bool to enforce that no changes are made to the trie after the nodes have been calculated
 	nodesAlreadyCalculated utils.Atomic[bool]
 
@@ -321,6 +325,25 @@ func (v *view) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return result, nil
 }
 
+// GetMultiProof returns a proof that each of [keys] is in or not in trie [t].
+func (v *view) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	_, span := v.db.infoTracer.Start(ctx, "MerkleDB.view.GetMultiProof")
+	defer span.End()
+
+	if err := v.calculateNodeIDs(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := getMultiProof(v, keys)
+	if err != nil {
+		return nil, err
+	}
+	if v.isInvalid() {
+		return nil, ErrInvalid
+	}
+	return result, nil
+}
+
 // GetRangeProof returns a range proof for (at least part of) the key range [start, end].
 // The returned proof's [KeyValues] has at most [maxLength] values.
 // [maxLength] must be > 0.
@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -66,12 +65,16 @@ type Client interface {
 }
 
 type client struct {
-	networkClient    NetworkClient
-	stateSyncNodes   []ids.NodeID
-	stateSyncNodeIdx uint32
-	log              logging.Logger
-	metrics          SyncMetrics
-	tokenSize        int
+	networkClient  NetworkClient
+	stateSyncNodes []ids.NodeID
+	log            logging.Logger
+	metrics        SyncMetrics
+	tokenSize      int
+	// Scores the peers that requests are sent to, which is used to tune the
+	// key limit of requests to each peer.
+	// When [stateSyncNodes] is non-empty, it's also used to select the peer.
+	// Otherwise, peers are selected by [networkClient].
+	scorer *peerScorer
 }
 
 type ClientConfig struct {
@@ -92,6 +95,7 @@ func NewClient(config *ClientConfig) (Client, error) {
 		log:            config.Log,
 		metrics:        config.Metrics,
 		tokenSize:      merkledb.BranchFactorToTokenSize[config.BranchFactor],
+		scorer:         newPeerScorer(),
 	}, nil
 }
 
@@ -103,7 +107,17 @@ func (c *client) GetChangeProof(
 	req *pb.SyncGetChangeProofRequest,
 	db DB,
 ) (*merkledb.ChangeOrRangeProof, error) {
-	parseFn := func(ctx context.Context, responseBytes []byte) (*merkledb.ChangeOrRangeProof, error) {
+	marshalFn := func(keyLimit uint32) ([]byte, error) {
+		peerReq := proto.Clone(req).(*pb.SyncGetChangeProofRequest)
+		peerReq.KeyLimit = keyLimit
+		return proto.Marshal(&pb.Request{
+			Message: &pb.Request_ChangeProofRequest{
+				ChangeProofRequest: peerReq,
+			},
+		})
+	}
+
+	parseFn := func(ctx context.Context, keyLimit uint32, responseBytes []byte) (*merkledb.ChangeOrRangeProof, error) {
 		if len(responseBytes) > int(req.BytesLimit) {
 			return nil, fmt.Errorf("%w: (%d) > %d)", errTooManyBytes, len(responseBytes), req.BytesLimit)
 		}
@@ -126,10 +140,10 @@ func (c *client) GetChangeProof(
 
 			// Ensure the response does not contain more than the requested number of leaves
 			// and the start and end roots match the requested roots.
-			if len(changeProof.KeyChanges) > int(req.KeyLimit) {
+			if len(changeProof.KeyChanges) > int(keyLimit) {
 				return nil, fmt.Errorf(
 					"%w: (%d) > %d)",
-					errTooManyKeys, len(changeProof.KeyChanges), req.KeyLimit,
+					errTooManyKeys, len(changeProof.KeyChanges), keyLimit,
 				)
 			}
 
@@ -163,7 +177,7 @@ func (c *client) GetChangeProof(
 			err := verifyRangeProof(
 				ctx,
 				&rangeProof,
-				int(req.KeyLimit),
+				int(keyLimit),
 				startKey,
 				endKey,
 				req.EndRootHash,
@@ -184,15 +198,7 @@ func (c *client) GetChangeProof(
 		}
 	}
 
-	reqBytes, err := proto.Marshal(&pb.Request{
-		Message: &pb.Request_ChangeProofRequest{
-			ChangeProofRequest: req,
-		},
-	})
-	if err != nil {
-		return nil, err
-	}
-	return getAndParse(ctx, c, reqBytes, parseFn)
+	return getAndParse(ctx, c, req.KeyLimit, marshalFn, parseFn)
 }
 
 // Verify [rangeProof] is a valid range proof for keys in [start, end] for
@@ -239,7 +245,17 @@ func (c *client) GetRangeProof(
 	ctx context.Context,
 	req *pb.SyncGetRangeProofRequest,
 ) (*merkledb.RangeProof, error) {
-	parseFn := func(ctx context.Context, responseBytes []byte) (*merkledb.RangeProof, error) {
+	marshalFn := func(keyLimit uint32) ([]byte, error) {
+		peerReq := proto.Clone(req).(*pb.SyncGetRangeProofRequest)
+		peerReq.KeyLimit = keyLimit
+		return proto.Marshal(&pb.Request{
+			Message: &pb.Request_RangeProofRequest{
+				RangeProofRequest: peerReq,
+			},
+		})
+	}
+
+	parseFn := func(ctx context.Context, keyLimit uint32, responseBytes []byte) (*merkledb.RangeProof, error) {
 		if len(responseBytes) > int(req.BytesLimit) {
 			return nil, fmt.Errorf(
 				"%w: (%d) > %d)",
@@ -260,7 +276,7 @@ func (c *client) GetRangeProof(
 		if err := verifyRangeProof(
 			ctx,
 			&rangeProof,
-			int(req.KeyLimit),
+			int(keyLimit),
 			maybeBytesToMaybe(req.StartKey),
 			maybeBytesToMaybe(req.EndKey),
 			req.RootHash,
@@ -271,30 +287,25 @@ func (c *client) GetRangeProof(
 		return &rangeProof, nil
 	}
 
-	reqBytes, err := proto.Marshal(&pb.Request{
-		Message: &pb.Request_RangeProofRequest{
-			RangeProofRequest: req,
-		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return getAndParse(ctx, c, reqBytes, parseFn)
+	return getAndParse(ctx, c, req.KeyLimit, marshalFn, parseFn)
 }
 
-// getAndParse uses [client] to send [request] to an arbitrary peer.
+// getAndParse uses [client] to send a request to a peer.
 // Returns the response to the request.
-// [parseFn] parses the raw response.
+// [marshalFn] returns the request to send with the given key limit, which is
+// at most [maxKeyLimit] and is tuned to the peer's performance.
+// [parseFn] parses the raw response to a request with the given key limit.
 // If the request is unsuccessful or the response can't be parsed,
 // retries the request to a different peer until [ctx] expires.
+// Peers that send responses that can't be parsed are penalized.
 // Returns [errAppSendFailed] if we fail to send an AppRequest/AppResponse.
 // This should be treated as a fatal error.
 func getAndParse[T any](
 	ctx context.Context,
 	client *client,
-	request []byte,
-	parseFn func(context.Context, []byte) (*T, error),
+	maxKeyLimit uint32,
+	marshalFn func(keyLimit uint32) ([]byte, error),
+	parseFn func(ctx context.Context, keyLimit uint32, responseBytes []byte) (*T, error),
 ) (*T, error) {
 	var (
 		lastErr  error
@@ -302,11 +313,16 @@ func getAndParse[T any](
 	)
 	// Loop until the context is cancelled or we get a valid response.
 	for attempt := 1; ; attempt++ {
-		nodeID, responseBytes, err := client.get(ctx, request)
+		nodeID, keyLimit, responseBytes, err := client.get(ctx, maxKeyLimit, marshalFn)
 		if err == nil {
-			if response, err = parseFn(ctx, responseBytes); err == nil {
+			if response, err = parseFn(ctx, keyLimit, responseBytes); err == nil {
 				return response, nil
 			}
+			// Don't blame the peer if verification was interrupted by [ctx].
+			if ctx.Err() == nil {
+				client.scorer.registerInvalidResponse(nodeID)
+				client.networkClient.RegisterInvalidResponse(nodeID)
+			}
 		}
 
 		if errors.Is(err, errAppSendFailed) {
@@ -344,37 +360,59 @@ func getAndParse[T any](
 	}
 }
 
-// get sends [request] to an arbitrary peer and blocks
-// until the node receives a response, failure notification
-// or [ctx] is canceled.
-// Returns the peer's NodeID and response.
+// get sends a request to a peer and blocks until the node receives a
+// response, failure notification or [ctx] is canceled.
+// The request is created by calling [marshalFn] with the key limit to use,
+// which is at most [maxKeyLimit].
+// Returns the peer's NodeID, the key limit of the request and the response.
 // Returns [errAppSendFailed] if we failed to send an AppRequest/AppResponse.
 // This should be treated as fatal.
 // It's safe to call this method multiple times concurrently.
-func (c *client) get(ctx context.Context, request []byte) (ids.NodeID, []byte, error) {
+func (c *client) get(
+	ctx context.Context,
+	maxKeyLimit uint32,
+	marshalFn func(keyLimit uint32) ([]byte, error),
+) (ids.NodeID, uint32, []byte, error) {
 	var (
-		response []byte
-		nodeID   ids.NodeID
-		err      error
+		nodeID    ids.NodeID
+		keyLimit  uint32
+		response  []byte
+		startTime time.Time
+		err       error
 	)
-
-	c.metrics.RequestMade()
+	// The request is created once the peer is selected, so that its key limit
+	// is tuned to the peer.
+	requestFn := func(selectedNodeID ids.NodeID) ([]byte, error) {
+		keyLimit = c.scorer.keyLimit(selectedNodeID, maxKeyLimit)
+		startTime = time.Now()
+		return marshalFn(keyLimit)
+	}
 
 	if len(c.stateSyncNodes) == 0 {
-		nodeID, response, err = c.networkClient.RequestAny(ctx, request)
+		c.metrics.RequestMade()
+		nodeID, response, err = c.networkClient.RequestAny(ctx, requestFn)
 	} else {
-		// Get the next nodeID to query using the [nodeIdx] offset.
-		// If we're out of nodes, loop back to 0.
-		// We do this try to query a different node each time if possible.
-		nodeIdx := atomic.AddUint32(&c.stateSyncNodeIdx, 1)
-		nodeID = c.stateSyncNodes[nodeIdx%uint32(len(c.stateSyncNodes))]
+		nodeID, _ = c.scorer.selectPeer(c.stateSyncNodes)
+		var request []byte
+		request, err = requestFn(nodeID)
+		if err != nil {
+			return nodeID, keyLimit, nil, err
+		}
+
+		c.metrics.RequestMade()
 		response, err = c.networkClient.Request(ctx, nodeID, request)
 	}
 	if err != nil {
 		c.metrics.RequestFailed()
-		return nodeID, response, err
+		// Don't blame the peer if the request was interrupted by [ctx] or
+		// wasn't sent to a peer.
+		if ctx.Err() == nil && nodeID != ids.EmptyNodeID {
+			c.scorer.registerFailure(nodeID)
+		}
+		return nodeID, keyLimit, response, err
 	}
 
 	c.metrics.RequestSucceeded()
-	return nodeID, response, nil
+	c.scorer.registerResponse(nodeID, len(response), time.Since(startTime))
+	return nodeID, keyLimit, response, nil
 }
@@ -97,9 +97,12 @@ func sendRangeProofRequest(
 
 	networkClient.EXPECT().RequestAny(
 		gomock.Any(), // ctx
-		gomock.Any(), // request
+		gomock.Any(), // requestFn
 	).DoAndReturn(
-		func(_ context.Context, request []byte) (ids.NodeID, []byte, error) {
+		func(_ context.Context, requestFn func(ids.NodeID) ([]byte, error)) (ids.NodeID, []byte, error) {
+			request, err := requestFn(serverNodeID)
+			require.NoError(err)
+
 			go func() {
 				// Get response from server
 				require.NoError(server.AppRequest(context.Background(), clientNodeID, 0, time.Now().Add(time.Hour), request))
@@ -118,6 +121,9 @@ func sendRangeProofRequest(
 		},
 	).AnyTimes()
 
+	// The client penalizes the server if its response is invalid.
+	networkClient.EXPECT().RegisterInvalidResponse(serverNodeID).AnyTimes()
+
 	// The server should expect to "send" a response to the client.
 	sender.EXPECT().SendAppResponse(
 		gomock.Any(), // ctx
@@ -396,9 +402,12 @@ func sendChangeProofRequest(
 
 	networkClient.EXPECT().RequestAny(
 		gomock.Any(), // ctx
-		gomock.Any(), // request
+		gomock.Any(), // requestFn
 	).DoAndReturn(
-		func(_ context.Context, request []byte) (ids.NodeID, []byte, error) {
+		func(_ context.Context, requestFn func(ids.NodeID) ([]byte, error)) (ids.NodeID, []byte, error) {
+			request, err := requestFn(serverNodeID)
+			require.NoError(err)
+
 			go func() {
 				// Get response from server
 				require.NoError(server.AppRequest(context.Background(), clientNodeID, 0, time.Now().Add(time.Hour), request))
@@ -417,6 +426,9 @@ func sendChangeProofRequest(
 		},
 	).AnyTimes()
 
+	// The client penalizes the server if its response is invalid.
+	networkClient.EXPECT().RegisterInvalidResponse(serverNodeID).AnyTimes()
+
 	// Expect server (serverDB) to send app response to client (clientDB)
 	sender.EXPECT().SendAppResponse(
 		gomock.Any(), // ctx
@@ -737,6 +749,102 @@ func TestRangeProofRetries(t *testing.T) {
 	require.Equal(responseCount, maxRequests) // check the client performed retries.
 }
 
+// Test that a peer that sends an invalid response isn't sent the request again.
+func TestGetRangeProofPenalizesInvalidResponse(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	db, err := generateTrie(t, r, 100)
+	require.NoError(err)
+
+	ctx := context.Background()
+	root, err := db.GetMerkleRoot(ctx)
+	require.NoError(err)
+
+	proof, err := db.GetRangeProofAtRoot(ctx, root, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), defaultRequestKeyLimit)
+	require.NoError(err)
+	proofBytes, err := proto.Marshal(proof.ToProto())
+	require.NoError(err)
+
+	var (
+		invalidNodeID = ids.GenerateTestNodeID()
+		validNodeID   = ids.GenerateTestNodeID()
+		networkClient = NewMockNetworkClient(ctrl)
+	)
+	client, err := NewClient(&ClientConfig{
+		NetworkClient:    networkClient,
+		StateSyncNodeIDs: []ids.NodeID{invalidNodeID, validNodeID},
+		Metrics:          &mockMetrics{},
+		Log:              logging.NoLog{},
+		BranchFactor:     merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	networkClient.EXPECT().Request(gomock.Any(), invalidNodeID, gomock.Any()).Return([]byte{}, nil).MaxTimes(1)
+	networkClient.EXPECT().RegisterInvalidResponse(invalidNodeID).MaxTimes(1)
+	networkClient.EXPECT().Request(gomock.Any(), validNodeID, gomock.Any()).Return(proofBytes, nil).Times(1)
+
+	gotProof, err := client.GetRangeProof(ctx, &pb.SyncGetRangeProofRequest{
+		RootHash:   root[:],
+		StartKey:   &pb.MaybeBytes{IsNothing: true},
+		EndKey:     &pb.MaybeBytes{IsNothing: true},
+		KeyLimit:   defaultRequestKeyLimit,
+		BytesLimit: defaultRequestByteSizeLimit,
+	})
+	require.NoError(err)
+	require.Equal(proof.KeyValues, gotProof.KeyValues)
+}
+
+// Test that when the network client selects the peer, the peer that the
+// request was sent to is scored and its key limit is used for the request.
+func TestClientScoresSelectedPeer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	networkClient := NewMockNetworkClient(ctrl)
+	c, err := NewClient(&ClientConfig{
+		NetworkClient: networkClient,
+		Metrics:       &mockMetrics{},
+		Log:           logging.NoLog{},
+		BranchFactor:  merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		maxKeyLimit = uint32(1024)
+		keyLimits   []uint32
+	)
+	marshalFn := func(keyLimit uint32) ([]byte, error) {
+		keyLimits = append(keyLimits, keyLimit)
+		return nil, nil
+	}
+	networkClient.EXPECT().RequestAny(
+		gomock.Any(), // ctx
+		gomock.Any(), // requestFn
+	).DoAndReturn(
+		func(_ context.Context, requestFn func(ids.NodeID) ([]byte, error)) (ids.NodeID, []byte, error) {
+			_, err := requestFn(nodeID)
+			require.NoError(err)
+			return nodeID, nil, errRequestFailed
+		},
+	).Times(2)
+
+	impl := c.(*client)
+	for i := 0; i < 2; i++ {
+		gotNodeID, _, _, err := impl.get(context.Background(), maxKeyLimit, marshalFn)
+		require.ErrorIs(err, errRequestFailed)
+		require.Equal(nodeID, gotNodeID)
+	}
+
+	// The failure was registered against the peer, so the second request was
+	// sent with a smaller key limit.
+	require.Equal([]uint32{maxKeyLimit, maxKeyLimit / 2}, keyLimits)
+	require.Equal(maxKeyLimit, impl.scorer.keyLimit(ids.EmptyNodeID, maxKeyLimit))
+}
+
 // Test that a failure to send an AppRequest is propagated
 // and returned by GetRangeProof and GetChangeProof.
 func TestAppRequestSendFailed(t *testing.T) {
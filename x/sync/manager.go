@@ -6,14 +6,17 @@ package sync
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"slices"
 	"sync"
 
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/maybe"
@@ -88,6 +91,12 @@ type Manager struct {
 	unprocessedWorkCond sync.Cond
 	// [workLock] must be held while accessing [processedWork].
 	processedWork *workHeap
+	// The estimated fraction of the keyspace covered by [processedWork].
+	// [workLock] must be held while accessing [processedFraction].
+	processedFraction float64
+	// If non-nil, the root ID that each range of keys was synced to.
+	// Persisted to [config.ProgressDB].
+	syncedRanges *syncedRanges
 
 	// When this is closed:
 	// - [closed] is true.
@@ -116,6 +125,14 @@ type ManagerConfig struct {
 	Log                   logging.Logger
 	TargetRoot            ids.ID
 	BranchFactor          merkledb.BranchFactor
+	// If non-nil, the progress of the sync is reported to [Metrics].
+	Metrics SyncMetrics
+	// If non-nil, the ranges of keys that have been synced are persisted to
+	// [ProgressDB], so that a Manager created after a restart resumes syncing
+	// where the previous one left off. The ranges are cleared once the sync
+	// completes. [ProgressDB] must only be used to track the progress of
+	// syncing [DB].
+	ProgressDB database.Database
 }
 
 func NewManager(config ManagerConfig) (*Manager, error) {
@@ -142,6 +159,13 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	}
 	m.unprocessedWorkCond.L = &m.workLock
 
+	if config.ProgressDB != nil {
+		syncedRanges, err := newSyncedRanges(config.ProgressDB)
+		if err != nil {
+			return nil, err
+		}
+		m.syncedRanges = syncedRanges
+	}
 	return m, nil
 }
 
@@ -155,17 +179,66 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.config.Log.Info("starting sync", zap.Stringer("target root", m.config.TargetRoot))
 
-	// Add work item to fetch the entire key range.
-	// Note that this will be the first work item to be processed.
-	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	if m.syncedRanges == nil {
+		// Add work item to fetch the entire key range.
+		// Note that this will be the first work item to be processed.
+		m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	} else {
+		m.resumeWork()
+	}
 
 	m.syncing = true
+	m.reportProgress()
 	ctx, m.cancelCtx = context.WithCancel(ctx)
 
 	go m.sync(ctx)
 	return nil
 }
 
+// resumeWork populates the work heaps from the ranges that were synced before
+// a restart.
+// Ranges synced to the target root are already processed. Ranges synced to
+// another root are updated to the target root with change proofs. Ranges that
+// haven't been synced are fetched with range proofs.
+// Assumes [m.workLock] is held.
+func (m *Manager) resumeWork() {
+	var (
+		ranges = m.syncedRanges.list()
+		// The start of the range that hasn't been handled yet.
+		nextStart = maybe.Nothing[[]byte]()
+		done      bool
+	)
+	for _, r := range ranges {
+		if compareStarts(nextStart, r.start) < 0 {
+			// The keys before [r] haven't been synced.
+			m.unprocessedWork.Insert(newWorkItem(ids.Empty, nextStart, r.start, lowPriority))
+		}
+
+		switch r.localRootID {
+		case m.config.TargetRoot:
+			m.processedWork.MergeInsert(newWorkItem(r.localRootID, r.start, r.end, lowPriority))
+			m.processedFraction += keyspaceFraction(r.start, r.end)
+		case ids.Empty:
+			m.unprocessedWork.Insert(newWorkItem(ids.Empty, r.start, r.end, lowPriority))
+		default:
+			m.unprocessedWork.Insert(newWorkItem(r.localRootID, r.start, r.end, highPriority))
+		}
+
+		nextStart = r.end
+		done = r.end.IsNothing()
+	}
+	if !done {
+		// The keys after the last synced range haven't been synced.
+		m.unprocessedWork.Insert(newWorkItem(ids.Empty, nextStart, maybe.Nothing[[]byte](), lowPriority))
+	}
+
+	m.config.Log.Info("resuming sync",
+		zap.Int("syncedRanges", len(ranges)),
+		zap.Int("processedWork", m.processedWork.Len()),
+		zap.Int("unprocessedWork", m.unprocessedWork.Len()),
+	)
+}
+
 // sync awaits signal on [m.unprocessedWorkCond], which indicates that there
 // is work to do or syncing completes.  If there is work, sync will dispatch a goroutine to do
 // the work.
@@ -600,6 +673,14 @@ func (m *Manager) Wait(ctx context.Context) error {
 		return fmt.Errorf("%w: expected %s, got %s", ErrFinishedWithUnexpectedRoot, targetRootID, root)
 	}
 
+	// [DB] may be modified once the sync is complete, so the synced ranges
+	// must not be resumed from by a later sync.
+	if m.syncedRanges != nil {
+		if err := m.syncedRanges.clear(); err != nil {
+			return err
+		}
+	}
+
 	m.config.Log.Info("completed", zap.Stringer("root", root))
 	return nil
 }
@@ -625,6 +706,10 @@ func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 	m.config.Log.Debug("updated sync target", zap.Stringer("target", syncTargetRoot))
 	m.config.TargetRoot = syncTargetRoot
 
+	// None of the processed work is for the new target.
+	m.processedFraction = 0
+	m.reportProgress()
+
 	// move all completed ranges into the work heap with high priority
 	shouldSignal := m.processedWork.Len() > 0
 	for m.processedWork.Len() > 0 {
@@ -643,6 +728,27 @@ func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 	return nil
 }
 
+// Progress returns the estimated percent of the keyspace that has been synced
+// to the current target root.
+func (m *Manager) Progress() float64 {
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	return m.progress()
+}
+
+// Assumes [m.workLock] is held.
+func (m *Manager) progress() float64 {
+	return min(100*m.processedFraction, 100)
+}
+
+// Assumes [m.workLock] is held.
+func (m *Manager) reportProgress() {
+	if m.config.Metrics != nil {
+		m.config.Metrics.SetProgress(m.progress())
+	}
+}
+
 func (m *Manager) getTargetRoot() ids.ID {
 	m.syncTargetLock.RLock()
 	defer m.syncTargetLock.RUnlock()
@@ -700,6 +806,13 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 		}
 	}
 
+	if m.syncedRanges != nil {
+		if err := m.syncedRanges.set(work.start, largestHandledKey, rootID); err != nil {
+			m.setError(err)
+			return
+		}
+	}
+
 	// Process [work] while holding [syncTargetLock] to ensure that object
 	// is added to the right queue, even if a target update is triggered
 	m.syncTargetLock.RLock()
@@ -714,6 +827,8 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 		defer m.workLock.Unlock()
 
 		m.processedWork.MergeInsert(newWorkItem(rootID, work.start, largestHandledKey, work.priority))
+		m.processedFraction += keyspaceFraction(work.start, largestHandledKey)
+		m.reportProgress()
 	}
 
 	// completed the range [work.start, lastKey], log and record in the completed work heap
@@ -765,6 +880,30 @@ func (m *Manager) enqueueWork(work *workItem) {
 	m.unprocessedWork.Insert(second)
 }
 
+// keyspaceFraction returns the estimated fraction of the keyspace in the range
+// [start, end], assuming that keys are uniformly distributed.
+// Nothing [start] is the start of the keyspace.
+// Nothing [end] is the end of the keyspace.
+func keyspaceFraction(start, end maybe.Maybe[[]byte]) float64 {
+	startPosition := 0.0
+	if start.HasValue() {
+		startPosition = keyPosition(start.Value())
+	}
+	endPosition := 1.0
+	if end.HasValue() {
+		endPosition = keyPosition(end.Value())
+	}
+	return max(endPosition-startPosition, 0)
+}
+
+// keyPosition returns the position of [key] in the keyspace as a number in
+// [0, 1), using the first 8 bytes of [key].
+func keyPosition(key []byte) float64 {
+	var prefix [8]byte
+	copy(prefix[:], key)
+	return float64(binary.BigEndian.Uint64(prefix[:])) / math.Exp2(64)
+}
+
 // find the midpoint between two keys
 // start is expected to be less than end
 // Nothing/nil [start] is treated as all 0's
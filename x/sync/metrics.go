@@ -20,6 +20,9 @@ type SyncMetrics interface {
 	RequestFailed()
 	RequestMade()
 	RequestSucceeded()
+	// SetProgress records the estimated percent of the keyspace that has been
+	// synced to the current target root.
+	SetProgress(percent float64)
 }
 
 type mockMetrics struct {
@@ -27,6 +30,7 @@ type mockMetrics struct {
 	requestsFailed    int
 	requestsMade      int
 	requestsSucceeded int
+	progress          float64
 }
 
 func (m *mockMetrics) RequestFailed() {
@@ -50,10 +54,18 @@ func (m *mockMetrics) RequestSucceeded() {
 	m.requestsSucceeded++
 }
 
+func (m *mockMetrics) SetProgress(percent float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.progress = percent
+}
+
 type metrics struct {
 	requestsFailed    prometheus.Counter
 	requestsMade      prometheus.Counter
 	requestsSucceeded prometheus.Counter
+	progress          prometheus.Gauge
 }
 
 func NewMetrics(namespace string, reg prometheus.Registerer) (SyncMetrics, error) {
@@ -73,11 +85,17 @@ func NewMetrics(namespace string, reg prometheus.Registerer) (SyncMetrics, error
 			Name:      "requests_succeeded",
 			Help:      "cumulative amount of proof requests that were successful",
 		}),
+		progress: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "progress",
+			Help:      "estimated percent of the keyspace that has been synced",
+		}),
 	}
 	err := utils.Err(
 		reg.Register(m.requestsFailed),
 		reg.Register(m.requestsMade),
 		reg.Register(m.requestsSucceeded),
+		reg.Register(m.progress),
 	)
 	return &m, err
 }
@@ -93,3 +111,7 @@ func (m *metrics) RequestMade() {
 func (m *metrics) RequestSucceeded() {
 	m.requestsSucceeded.Inc()
 }
+
+func (m *metrics) SetProgress(percent float64) {
+	m.progress.Set(percent)
+}
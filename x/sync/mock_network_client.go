@@ -97,6 +97,18 @@ func (mr *MockNetworkClientMockRecorder) Disconnected(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnected", reflect.TypeOf((*MockNetworkClient)(nil).Disconnected), arg0, arg1)
 }
 
+// RegisterInvalidResponse mocks base method.
+func (m *MockNetworkClient) RegisterInvalidResponse(arg0 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterInvalidResponse", arg0)
+}
+
+// RegisterInvalidResponse indicates an expected call of RegisterInvalidResponse.
+func (mr *MockNetworkClientMockRecorder) RegisterInvalidResponse(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterInvalidResponse", reflect.TypeOf((*MockNetworkClient)(nil).RegisterInvalidResponse), arg0)
+}
+
 // Request mocks base method.
 func (m *MockNetworkClient) Request(arg0 context.Context, arg1 ids.NodeID, arg2 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -113,7 +125,7 @@ func (mr *MockNetworkClientMockRecorder) Request(arg0, arg1, arg2 any) *gomock.C
 }
 
 // RequestAny mocks base method.
-func (m *MockNetworkClient) RequestAny(arg0 context.Context, arg1 []byte) (ids.NodeID, []byte, error) {
+func (m *MockNetworkClient) RequestAny(arg0 context.Context, arg1 func(ids.NodeID) ([]byte, error)) (ids.NodeID, []byte, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RequestAny", arg0, arg1)
 	ret0, _ := ret[0].(ids.NodeID)
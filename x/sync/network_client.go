@@ -17,6 +17,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/reputation"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/version"
@@ -35,13 +36,15 @@ var (
 
 // NetworkClient defines ability to send request / response through the Network
 type NetworkClient interface {
-	// RequestAny synchronously sends request to an arbitrary peer with a
+	// RequestAny synchronously sends a request to an arbitrary peer with a
 	// node version greater than or equal to minVersion.
+	// The request is created by calling [requestFn] with the ID of the chosen
+	// peer, so that it can be tailored to the peer.
 	// Returns response bytes, the ID of the chosen peer, and ErrRequestFailed if
 	// the request should be retried.
 	RequestAny(
 		ctx context.Context,
-		request []byte,
+		requestFn func(nodeID ids.NodeID) ([]byte, error),
 	) (ids.NodeID, []byte, error)
 
 	// Sends [request] to [nodeID] and returns the response.
@@ -53,6 +56,11 @@ type NetworkClient interface {
 		request []byte,
 	) ([]byte, error)
 
+	// Records that [nodeID] sent a response that failed verification, so
+	// that it's less likely to be selected by RequestAny, and reports it to
+	// the reputation tracker.
+	RegisterInvalidResponse(nodeID ids.NodeID)
+
 	// The following declarations allow this interface to be embedded in the VM
 	// to handle incoming responses from peers.
 
@@ -86,6 +94,27 @@ type networkClient struct {
 	peers *p2p.PeerTracker
 	// For sending messages to peers
 	appSender common.AppSender
+	// Reputation of peers
+	reputation reputation.Tracker
+}
+
+// NetworkClientOption configures NetworkClient
+type NetworkClientOption interface {
+	applyNetworkClient(client *networkClient)
+}
+
+type networkClientOptionFunc func(client *networkClient)
+
+func (o networkClientOptionFunc) applyNetworkClient(client *networkClient) {
+	o(client)
+}
+
+// WithReputation reports peers that send invalid responses to [reputation]
+// and weights the selection of peers by their reputation.
+func WithReputation(reputation reputation.Tracker) NetworkClientOption {
+	return networkClientOptionFunc(func(client *networkClient) {
+		client.reputation = reputation
+	})
 }
 
 func NewNetworkClient(
@@ -96,25 +125,34 @@ func NewNetworkClient(
 	metricsNamespace string,
 	registerer prometheus.Registerer,
 	minVersion *version.Application,
+	options ...NetworkClientOption,
 ) (NetworkClient, error) {
+	c := &networkClient{
+		appSender:                  appSender,
+		outstandingRequestHandlers: make(map[uint32]ResponseHandler),
+		activeRequests:             semaphore.NewWeighted(maxActiveRequests),
+		log:                        log,
+	}
+	for _, option := range options {
+		option.applyNetworkClient(c)
+	}
+	if c.reputation == nil {
+		c.reputation = reputation.NewNoTracker()
+	}
+
 	peerTracker, err := p2p.NewPeerTracker(
 		log,
 		metricsNamespace,
 		registerer,
 		set.Of(myNodeID),
 		minVersion,
+		p2p.WithReputation(c.reputation),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create peer tracker: %w", err)
 	}
-
-	return &networkClient{
-		appSender:                  appSender,
-		outstandingRequestHandlers: make(map[uint32]ResponseHandler),
-		activeRequests:             semaphore.NewWeighted(maxActiveRequests),
-		peers:                      peerTracker,
-		log:                        log,
-	}, nil
+	c.peers = peerTracker
+	return c, nil
 }
 
 func (c *networkClient) AppResponse(
@@ -194,7 +232,7 @@ func (c *networkClient) getRequestHandler(requestID uint32) (ResponseHandler, bo
 // If [errAppSendFailed] is returned this should be considered fatal.
 func (c *networkClient) RequestAny(
 	ctx context.Context,
-	request []byte,
+	requestFn func(nodeID ids.NodeID) ([]byte, error),
 ) (ids.NodeID, []byte, error) {
 	// Take a slot from total [activeRequests] and block until a slot becomes available.
 	if err := c.activeRequests.Acquire(ctx, 1); err != nil {
@@ -202,7 +240,7 @@ func (c *networkClient) RequestAny(
 	}
 	defer c.activeRequests.Release(1)
 
-	nodeID, responseChan, err := c.sendRequestAny(ctx, request)
+	nodeID, responseChan, err := c.sendRequestAny(ctx, requestFn)
 	if err != nil {
 		return ids.EmptyNodeID, nil, err
 	}
@@ -213,7 +251,7 @@ func (c *networkClient) RequestAny(
 
 func (c *networkClient) sendRequestAny(
 	ctx context.Context,
-	request []byte,
+	requestFn func(nodeID ids.NodeID) ([]byte, error),
 ) (ids.NodeID, chan []byte, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -224,6 +262,11 @@ func (c *networkClient) sendRequestAny(
 		return ids.EmptyNodeID, nil, fmt.Errorf("no peers found from %d peers", numPeers)
 	}
 
+	request, err := requestFn(nodeID)
+	if err != nil {
+		return ids.EmptyNodeID, nil, err
+	}
+
 	responseChan, err := c.sendRequestLocked(ctx, nodeID, request)
 	return nodeID, responseChan, err
 }
@@ -344,6 +387,12 @@ func (c *networkClient) awaitResponse(
 	return response, nil
 }
 
+func (c *networkClient) RegisterInvalidResponse(nodeID ids.NodeID) {
+	c.log.Debug("received invalid response from peer", zap.Stringer("nodeID", nodeID))
+	c.peers.RegisterFailure(nodeID)
+	c.reputation.Report(nodeID, reputation.InvalidProof)
+}
+
 func (c *networkClient) Connected(
 	_ context.Context,
 	nodeID ids.NodeID,
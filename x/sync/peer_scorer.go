@@ -0,0 +1,212 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+
+	safemath "github.com/MetalBlockchain/metalgo/utils/math"
+)
+
+const (
+	// Peer measurements decay with this half-life, so that a peer's recent
+	// performance matters more than its past performance.
+	peerScoreHalflife = time.Minute
+
+	// The key limit of requests sent to a peer is tuned so that the peer's
+	// response time is close to [targetResponseTime].
+	targetResponseTime = time.Second
+	// The key limit of requests is never reduced below this fraction of the
+	// requested key limit.
+	minKeyLimitFactor = 1.0 / 64
+	// The key limit factor is multiplied by this when a peer is responding
+	// quickly.
+	keyLimitIncreaseFactor = 1.5
+	// The key limit factor is multiplied by this when a peer is responding
+	// slowly or failing to respond.
+	keyLimitDecreaseFactor = 0.5
+
+	// A peer that sends an invalid response isn't sent requests for this long.
+	// The penalty doubles for every subsequent invalid response, up to
+	// [maxInvalidResponsePenalty].
+	initialInvalidResponsePenalty = 10 * time.Second
+	maxInvalidResponsePenalty     = 10 * time.Minute
+
+	// The probability that, when we select a peer, we select randomly rather
+	// than based on their score.
+	randomPeerProbability = 0.1
+)
+
+type peerScore struct {
+	// Average bytes per second of responses from the peer, measured from
+	// when the request was sent, so that the latency of the peer counts
+	// against it. Failed requests are counted as a bandwidth of 0.
+	bandwidth safemath.Averager
+	// The fraction of the requested key limit to ask the peer for.
+	// In [minKeyLimitFactor, 1].
+	keyLimitFactor float64
+	// The number of invalid responses the peer has sent.
+	numInvalidResponses int
+	// The peer isn't sent requests until this time.
+	penalizedUntil time.Time
+}
+
+// peerScorer tracks the bandwidth, response time and validity of the responses
+// peers send to sync requests. It's used to select which peer to send a
+// request to, and to size requests so that slow peers are sent less work.
+//
+// It's safe to call methods on peerScorer concurrently.
+type peerScorer struct {
+	lock  sync.Mutex
+	clock mockable.Clock
+	peers map[ids.NodeID]*peerScore
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		peers: make(map[ids.NodeID]*peerScore),
+	}
+}
+
+// selectPeer returns the peer in [nodeIDs] that we should send a request to.
+//
+// Peers that haven't been sent a request yet are preferred, so that every
+// peer gets scored. Otherwise, with probability [randomPeerProbability]
+// returns a random peer that isn't penalized. With probability
+// [1-randomPeerProbability] returns the peer with the highest bandwidth that
+// isn't penalized. If every peer is penalized, returns the peer whose penalty
+// expires first.
+//
+// Returns false if [nodeIDs] is empty.
+func (p *peerScorer) selectPeer(nodeIDs []ids.NodeID) (ids.NodeID, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(nodeIDs) == 0 {
+		return ids.EmptyNodeID, false
+	}
+
+	var (
+		now         = p.clock.Time()
+		unscored    []ids.NodeID
+		available   []ids.NodeID
+		best        ids.NodeID
+		bestScore   float64
+		leastRecent ids.NodeID
+		leastUntil  time.Time
+	)
+	for _, nodeID := range nodeIDs {
+		score, ok := p.peers[nodeID]
+		if !ok {
+			unscored = append(unscored, nodeID)
+			continue
+		}
+		if now.Before(score.penalizedUntil) {
+			if leastUntil.IsZero() || score.penalizedUntil.Before(leastUntil) {
+				leastRecent = nodeID
+				leastUntil = score.penalizedUntil
+			}
+			continue
+		}
+
+		bandwidth := score.bandwidth.Read()
+		if len(available) == 0 || bandwidth > bestScore {
+			best = nodeID
+			bestScore = bandwidth
+		}
+		available = append(available, nodeID)
+	}
+
+	switch {
+	case len(unscored) > 0:
+		return unscored[rand.Intn(len(unscored))], true // #nosec G404
+	case len(available) == 0:
+		return leastRecent, true
+	case rand.Float64() < randomPeerProbability: // #nosec G404
+		return available[rand.Intn(len(available))], true // #nosec G404
+	default:
+		return best, true
+	}
+}
+
+// keyLimit returns the key limit to use for a request to [nodeID] that
+// requested at most [maxKeyLimit] keys.
+func (p *peerScorer) keyLimit(nodeID ids.NodeID, maxKeyLimit uint32) uint32 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	score, ok := p.peers[nodeID]
+	if !ok {
+		return maxKeyLimit
+	}
+	return max(uint32(float64(maxKeyLimit)*score.keyLimitFactor), 1)
+}
+
+// registerResponse records that [nodeID] responded with [numBytes] bytes
+// after [elapsed].
+//
+// The key limit used for [nodeID] grows if it's responding quickly and shrinks
+// if it's responding slowly.
+func (p *peerScorer) registerResponse(nodeID ids.NodeID, numBytes int, elapsed time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		now            = p.clock.Time()
+		score          = p.getScore(nodeID)
+		elapsedSeconds = elapsed.Seconds()
+	)
+	score.bandwidth.Observe(float64(numBytes)/(elapsedSeconds+epsilon), now)
+
+	switch {
+	case elapsed > targetResponseTime:
+		score.keyLimitFactor = max(score.keyLimitFactor*keyLimitDecreaseFactor, minKeyLimitFactor)
+	case elapsed < targetResponseTime/2:
+		score.keyLimitFactor = min(score.keyLimitFactor*keyLimitIncreaseFactor, 1)
+	}
+}
+
+// registerFailure records that a request to [nodeID] failed.
+func (p *peerScorer) registerFailure(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.clock.Time()
+	score := p.getScore(nodeID)
+	score.bandwidth.Observe(0, now)
+	score.keyLimitFactor = max(score.keyLimitFactor*keyLimitDecreaseFactor, minKeyLimitFactor)
+}
+
+// registerInvalidResponse records that [nodeID] sent a response that failed
+// verification, and penalizes [nodeID] so that it isn't selected for a while.
+func (p *peerScorer) registerInvalidResponse(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.clock.Time()
+	score := p.getScore(nodeID)
+	score.bandwidth.Observe(0, now)
+
+	penalty := initialInvalidResponsePenalty << min(score.numInvalidResponses, 16)
+	score.penalizedUntil = now.Add(min(penalty, maxInvalidResponsePenalty))
+	score.numInvalidResponses++
+}
+
+// Assumes [p.lock] is held.
+func (p *peerScorer) getScore(nodeID ids.NodeID) *peerScore {
+	score, ok := p.peers[nodeID]
+	if !ok {
+		score = &peerScore{
+			bandwidth:      safemath.NewUninitializedAverager(peerScoreHalflife),
+			keyLimitFactor: 1,
+		}
+		p.peers[nodeID] = score
+	}
+	return score
+}
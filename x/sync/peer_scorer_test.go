@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestPeerScorerSelectPeer(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newPeerScorer()
+	scorer.clock.Set(time.Unix(1, 0))
+
+	_, ok := scorer.selectPeer(nil)
+	require.False(ok)
+
+	fastNodeID := ids.GenerateTestNodeID()
+	slowNodeID := ids.GenerateTestNodeID()
+	nodeIDs := []ids.NodeID{fastNodeID, slowNodeID}
+
+	// Peers that haven't been scored are selected first.
+	scorer.registerResponse(slowNodeID, 1000, time.Second)
+	nodeID, ok := scorer.selectPeer(nodeIDs)
+	require.True(ok)
+	require.Equal(fastNodeID, nodeID)
+
+	// The peer with the highest bandwidth is usually selected.
+	scorer.registerResponse(fastNodeID, 1000, time.Millisecond)
+	var numFastSelected int
+	for i := 0; i < 1000; i++ {
+		nodeID, ok := scorer.selectPeer(nodeIDs)
+		require.True(ok)
+		if nodeID == fastNodeID {
+			numFastSelected++
+		}
+	}
+	require.Greater(numFastSelected, 800)
+
+	// A penalized peer isn't selected until its penalty expires.
+	scorer.registerInvalidResponse(fastNodeID)
+	for i := 0; i < 100; i++ {
+		nodeID, ok := scorer.selectPeer(nodeIDs)
+		require.True(ok)
+		require.Equal(slowNodeID, nodeID)
+	}
+
+	// If every peer is penalized, the peer whose penalty expires first is
+	// selected.
+	scorer.clock.Set(scorer.clock.Time().Add(time.Second))
+	scorer.registerInvalidResponse(slowNodeID)
+	nodeID, ok = scorer.selectPeer(nodeIDs)
+	require.True(ok)
+	require.Equal(fastNodeID, nodeID)
+
+	// Once the penalty of a peer expires, it's selected again.
+	scorer.clock.Set(scorer.clock.Time().Add(initialInvalidResponsePenalty - time.Millisecond))
+	for i := 0; i < 100; i++ {
+		nodeID, ok := scorer.selectPeer(nodeIDs)
+		require.True(ok)
+		require.Equal(fastNodeID, nodeID)
+	}
+}
+
+func TestPeerScorerInvalidResponsePenalty(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newPeerScorer()
+	scorer.clock.Set(time.Unix(1, 0))
+
+	penalizedNodeID := ids.GenerateTestNodeID()
+	otherNodeID := ids.GenerateTestNodeID()
+	nodeIDs := []ids.NodeID{penalizedNodeID, otherNodeID}
+	scorer.registerResponse(otherNodeID, 1, time.Second)
+
+	expectedPenalty := initialInvalidResponsePenalty
+	for i := 0; i < 10; i++ {
+		scorer.registerInvalidResponse(penalizedNodeID)
+
+		// The penalty doubles with each invalid response.
+		scorer.clock.Set(scorer.clock.Time().Add(expectedPenalty - time.Nanosecond))
+		nodeID, ok := scorer.selectPeer(nodeIDs)
+		require.True(ok)
+		require.Equal(otherNodeID, nodeID)
+
+		// The penalty has expired.
+		scorer.clock.Set(scorer.clock.Time().Add(time.Nanosecond))
+		require.False(scorer.clock.Time().Before(scorer.peers[penalizedNodeID].penalizedUntil))
+
+		expectedPenalty = min(2*expectedPenalty, maxInvalidResponsePenalty)
+	}
+}
+
+func TestPeerScorerKeyLimit(t *testing.T) {
+	require := require.New(t)
+
+	scorer := newPeerScorer()
+	nodeID := ids.GenerateTestNodeID()
+
+	// Unscored peers are sent the full key limit.
+	require.Equal(uint32(1024), scorer.keyLimit(nodeID, 1024))
+
+	// Slow responses shrink the key limit.
+	scorer.registerResponse(nodeID, 1000, 2*targetResponseTime)
+	require.Equal(uint32(512), scorer.keyLimit(nodeID, 1024))
+
+	// Failures shrink the key limit.
+	scorer.registerFailure(nodeID)
+	require.Equal(uint32(256), scorer.keyLimit(nodeID, 1024))
+
+	// The key limit never shrinks below the minimum.
+	for i := 0; i < 100; i++ {
+		scorer.registerFailure(nodeID)
+	}
+	require.Equal(uint32(1024*minKeyLimitFactor), scorer.keyLimit(nodeID, 1024))
+	require.Equal(uint32(1), scorer.keyLimit(nodeID, 1))
+
+	// Responses close to the target don't change the key limit.
+	scorer.registerResponse(nodeID, 1000, targetResponseTime)
+	require.Equal(uint32(1024*minKeyLimitFactor), scorer.keyLimit(nodeID, 1024))
+
+	// Fast responses grow the key limit back to the full key limit.
+	for i := 0; i < 100; i++ {
+		scorer.registerResponse(nodeID, 1000, time.Millisecond)
+	}
+	require.Equal(uint32(1024), scorer.keyLimit(nodeID, 1024))
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"math/rand"
 	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -131,6 +132,51 @@ func Test_Completion(t *testing.T) {
 	syncer.workLock.Unlock()
 }
 
+func TestKeyspaceFraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    maybe.Maybe[[]byte]
+		end      maybe.Maybe[[]byte]
+		expected float64
+	}{
+		{
+			name:     "entire keyspace",
+			start:    maybe.Nothing[[]byte](),
+			end:      maybe.Nothing[[]byte](),
+			expected: 1,
+		},
+		{
+			name:     "first half",
+			start:    maybe.Nothing[[]byte](),
+			end:      maybe.Some([]byte{128}),
+			expected: 0.5,
+		},
+		{
+			name:     "last quarter",
+			start:    maybe.Some([]byte{192}),
+			end:      maybe.Nothing[[]byte](),
+			expected: 0.25,
+		},
+		{
+			name:     "empty key start",
+			start:    maybe.Some([]byte{}),
+			end:      maybe.Some([]byte{64, 0}),
+			expected: 0.25,
+		},
+		{
+			name:     "same prefix",
+			start:    maybe.Some([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9}),
+			end:      maybe.Some([]byte{1, 2, 3, 4, 5, 6, 7, 8, 10}),
+			expected: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.InDelta(t, tt.expected, keyspaceFraction(tt.start, tt.end), 1e-9)
+		})
+	}
+}
+
 func Test_Midpoint(t *testing.T) {
 	require := require.New(t)
 
@@ -812,6 +858,7 @@ func Test_Sync_Result_Correct_Root(t *testing.T) {
 		newDefaultDBConfig(),
 	)
 	require.NoError(err)
+	metrics := &mockMetrics{}
 	syncer, err := NewManager(ManagerConfig{
 		DB:                    db,
 		Client:                newCallthroughSyncClient(ctrl, dbToSync),
@@ -819,6 +866,7 @@ func Test_Sync_Result_Correct_Root(t *testing.T) {
 		SimultaneousWorkLimit: 5,
 		Log:                   logging.NoLog{},
 		BranchFactor:          merkledb.BranchFactor16,
+		Metrics:               metrics,
 	})
 	require.NoError(err)
 	require.NotNil(syncer)
@@ -827,6 +875,10 @@ func Test_Sync_Result_Correct_Root(t *testing.T) {
 	require.NoError(syncer.Wait(context.Background()))
 	require.NoError(syncer.Error())
 
+	// the entire keyspace has been synced
+	require.InDelta(100, syncer.Progress(), 1e-6)
+	require.InDelta(100, metrics.progress, 1e-6)
+
 	// new db has fully sync'ed and should be at the same root as the original db
 	newRoot, err := db.GetMerkleRoot(context.Background())
 	require.NoError(err)
@@ -917,6 +969,192 @@ func Test_Sync_Result_Correct_Root_With_Sync_Restart(t *testing.T) {
 	require.Equal(syncRoot, newRoot)
 }
 
+func Test_Sync_Resume_With_Progress_DB(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	progressDB := memdb.New()
+
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		ProgressDB:            progressDB,
+		Client:                newCallthroughSyncClient(ctrl, dbToSync),
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(context.Background()))
+
+	// Wait until we've processed some work before stopping the syncer.
+	require.Eventually(
+		func() bool {
+			syncer.workLock.Lock()
+			defer syncer.workLock.Unlock()
+
+			return syncer.processedWork.Len() > 0
+		},
+		5*time.Second,
+		5*time.Millisecond,
+	)
+	syncer.Close()
+
+	// Wait for in-flight work to finish so that it doesn't race with the
+	// new syncer.
+	require.Eventually(
+		func() bool {
+			syncer.workLock.Lock()
+			defer syncer.workLock.Unlock()
+
+			return syncer.processingWorkItems == 0
+		},
+		5*time.Second,
+		5*time.Millisecond,
+	)
+
+	syncedRanges, err := newSyncedRanges(progressDB)
+	require.NoError(err)
+	require.NotEmpty(syncedRanges.list())
+
+	// Update the database being synced, so that the ranges synced before the
+	// restart are out of date.
+	for i := 0; i < 10; i++ {
+		key := make([]byte, r.Intn(50))
+		_, _ = r.Read(key)
+		val := make([]byte, r.Intn(50))
+		_, _ = r.Read(val)
+		require.NoError(dbToSync.Put(key, val))
+	}
+	newSyncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// The new syncer should update the ranges synced before the restart with
+	// change proofs rather than fetching them again.
+	var numChangeProofs atomic.Int32
+	callthroughClient := newCallthroughSyncClient(ctrl, dbToSync)
+	client := NewMockClient(ctrl)
+	client.EXPECT().GetRangeProof(gomock.Any(), gomock.Any()).DoAndReturn(
+		callthroughClient.GetRangeProof,
+	).AnyTimes()
+	client.EXPECT().GetChangeProof(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, request *pb.SyncGetChangeProofRequest, db DB) (*merkledb.ChangeOrRangeProof, error) {
+			numChangeProofs.Add(1)
+			return callthroughClient.GetChangeProof(ctx, request, db)
+		},
+	).AnyTimes()
+
+	newSyncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		ProgressDB:            progressDB,
+		Client:                client,
+		TargetRoot:            newSyncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+	require.NoError(newSyncer.Start(context.Background()))
+	require.NoError(newSyncer.Wait(context.Background()))
+	require.Positive(numChangeProofs.Load())
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(newSyncRoot, newRoot)
+
+	// Once synced, the synced ranges are cleared.
+	syncedRanges, err = newSyncedRanges(progressDB)
+	require.NoError(err)
+	require.Empty(syncedRanges.list())
+}
+
+func Test_Sync_After_Completed_Sync_With_Progress_DB(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	progressDB := memdb.New()
+
+	syncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		ProgressDB:            progressDB,
+		Client:                newCallthroughSyncClient(ctrl, dbToSync),
+		TargetRoot:            syncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(context.Background()))
+	require.NoError(syncer.Wait(context.Background()))
+
+	// Modify the synced database after the sync, so that it no longer
+	// matches the root that its keys were synced to.
+	for i := 0; i < 10; i++ {
+		key := make([]byte, r.Intn(50))
+		_, _ = r.Read(key)
+		val := make([]byte, r.Intn(50))
+		_, _ = r.Read(val)
+		require.NoError(db.Put(key, val))
+	}
+
+	// Update the database being synced.
+	for i := 0; i < 10; i++ {
+		key := make([]byte, r.Intn(50))
+		_, _ = r.Read(key)
+		val := make([]byte, r.Intn(50))
+		_, _ = r.Read(val)
+		require.NoError(dbToSync.Put(key, val))
+	}
+	newSyncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// The second sync must not resume from the ranges of the first one.
+	newSyncer, err := NewManager(ManagerConfig{
+		DB:                    db,
+		ProgressDB:            progressDB,
+		Client:                newCallthroughSyncClient(ctrl, dbToSync),
+		TargetRoot:            newSyncRoot,
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+	})
+	require.NoError(err)
+	require.NoError(newSyncer.Start(context.Background()))
+	require.NoError(newSyncer.Wait(context.Background()))
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(newSyncRoot, newRoot)
+}
+
 func Test_Sync_Error_During_Sync(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -1118,6 +1356,7 @@ func Test_Sync_UpdateSyncTarget(t *testing.T) {
 		localRootID: ids.GenerateTestID(),
 	}
 	m.processedWork.Insert(item)
+	m.processedFraction = keyspaceFraction(item.start, item.end)
 
 	// Make sure that [m.unprocessedWorkCond] is signaled.
 	gotSignalChan := make(chan struct{})
@@ -1140,6 +1379,7 @@ func Test_Sync_UpdateSyncTarget(t *testing.T) {
 	require.Equal(newSyncRoot, m.config.TargetRoot)
 	require.Zero(m.processedWork.Len())
 	require.Equal(1, m.unprocessedWork.Len())
+	require.Zero(m.Progress())
 }
 
 func generateTrie(t *testing.T, r *rand.Rand, count int) (merkledb.MerkleDB, error) {
@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/btree"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+)
+
+const (
+	nothingMarker byte = iota
+	someMarker
+)
+
+var errInvalidSyncedRange = errors.New("invalid synced range")
+
+// syncedRanges tracks the root ID that each range of keys in the sync database
+// was last synced to, and persists it to [db] so that syncing can resume after
+// a restart.
+//
+// Ranges are half-open. That is, a range [start, end) contains the keys that
+// are >= start and < end. A Nothing start is before every key and a Nothing
+// end is after every key. Ranges never overlap. Keys that aren't in any range
+// haven't been synced.
+//
+// It's safe to call methods on syncedRanges concurrently.
+type syncedRanges struct {
+	lock sync.Mutex
+	db   database.Database
+	// The synced ranges, sorted by start.
+	// [workItem.localRootID] is the root ID that the range was synced to.
+	// [workItem.priority] is unused.
+	ranges *btree.BTreeG[*workItem]
+}
+
+// newSyncedRanges returns the synced ranges persisted in [db].
+func newSyncedRanges(db database.Database) (*syncedRanges, error) {
+	s := &syncedRanges{
+		db:     db,
+		ranges: btree.NewG(2, workItemStartLess),
+	}
+
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		r, err := parseSyncedRange(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+		s.ranges.ReplaceOrInsert(r)
+	}
+	return s, it.Error()
+}
+
+// list returns the synced ranges, sorted by start.
+func (s *syncedRanges) list() []*workItem {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ranges := make([]*workItem, 0, s.ranges.Len())
+	s.ranges.Ascend(func(r *workItem) bool {
+		ranges = append(ranges, newWorkItem(r.localRootID, r.start, r.end, r.priority))
+		return true
+	})
+	return ranges
+}
+
+// set records that the keys in [start, end) were synced to [rootID].
+// Ranges that overlap [start, end) are trimmed, and adjacent ranges that were
+// synced to [rootID] are merged with it.
+func (s *syncedRanges) set(start, end maybe.Maybe[[]byte], rootID ids.ID) error {
+	if start.HasValue() && end.HasValue() && bytes.Compare(start.Value(), end.Value()) >= 0 {
+		// The range is empty.
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Find the ranges that overlap [start, end), or are adjacent to it and
+	// were synced to [rootID].
+	var (
+		searchItem = &workItem{start: start}
+		affected   []*workItem
+	)
+	s.ranges.DescendLessOrEqual(searchItem, func(r *workItem) bool {
+		if endsAfter(r.end, start) ||
+			(r.localRootID == rootID && maybe.Equal(r.end, start, bytes.Equal)) {
+			affected = append(affected, r)
+		}
+		// If [r] starts at [start], the range before it may be adjacent.
+		return maybe.Equal(r.start, start, bytes.Equal)
+	})
+	s.ranges.AscendGreaterOrEqual(searchItem, func(r *workItem) bool {
+		switch {
+		case maybe.Equal(r.start, start, bytes.Equal):
+			// Already handled above.
+			return true
+		case endsAfter(end, r.start):
+			affected = append(affected, r)
+			return true
+		case r.localRootID == rootID && maybe.Equal(r.start, end, bytes.Equal):
+			affected = append(affected, r)
+		}
+		return false
+	})
+
+	var (
+		newRange = newWorkItem(rootID, start, end, lowPriority)
+		added    []*workItem
+	)
+	for _, r := range affected {
+		if r.localRootID == rootID {
+			// Merge [r] into [newRange].
+			if compareStarts(r.start, newRange.start) < 0 {
+				newRange.start = r.start
+			}
+			if compareEnds(r.end, newRange.end) > 0 {
+				newRange.end = r.end
+			}
+			continue
+		}
+
+		// Keep the parts of [r] outside of [start, end).
+		if compareStarts(r.start, start) < 0 {
+			added = append(added, newWorkItem(r.localRootID, r.start, start, lowPriority))
+		}
+		if compareEnds(end, r.end) < 0 {
+			added = append(added, newWorkItem(r.localRootID, end, r.end, lowPriority))
+		}
+	}
+	added = append(added, newRange)
+
+	batch := s.db.NewBatch()
+	for _, r := range affected {
+		if err := batch.Delete(syncedRangeKey(r.start)); err != nil {
+			return err
+		}
+	}
+	for _, r := range added {
+		if err := batch.Put(syncedRangeKey(r.start), syncedRangeValue(r)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	for _, r := range affected {
+		s.ranges.Delete(r)
+	}
+	for _, r := range added {
+		s.ranges.ReplaceOrInsert(r)
+	}
+	return nil
+}
+
+// clear removes all of the synced ranges.
+func (s *syncedRanges) clear() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batch := s.db.NewBatch()
+	var err error
+	s.ranges.Ascend(func(r *workItem) bool {
+		err = batch.Delete(syncedRangeKey(r.start))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	s.ranges.Clear(false)
+	return nil
+}
+
+// endsAfter returns true if a range ending at [end] contains keys that are
+// >= [start].
+func endsAfter(end, start maybe.Maybe[[]byte]) bool {
+	if end.IsNothing() || start.IsNothing() {
+		return true
+	}
+	return bytes.Compare(end.Value(), start.Value()) > 0
+}
+
+// compareStarts compares range starts, where Nothing is before every key.
+func compareStarts(a, b maybe.Maybe[[]byte]) int {
+	switch {
+	case a.IsNothing() && b.IsNothing():
+		return 0
+	case a.IsNothing():
+		return -1
+	case b.IsNothing():
+		return 1
+	default:
+		return bytes.Compare(a.Value(), b.Value())
+	}
+}
+
+// compareEnds compares range ends, where Nothing is after every key.
+func compareEnds(a, b maybe.Maybe[[]byte]) int {
+	switch {
+	case a.IsNothing() && b.IsNothing():
+		return 0
+	case a.IsNothing():
+		return 1
+	case b.IsNothing():
+		return -1
+	default:
+		return bytes.Compare(a.Value(), b.Value())
+	}
+}
+
+// syncedRangeKey returns the key that the range starting at [start] is
+// stored under. Keys sort in the same order as range starts.
+func syncedRangeKey(start maybe.Maybe[[]byte]) []byte {
+	return appendMaybeBytes(nil, start)
+}
+
+// syncedRangeValue returns the value that [r] is stored as.
+func syncedRangeValue(r *workItem) []byte {
+	value := make([]byte, ids.IDLen, ids.IDLen+1+len(r.end.Value()))
+	copy(value, r.localRootID[:])
+	return appendMaybeBytes(value, r.end)
+}
+
+func parseSyncedRange(key, value []byte) (*workItem, error) {
+	start, err := parseMaybeBytes(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) < ids.IDLen {
+		return nil, fmt.Errorf("%w: value length %d", errInvalidSyncedRange, len(value))
+	}
+	rootID, err := ids.ToID(value[:ids.IDLen])
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseMaybeBytes(value[ids.IDLen:])
+	if err != nil {
+		return nil, err
+	}
+	if start.HasValue() && end.HasValue() && bytes.Compare(start.Value(), end.Value()) >= 0 {
+		return nil, fmt.Errorf("%w: start %x isn't before end %x", errInvalidSyncedRange, start.Value(), end.Value())
+	}
+	return newWorkItem(rootID, start, end, lowPriority), nil
+}
+
+func appendMaybeBytes(b []byte, m maybe.Maybe[[]byte]) []byte {
+	if m.IsNothing() {
+		return append(b, nothingMarker)
+	}
+	b = append(b, someMarker)
+	return append(b, m.Value()...)
+}
+
+func parseMaybeBytes(b []byte) (maybe.Maybe[[]byte], error) {
+	switch {
+	case len(b) == 1 && b[0] == nothingMarker:
+		return maybe.Nothing[[]byte](), nil
+	case len(b) >= 1 && b[0] == someMarker:
+		return maybe.Some(bytes.Clone(b[1:])), nil
+	default:
+		return maybe.Nothing[[]byte](), fmt.Errorf("%w: malformed bytes %x", errInvalidSyncedRange, b)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+)
+
+func TestSyncedRangesSet(t *testing.T) {
+	var (
+		root1 = ids.GenerateTestID()
+		root2 = ids.GenerateTestID()
+
+		nothing = maybe.Nothing[[]byte]()
+		some    = func(b ...byte) maybe.Maybe[[]byte] {
+			return maybe.Some(append([]byte{}, b...))
+		}
+	)
+
+	type syncedRange struct {
+		start  maybe.Maybe[[]byte]
+		end    maybe.Maybe[[]byte]
+		rootID ids.ID
+	}
+
+	tests := []struct {
+		name     string
+		set      []syncedRange
+		expected []syncedRange
+	}{
+		{
+			name:     "empty",
+			set:      nil,
+			expected: nil,
+		},
+		{
+			name: "entire keyspace",
+			set: []syncedRange{
+				{nothing, nothing, root1},
+			},
+			expected: []syncedRange{
+				{nothing, nothing, root1},
+			},
+		},
+		{
+			name: "empty range ignored",
+			set: []syncedRange{
+				{some(1), some(1), root1},
+				{some(2), some(1), root1},
+			},
+			expected: nil,
+		},
+		{
+			name: "disjoint",
+			set: []syncedRange{
+				{some(5), nothing, root1},
+				{nothing, some(2), root1},
+			},
+			expected: []syncedRange{
+				{nothing, some(2), root1},
+				{some(5), nothing, root1},
+			},
+		},
+		{
+			name: "adjacent ranges with the same root are merged",
+			set: []syncedRange{
+				{nothing, some(2), root1},
+				{some(4), nothing, root1},
+				{some(2), some(4), root1},
+			},
+			expected: []syncedRange{
+				{nothing, nothing, root1},
+			},
+		},
+		{
+			name: "adjacent ranges with different roots aren't merged",
+			set: []syncedRange{
+				{nothing, some(2), root1},
+				{some(4), nothing, root1},
+				{some(2), some(4), root2},
+			},
+			expected: []syncedRange{
+				{nothing, some(2), root1},
+				{some(2), some(4), root2},
+				{some(4), nothing, root1},
+			},
+		},
+		{
+			name: "overlapping range is split",
+			set: []syncedRange{
+				{nothing, nothing, root1},
+				{some(2), some(4), root2},
+			},
+			expected: []syncedRange{
+				{nothing, some(2), root1},
+				{some(2), some(4), root2},
+				{some(4), nothing, root1},
+			},
+		},
+		{
+			name: "overlapping ranges are trimmed",
+			set: []syncedRange{
+				{nothing, some(3), root1},
+				{some(3), some(6), root2},
+				{some(6), nothing, root1},
+				{some(2), some(7), root1},
+			},
+			expected: []syncedRange{
+				{nothing, nothing, root1},
+			},
+		},
+		{
+			name: "overlapping ranges are replaced",
+			set: []syncedRange{
+				{some(1), some(3), root1},
+				{some(3), some(6), root2},
+				{some(6), some(8), root1},
+				{some(1), some(8), root2},
+			},
+			expected: []syncedRange{
+				{some(1), some(8), root2},
+			},
+		},
+		{
+			name: "update prefix of range",
+			set: []syncedRange{
+				{some(1), nothing, root1},
+				{some(1), some(2), root2},
+			},
+			expected: []syncedRange{
+				{some(1), some(2), root2},
+				{some(2), nothing, root1},
+			},
+		},
+		{
+			name: "update suffix of range",
+			set: []syncedRange{
+				{nothing, some(5), root1},
+				{some(3), some(5), root2},
+			},
+			expected: []syncedRange{
+				{nothing, some(3), root1},
+				{some(3), some(5), root2},
+			},
+		},
+		{
+			name: "empty key",
+			set: []syncedRange{
+				{nothing, some(), root1},
+				{some(), some(0), root2},
+			},
+			expected: []syncedRange{
+				{nothing, some(), root1},
+				{some(), some(0), root2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			ranges, err := newSyncedRanges(db)
+			require.NoError(err)
+
+			for _, r := range tt.set {
+				require.NoError(ranges.set(r.start, r.end, r.rootID))
+			}
+
+			expected := make([]*workItem, len(tt.expected))
+			for i, r := range tt.expected {
+				expected[i] = newWorkItem(r.rootID, r.start, r.end, lowPriority)
+			}
+			require.Equal(expected, ranges.list())
+
+			// The ranges should be the same after a restart.
+			reloadedRanges, err := newSyncedRanges(db)
+			require.NoError(err)
+			require.Equal(expected, reloadedRanges.list())
+
+			// Cleared ranges should stay cleared after a restart.
+			require.NoError(ranges.clear())
+			require.Empty(ranges.list())
+
+			reloadedRanges, err = newSyncedRanges(db)
+			require.NoError(err)
+			require.Empty(reloadedRanges.list())
+		})
+	}
+}
+
+func TestSyncedRangesInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   []byte
+		value []byte
+	}{
+		{
+			name:  "empty key",
+			key:   []byte{},
+			value: append(ids.Empty[:], nothingMarker),
+		},
+		{
+			name:  "invalid key marker",
+			key:   []byte{2},
+			value: append(ids.Empty[:], nothingMarker),
+		},
+		{
+			name:  "short value",
+			key:   []byte{nothingMarker},
+			value: ids.Empty[:ids.IDLen-1],
+		},
+		{
+			name:  "missing end",
+			key:   []byte{nothingMarker},
+			value: ids.Empty[:],
+		},
+		{
+			name:  "start after end",
+			key:   []byte{someMarker, 2},
+			value: append(ids.Empty[:], someMarker, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			require.NoError(db.Put(tt.key, tt.value))
+
+			_, err := newSyncedRanges(db)
+			require.ErrorIs(err, errInvalidSyncedRange)
+		})
+	}
+}
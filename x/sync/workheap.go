@@ -31,27 +31,28 @@ func newWorkHeap() *workHeap {
 		innerHeap: heap.NewSet[*workItem](func(a, b *workItem) bool {
 			return a.priority > b.priority
 		}),
-		sortedItems: btree.NewG(
-			2,
-			func(a, b *workItem) bool {
-				aNothing := a.start.IsNothing()
-				bNothing := b.start.IsNothing()
-				if aNothing {
-					// [a] is Nothing, so if [b] is Nothing, they're equal.
-					// Otherwise, [b] is greater.
-					return !bNothing
-				}
-				if bNothing {
-					// [a] has a value and [b] doesn't so [a] is greater.
-					return false
-				}
-				// [a] and [b] both contain values. Compare the values.
-				return bytes.Compare(a.start.Value(), b.start.Value()) < 0
-			},
-		),
+		sortedItems: btree.NewG(2, workItemStartLess),
 	}
 }
 
+// workItemStartLess returns true if [a] starts before [b].
+// A Nothing start is considered to be the smallest.
+func workItemStartLess(a, b *workItem) bool {
+	aNothing := a.start.IsNothing()
+	bNothing := b.start.IsNothing()
+	if aNothing {
+		// [a] is Nothing, so if [b] is Nothing, they're equal.
+		// Otherwise, [b] is greater.
+		return !bNothing
+	}
+	if bNothing {
+		// [a] has a value and [b] doesn't so [a] is greater.
+		return false
+	}
+	// [a] and [b] both contain values. Compare the values.
+	return bytes.Compare(a.start.Value(), b.start.Value()) < 0
+}
+
 // Marks the heap as closed.
 func (wh *workHeap) Close() {
 	wh.closed = true